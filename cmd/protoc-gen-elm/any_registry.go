@@ -0,0 +1,198 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/jalandis/elm-protobuf/pkg/elm"
+	"github.com/jalandis/elm-protobuf/pkg/stringextras"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const anyFile = "google/protobuf/any.proto"
+
+const typeURLPrefix = "type.googleapis.com/"
+
+// anyRegistryEntry - generated message that can be packed in a google.protobuf.Any
+type anyRegistryEntry struct {
+	Variant  elm.VariantName
+	TypeName string
+	Type     elm.Type
+	Decoder  elm.VariableName
+	Encoder  elm.VariableName
+}
+
+func requestsAny(req *pluginpb.CodeGeneratorRequest) bool {
+	for _, inFile := range req.GetProtoFile() {
+		if inFile.GetName() == anyFile {
+			return true
+		}
+	}
+
+	return false
+}
+
+func anyRegistryModuleName(p parameters) string {
+	if p.ModulePrefix == "" {
+		return "AnyRegistry"
+	}
+
+	return p.ModulePrefix + ".AnyRegistry"
+}
+
+// anyRegistryFile - module mapping the type URL of every generated message to its decoder
+func anyRegistryFile(inFiles []*descriptorpb.FileDescriptorProto, r *elm.Registry, p parameters) (*pluginpb.CodeGeneratorResponse_File, error) {
+	moduleName := anyRegistryModuleName(p)
+	r = r.InModule(moduleName)
+
+	var entries []anyRegistryEntry
+	for _, inFile := range inFiles {
+		prefix := ""
+		if inFile.GetPackage() != "" {
+			prefix = inFile.GetPackage() + "."
+		}
+
+		newEntries, err := anyRegistryEntries(prefix, inFile.GetMessageType(), r, p)
+		if err != nil {
+			return nil, fileError(inFile, err)
+		}
+
+		entries = append(entries, newEntries...)
+	}
+
+	var constructors []elm.Constructor
+	var unpackBranches, packBranches []elm.Branch
+	for _, e := range entries {
+		constructors = append(constructors, elm.Constructor{Name: e.Variant, Args: []elm.TypeExpr{e.Type}})
+		unpackBranches = append(unpackBranches, elm.Branch{
+			Pattern: elm.StringLiteral(e.TypeName),
+			Body:    elm.Apply(elm.VariableName("unpackAs"), e.Variant, e.Decoder, elm.VariableName("any")),
+		})
+		packBranches = append(packBranches, elm.Branch{
+			Pattern: elm.ConstructorPattern{Name: e.Variant, Args: []elm.Pattern{elm.VariableName("v")}},
+			Body: elm.Apply(
+				elm.VariableName("Protobuf.pack"),
+				elm.StringLiteral(typeURLPrefix+e.TypeName),
+				e.Encoder,
+				elm.VariableName("v"),
+			),
+		})
+	}
+
+	unknown := elm.Apply(elm.VariantName("Unknown_"), elm.VariableName("any"))
+	constructors = append(constructors, elm.Constructor{Name: "Unknown_", Args: []elm.TypeExpr{elm.Type("Protobuf.Any")}})
+	unpackBranches = append(unpackBranches, elm.Branch{Pattern: elm.VariableName("_"), Body: unknown})
+	packBranches = append(packBranches, elm.Branch{
+		Pattern: elm.ConstructorPattern{Name: "Unknown_", Args: []elm.Pattern{elm.VariableName("any")}},
+		Body:    elm.VariableName("any"),
+	})
+
+	content, err := elm.Print(elm.Module{
+		Name:     moduleName,
+		Exposing: []string{"Message(..)", "pack", "unpack"},
+		Comments: []string{
+			"DO NOT EDIT",
+			"AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER",
+			"https://github.com/tiziano88/elm-protobuf",
+			"type URL registry of the messages generated in the same run",
+		},
+		Imports: append([]elm.Import{
+			{Module: "Protobuf"},
+			{Module: "Json.Decode", Alias: "JD"},
+		}, r.Imports()...),
+		Declarations: []elm.Declaration{
+			elm.CustomTypeDeclaration{
+				Name:         "Message",
+				Constructors: constructors,
+			},
+			elm.ValueDeclaration{
+				Name:       "unpack",
+				Annotation: elm.FunctionType{Args: []elm.TypeExpr{elm.Type("Protobuf.Any")}, Result: elm.Type("Message")},
+				Args:       []elm.Pattern{elm.VariableName("any")},
+				Body: elm.Case{
+					Subject:  elm.Apply(elm.VariableName("Protobuf.anyTypeName"), elm.VariableName("any")),
+					Branches: unpackBranches,
+				},
+			},
+			elm.ValueDeclaration{
+				Name:       "pack",
+				Annotation: elm.FunctionType{Args: []elm.TypeExpr{elm.Type("Message")}, Result: elm.Type("Protobuf.Any")},
+				Args:       []elm.Pattern{elm.VariableName("message")},
+				Body:       elm.Case{Subject: elm.VariableName("message"), Branches: packBranches},
+			},
+			elm.ValueDeclaration{
+				Name: "unpackAs",
+				Annotation: elm.FunctionType{
+					Args: []elm.TypeExpr{
+						elm.FunctionType{Args: []elm.TypeExpr{elm.Type("a")}, Result: elm.Type("Message")},
+						elm.TypeRef{Name: "JD.Decoder", Args: []elm.TypeExpr{elm.Type("a")}},
+						elm.Type("Protobuf.Any"),
+					},
+					Result: elm.Type("Message"),
+				},
+				Args: []elm.Pattern{elm.VariableName("variant"), elm.VariableName("decoder"), elm.VariableName("any")},
+				Body: elm.Case{
+					Subject: elm.Apply(elm.VariableName("Protobuf.unpack"), elm.VariableName("decoder"), elm.VariableName("any")),
+					Branches: []elm.Branch{{
+						Pattern: elm.ConstructorPattern{Name: "Ok", Args: []elm.Pattern{elm.VariableName("v")}},
+						Body:    elm.Apply(elm.VariableName("variant"), elm.VariableName("v")),
+					}, {
+						Pattern: elm.ConstructorPattern{Name: "Err", Args: []elm.Pattern{elm.VariableName("_")}},
+						Body:    unknown,
+					}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.Replace(moduleName, ".", "/", -1) + ".elm"
+	return &pluginpb.CodeGeneratorResponse_File{
+		Name:    &name,
+		Content: &content,
+	}, nil
+}
+
+func anyRegistryEntries(prefix string, messagePbs []*descriptorpb.DescriptorProto, r *elm.Registry, p parameters) ([]anyRegistryEntry, error) {
+	var result []anyRegistryEntry
+	for _, messagePb := range messagePbs {
+		if messagePb.GetOptions().GetMapEntry() {
+			continue
+		}
+
+		if isDeprecated(messagePb.Options) && p.RemoveDeprecated {
+			continue
+		}
+
+		typeName := prefix + messagePb.GetName()
+		symbol, err := r.Lookup("." + typeName)
+		if err != nil {
+			return nil, err
+		}
+
+		var variant []string
+		for _, segment := range strings.Split(typeName, ".") {
+			variant = append(variant, stringextras.UpperCamelCase(segment))
+		}
+
+		result = append(result, anyRegistryEntry{
+			Variant:  elm.VariantName(strings.Join(variant, "_")),
+			TypeName: typeName,
+			Type:     elm.Type(r.Qualify(symbol, string(symbol.Type))),
+			Decoder:  elm.VariableName(r.Qualify(symbol, string(elm.DecoderName(symbol.Type)))),
+			Encoder:  elm.VariableName(r.Qualify(symbol, string(elm.EncoderName(symbol.Type)))),
+		})
+
+		nested, err := anyRegistryEntries(typeName+".", messagePb.GetNestedType(), r, p)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, nested...)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/jalandis/elm-protobuf/pkg/elm"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// sourceComments - source locations of the PB definitions of a file, by SourceCodeInfo path
+type sourceComments map[string]*descriptorpb.SourceCodeInfo_Location
+
+func newSourceComments(inFile *descriptorpb.FileDescriptorProto) sourceComments {
+	result := sourceComments{}
+	for _, location := range inFile.GetSourceCodeInfo().GetLocation() {
+		key := pathKey(location.GetPath())
+		if _, ok := result[key]; !ok {
+			result[key] = location
+		}
+	}
+
+	return result
+}
+
+// at - comments of the PB definition at path, empty without source code info
+func (c sourceComments) at(path []int32) elm.Comment {
+	return elm.NewComment(c[pathKey(path)])
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Field numbers used to build SourceCodeInfo location paths.
+// https://github.com/protocolbuffers/protobuf/blob/main/src/google/protobuf/descriptor.proto
+const (
+	fileMessageTypePath = 4
+	fileEnumTypePath    = 5
+	fileServicePath     = 6
+	fileExtensionPath   = 7
+
+	messageFieldPath      = 2
+	messageNestedTypePath = 3
+	messageEnumTypePath   = 4
+	messageExtensionPath  = 6
+	messageOneofDeclPath  = 8
+
+	enumValuePath = 2
+
+	serviceMethodPath = 2
+)
+
+// definitionError - failure to generate the PB definition found at a SourceCodeInfo path
+type definitionError struct {
+	path []int32
+	err  error
+}
+
+func (e definitionError) Error() string {
+	return e.err.Error()
+}
+
+func appendPath(path []int32, elements ...int32) []int32 {
+	result := make([]int32, 0, len(path)+len(elements))
+	result = append(result, path...)
+	return append(result, elements...)
+}
+
+// fileError - prefixes an error with the file, line and definition it originated from,
+// formatted like a protoc diagnostic, e.g. `foo.proto:12:3: field Foo.bar: ...`
+func fileError(inFile *descriptorpb.FileDescriptorProto, err error) error {
+	defErr, ok := err.(definitionError)
+	if !ok {
+		return fmt.Errorf("%s: %v", inFile.GetName(), err)
+	}
+
+	prefix := inFile.GetName()
+	if span := locationSpan(inFile, defErr.path); len(span) >= 2 {
+		prefix = fmt.Sprintf("%s:%d:%d", prefix, span[0]+1, span[1]+1)
+	}
+
+	if description := describePath(inFile, defErr.path); description != "" {
+		prefix = fmt.Sprintf("%s: %s", prefix, description)
+	}
+
+	return fmt.Errorf("%s: %v", prefix, defErr.err)
+}
+
+// locationSpan - source span of the closest definition enclosing path
+func locationSpan(inFile *descriptorpb.FileDescriptorProto, path []int32) []int32 {
+	spans := map[string][]int32{}
+	for _, location := range inFile.GetSourceCodeInfo().GetLocation() {
+		key := pathKey(location.GetPath())
+		if _, ok := spans[key]; !ok {
+			spans[key] = location.GetSpan()
+		}
+	}
+
+	for i := len(path); i > 0; i-- {
+		if span, ok := spans[pathKey(path[:i])]; ok {
+			return span
+		}
+	}
+
+	return nil
+}
+
+func pathKey(path []int32) string {
+	return strings.Trim(fmt.Sprint(path), "[]")
+}
+
+// describePath - human readable name of the definition at path, e.g. `field Foo.Bar.baz`
+func describePath(inFile *descriptorpb.FileDescriptorProto, path []int32) string {
+	name := strings.TrimPrefix(inFile.GetPackage()+".", ".")
+	kind := ""
+
+	var message *descriptorpb.DescriptorProto
+	var enum *descriptorpb.EnumDescriptorProto
+	var service *descriptorpb.ServiceDescriptorProto
+	for i := 0; i+1 < len(path); i += 2 {
+		index := int(path[i+1])
+		switch {
+		case i == 0 && path[i] == fileMessageTypePath && index < len(inFile.GetMessageType()):
+			message = inFile.GetMessageType()[index]
+			kind, name = "message", name+message.GetName()
+		case i == 0 && path[i] == fileEnumTypePath && index < len(inFile.GetEnumType()):
+			enum = inFile.GetEnumType()[index]
+			kind, name = "enum", name+enum.GetName()
+		case i == 0 && path[i] == fileExtensionPath && index < len(inFile.GetExtension()):
+			kind, name = "extension", name+inFile.GetExtension()[index].GetName()
+		case i == 0 && path[i] == fileServicePath && index < len(inFile.GetService()):
+			service = inFile.GetService()[index]
+			kind, name = "service", name+service.GetName()
+		case message != nil && path[i] == messageNestedTypePath && index < len(message.GetNestedType()):
+			message = message.GetNestedType()[index]
+			kind, name = "message", name+"."+message.GetName()
+		case message != nil && path[i] == messageEnumTypePath && index < len(message.GetEnumType()):
+			enum, message = message.GetEnumType()[index], nil
+			kind, name = "enum", name+"."+enum.GetName()
+		case message != nil && path[i] == messageFieldPath && index < len(message.GetField()):
+			kind, name = "field", name+"."+message.GetField()[index].GetName()
+			message = nil
+		case message != nil && path[i] == messageExtensionPath && index < len(message.GetExtension()):
+			kind, name = "extension", name+"."+message.GetExtension()[index].GetName()
+			message = nil
+		case message != nil && path[i] == messageOneofDeclPath && index < len(message.GetOneofDecl()):
+			kind, name = "oneof", name+"."+message.GetOneofDecl()[index].GetName()
+			message = nil
+		case enum != nil && path[i] == enumValuePath && index < len(enum.GetValue()):
+			kind, name = "enum value", name+"."+enum.GetValue()[index].GetName()
+			enum = nil
+		case service != nil && path[i] == serviceMethodPath && index < len(service.GetMethod()):
+			kind, name = "method", name+"."+service.GetMethod()[index].GetName()
+			service = nil
+		default:
+			return strings.TrimSpace(kind + " " + name)
+		}
+	}
+
+	return strings.TrimSpace(kind + " " + name)
+}
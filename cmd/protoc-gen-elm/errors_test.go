@@ -0,0 +1,221 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// testFile - foo.proto, with a span for each definition:
+//
+//	syntax = "proto2";
+//	package foo;
+//
+//	message Foo {
+//	  optional Bar bar = 1;
+//	  message Bar {}
+//	  extensions 2;
+//	}
+//
+//	enum Kind {
+//	  KIND_UNSPECIFIED = 0;
+//	}
+//
+//	extend Foo {
+//	  optional string baz = 2;
+//	}
+//
+//	service FooService {
+//	  rpc Get(Foo) returns (Foo);
+//	}
+func testFile() *descriptorpb.FileDescriptorProto {
+	location := func(span []int32, path ...int32) *descriptorpb.SourceCodeInfo_Location {
+		return &descriptorpb.SourceCodeInfo_Location{Path: path, Span: span}
+	}
+
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("foo.proto"),
+		Package: proto.String("foo"),
+		Syntax:  proto.String("proto2"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Foo"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("bar"),
+				Number:   proto.Int32(1),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+				TypeName: proto.String(".foo.Foo.Bar"),
+				JsonName: proto.String("bar"),
+			}},
+			NestedType:     []*descriptorpb.DescriptorProto{{Name: proto.String("Bar")}},
+			ExtensionRange: []*descriptorpb.DescriptorProto_ExtensionRange{{Start: proto.Int32(2), End: proto.Int32(3)}},
+		}},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name:  proto.String("Kind"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{{Name: proto.String("KIND_UNSPECIFIED"), Number: proto.Int32(0)}},
+		}},
+		Extension: []*descriptorpb.FieldDescriptorProto{{
+			Name:     proto.String("baz"),
+			Number:   proto.Int32(2),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			Extendee: proto.String(".foo.Foo"),
+			JsonName: proto.String("baz"),
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("FooService"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Get"),
+				InputType:  proto.String(".foo.Foo"),
+				OutputType: proto.String(".foo.Foo"),
+			}},
+		}},
+		SourceCodeInfo: &descriptorpb.SourceCodeInfo{
+			Location: []*descriptorpb.SourceCodeInfo_Location{
+				location([]int32{3, 0, 7, 1}, fileMessageTypePath, 0),
+				location([]int32{4, 2, 23}, fileMessageTypePath, 0, messageFieldPath, 0),
+				location([]int32{5, 2, 16}, fileMessageTypePath, 0, messageNestedTypePath, 0),
+				location([]int32{9, 0, 11, 1}, fileEnumTypePath, 0),
+				location([]int32{10, 2, 24}, fileEnumTypePath, 0, enumValuePath, 0),
+				location([]int32{14, 2, 26}, fileExtensionPath, 0),
+				location([]int32{16, 0, 18, 1}, fileServicePath, 0),
+				location([]int32{17, 2, 29}, fileServicePath, 0, serviceMethodPath, 0),
+			},
+		},
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		parameter string
+		breakFile func(*descriptorpb.FileDescriptorProto)
+		err       string
+	}{
+		{
+			name: "field",
+			breakFile: func(f *descriptorpb.FileDescriptorProto) {
+				f.MessageType[0].Field[0].TypeName = proto.String(".foo.Missing")
+			},
+			err: "foo.proto:5:3: field foo.Foo.bar: unknown type .foo.Missing",
+		},
+		{
+			name: "extension",
+			breakFile: func(f *descriptorpb.FileDescriptorProto) {
+				f.Extension[0].Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+				f.Extension[0].TypeName = proto.String(".foo.Missing")
+			},
+			err: "foo.proto:15:3: extension foo.baz: unknown type .foo.Missing",
+		},
+		{
+			name:      "method",
+			parameter: "services=http",
+			breakFile: func(f *descriptorpb.FileDescriptorProto) {
+				f.Service[0].Method[0].InputType = proto.String(".foo.Missing")
+			},
+			err: "foo.proto:18:3: method foo.FooService.Get: unknown type .foo.Missing",
+		},
+		{
+			name:      "enum",
+			parameter: "remove-deprecated",
+			breakFile: func(f *descriptorpb.FileDescriptorProto) {
+				f.EnumType[0].Value[0].Options = &descriptorpb.EnumValueOptions{Deprecated: proto.Bool(true)}
+			},
+			err: "foo.proto:10:1: enum foo.Kind: enum has no values left to generate",
+		},
+		{
+			name:      "parameter",
+			parameter: "unknown",
+			breakFile: func(*descriptorpb.FileDescriptorProto) {},
+			err:       `failed to parse parameters: unknown parameter: "unknown"`,
+		},
+		{
+			name:      "services parameter",
+			parameter: "services=grpc",
+			breakFile: func(*descriptorpb.FileDescriptorProto) {},
+			err:       `failed to parse parameters: unknown services value: "grpc", expected "http"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file := testFile()
+			test.breakFile(file)
+
+			req := &pluginpb.CodeGeneratorRequest{
+				FileToGenerate: []string{"foo.proto"},
+				ProtoFile:      []*descriptorpb.FileDescriptorProto{file},
+			}
+			if test.parameter != "" {
+				req.Parameter = proto.String(test.parameter)
+			}
+
+			_, err := generate(req)
+			if err == nil {
+				t.Fatal("generate succeeded")
+			}
+
+			if err.Error() != test.err {
+				t.Errorf("generate error = %q, want %q", err.Error(), test.err)
+			}
+		})
+	}
+}
+
+func TestGenerateModuleCollision(t *testing.T) {
+	invoice := func(name string) *descriptorpb.FileDescriptorProto {
+		return &descriptorpb.FileDescriptorProto{
+			Name:        proto.String(name),
+			Package:     proto.String("acme.billing"),
+			Syntax:      proto.String("proto3"),
+			MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("Invoice")}},
+		}
+	}
+
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"a/invoice.proto", "b/invoice.proto"},
+		Parameter:      proto.String("module_from=package"),
+		ProtoFile:      []*descriptorpb.FileDescriptorProto{invoice("a/invoice.proto"), invoice("b/invoice.proto")},
+	}
+
+	_, err := generate(req)
+	if err == nil {
+		t.Fatal("generate succeeded")
+	}
+
+	if expected := "b/invoice.proto: module Acme.Billing.Invoice is already generated from a/invoice.proto"; err.Error() != expected {
+		t.Errorf("generate error = %q, want %q", err.Error(), expected)
+	}
+}
+
+func TestFileError(t *testing.T) {
+	err := errors.New("failure")
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"file", err, "foo.proto: failure"},
+		{"message", definitionError{path: []int32{fileMessageTypePath, 0}, err: err}, "foo.proto:4:1: message foo.Foo: failure"},
+		{"nested message", definitionError{path: []int32{fileMessageTypePath, 0, messageNestedTypePath, 0}, err: err}, "foo.proto:6:3: message foo.Foo.Bar: failure"},
+		{"field", definitionError{path: []int32{fileMessageTypePath, 0, messageFieldPath, 0}, err: err}, "foo.proto:5:3: field foo.Foo.bar: failure"},
+		{"enum", definitionError{path: []int32{fileEnumTypePath, 0}, err: err}, "foo.proto:10:1: enum foo.Kind: failure"},
+		{"enum value", definitionError{path: []int32{fileEnumTypePath, 0, enumValuePath, 0}, err: err}, "foo.proto:11:3: enum value foo.Kind.KIND_UNSPECIFIED: failure"},
+		{"extension", definitionError{path: []int32{fileExtensionPath, 0}, err: err}, "foo.proto:15:3: extension foo.baz: failure"},
+		{"service", definitionError{path: []int32{fileServicePath, 0}, err: err}, "foo.proto:17:1: service foo.FooService: failure"},
+		{"method", definitionError{path: []int32{fileServicePath, 0, serviceMethodPath, 0}, err: err}, "foo.proto:18:3: method foo.FooService.Get: failure"},
+		// Definitions without a span of their own take the span of the closest one enclosing them.
+		{"unknown field", definitionError{path: []int32{fileMessageTypePath, 0, messageFieldPath, 1}, err: err}, "foo.proto:4:1: message foo.Foo: failure"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := fileError(testFile(), test.err).Error(); got != test.want {
+				t.Errorf("fileError = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
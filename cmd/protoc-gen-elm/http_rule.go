@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Field numbers of the google.api.http method option and its HttpRule message.
+// https://github.com/googleapis/googleapis/blob/master/google/api/http.proto
+const (
+	httpRuleExtension = 72295728
+
+	httpRuleGet          = 2
+	httpRulePut          = 3
+	httpRulePost         = 4
+	httpRuleDelete       = 5
+	httpRulePatch        = 6
+	httpRuleBody         = 7
+	httpRuleCustom       = 8
+	httpRuleResponseBody = 12
+
+	customHTTPPatternKind = 1
+	customHTTPPatternPath = 2
+)
+
+// httpRule - HTTP binding of a PB service method, additional bindings excepted
+type httpRule struct {
+	Method       string
+	Path         string
+	Body         string
+	ResponseBody string
+}
+
+// methodHTTPRule - google.api.http option of a method, false without one. The google.api
+// descriptors are not linked in the plugin, so the option is read from the unknown fields.
+func methodHTTPRule(options *descriptorpb.MethodOptions) (httpRule, bool, error) {
+	var result httpRule
+	found := false
+	err := consumeFields(options.ProtoReflect().GetUnknown(), func(number protowire.Number, value []byte) error {
+		if number != httpRuleExtension {
+			return nil
+		}
+
+		found = true
+		return consumeFields(value, result.set)
+	})
+
+	return result, found, err
+}
+
+func (h *httpRule) set(number protowire.Number, value []byte) error {
+	switch number {
+	case httpRuleGet:
+		h.Method, h.Path = "GET", string(value)
+	case httpRulePut:
+		h.Method, h.Path = "PUT", string(value)
+	case httpRulePost:
+		h.Method, h.Path = "POST", string(value)
+	case httpRuleDelete:
+		h.Method, h.Path = "DELETE", string(value)
+	case httpRulePatch:
+		h.Method, h.Path = "PATCH", string(value)
+	case httpRuleBody:
+		h.Body = string(value)
+	case httpRuleResponseBody:
+		h.ResponseBody = string(value)
+	case httpRuleCustom:
+		return consumeFields(value, func(number protowire.Number, value []byte) error {
+			switch number {
+			case customHTTPPatternKind:
+				h.Method = string(value)
+			case customHTTPPatternPath:
+				h.Path = string(value)
+			}
+			return nil
+		})
+	}
+
+	return nil
+}
+
+// consumeFields - calls f with the length delimited fields of a PB message, skipping the others
+func consumeFields(b []byte, f func(protowire.Number, []byte) error) error {
+	for len(b) > 0 {
+		number, wireType, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if wireType != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(number, wireType, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+			continue
+		}
+
+		value, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if err := f(number, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pathSegment - literal part of an HTTP rule path template, or variable bound to a request field
+type pathSegment struct {
+	Literal string
+	// FieldPath - PB names of the fields leading to the variable value, empty for literals
+	FieldPath []string
+	// MultiSegment - the variable matches several path segments, e.g. `{name=shelves/*}`
+	MultiSegment bool
+}
+
+// parsePathTemplate - segments of an HTTP rule path template, e.g. `/v1/{name=shelves/*}:get`
+func parsePathTemplate(template string) ([]pathSegment, error) {
+	if !strings.HasPrefix(template, "/") {
+		return nil, fmt.Errorf("path template \"%s\" must start with \"/\"", template)
+	}
+
+	var result []pathSegment
+	rest := template
+	for rest != "" {
+		start := strings.IndexAny(rest, "{}")
+		if start < 0 {
+			start = len(rest)
+		}
+
+		if literal := rest[:start]; literal != "" {
+			if strings.Contains(literal, "*") {
+				return nil, fmt.Errorf("path template \"%s\" has a wildcard outside of a variable", template)
+			}
+			result = append(result, pathSegment{Literal: literal})
+		}
+
+		rest = rest[start:]
+		if rest == "" {
+			break
+		}
+
+		end := strings.Index(rest, "}")
+		if rest[0] == '}' || end < 0 || strings.Contains(rest[1:end], "{") {
+			return nil, fmt.Errorf("path template \"%s\" has unbalanced braces", template)
+		}
+
+		fieldPath, pattern := rest[1:end], "*"
+		if i := strings.Index(fieldPath, "="); i >= 0 {
+			fieldPath, pattern = fieldPath[:i], fieldPath[i+1:]
+		}
+
+		segment := pathSegment{
+			FieldPath:    strings.Split(fieldPath, "."),
+			MultiSegment: strings.Contains(pattern, "/") || strings.Contains(pattern, "**"),
+		}
+		for _, name := range segment.FieldPath {
+			if name == "" {
+				return nil, fmt.Errorf("path template \"%s\" has an invalid variable \"%s\"", template, fieldPath)
+			}
+		}
+
+		result = append(result, segment)
+		rest = rest[end+1:]
+	}
+
+	return result, nil
+}
@@ -1,14 +1,13 @@
 package main
 
 import (
-	"bytes"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
-	"text/template"
+	"unicode"
 
 	"github.com/jalandis/elm-protobuf/pkg/stringextras"
 
@@ -24,16 +23,36 @@ const version = "0.0.2"
 const docUrl = "https://github.com/jalandis/elm-protobuf"
 
 var excludedFiles = map[string]bool{
-	"google/protobuf/timestamp.proto": true,
-	"google/protobuf/wrappers.proto":  true,
+	"google/protobuf/any.proto":        true,
+	"google/protobuf/duration.proto":   true,
+	"google/protobuf/empty.proto":      true,
+	"google/protobuf/field_mask.proto": true,
+	"google/protobuf/struct.proto":     true,
+	"google/protobuf/timestamp.proto":  true,
+	"google/protobuf/wrappers.proto":   true,
 }
 
 type parameters struct {
 	Version          bool
 	Debug            bool
 	RemoveDeprecated bool
+	IncludeDeps      bool
+	EnumAliases      bool
+	LegacyOneOfNames bool
+	ModuleFrom       moduleSource
+	ModulePrefix     string
+	Services         serviceClients
+	Elm              elm.Options
 }
 
+// moduleSource - what the Elm module name of a PB file is derived from
+type moduleSource int
+
+const (
+	moduleFromFile moduleSource = iota
+	moduleFromPackage
+)
+
 func parseParameters(input *string) (parameters, error) {
 	var result parameters
 	var err error
@@ -43,11 +62,87 @@ func parseParameters(input *string) (parameters, error) {
 	}
 
 	for _, i := range strings.Split(*input, ",") {
-		switch i {
+		key, value := i, ""
+		if index := strings.Index(i, "="); index >= 0 {
+			key, value = i[:index], i[index+1:]
+		}
+
+		switch key {
+		case "module_from":
+			switch value {
+			case "file":
+				result.ModuleFrom = moduleFromFile
+			case "package":
+				result.ModuleFrom = moduleFromPackage
+			default:
+				err = fmt.Errorf("unknown module_from value: \"%s\", expected \"file\" or \"package\"", value)
+			}
+		case "module_prefix":
+			result.ModulePrefix = strings.Trim(value, ".")
+			for _, segment := range strings.Split(result.ModulePrefix, ".") {
+				if !isModuleSegment(segment) {
+					err = fmt.Errorf("invalid module_prefix: \"%s\"", value)
+				}
+			}
+		case "bytes":
+			switch value {
+			case "list":
+				result.Elm.Bytes = elm.BytesAsList
+			case "elm-bytes":
+				result.Elm.Bytes = elm.BytesAsElmBytes
+			default:
+				err = fmt.Errorf("unknown bytes value: \"%s\", expected \"list\" or \"elm-bytes\"", value)
+			}
+		case "struct":
+			switch value {
+			case "value":
+				result.Elm.Struct = elm.StructAsValue
+			case "json":
+				result.Elm.Struct = elm.StructAsJSON
+			default:
+				err = fmt.Errorf("unknown struct value: \"%s\", expected \"value\" or \"json\"", value)
+			}
+		case "int64":
+			switch value {
+			case "int":
+				result.Elm.Int64 = elm.Int64AsInt
+			case "string":
+				result.Elm.Int64 = elm.Int64AsString
+			case "int64":
+				result.Elm.Int64 = elm.Int64AsInt64
+			default:
+				err = fmt.Errorf("unknown int64 value: \"%s\", expected \"int\", \"string\" or \"int64\"", value)
+			}
+		case "strict":
+			switch value {
+			case "":
+				result.Elm.Strict = elm.StrictFields
+			case "reject-unknown":
+				result.Elm.Strict = elm.StrictUnknownFields
+			default:
+				err = fmt.Errorf("unknown strict value: \"%s\", expected none or \"reject-unknown\"", value)
+			}
+		case "services":
+			switch value {
+			case "http":
+				result.Services = httpServiceClients
+			default:
+				err = fmt.Errorf("unknown services value: \"%s\", expected \"http\"", value)
+			}
+		case "binary":
+			result.Elm.Binary = true
+		case "opaque":
+			result.Elm.Opaque = true
 		case "remove-deprecated":
 			result.RemoveDeprecated = true
 		case "debug":
 			result.Debug = true
+		case "include-deps":
+			result.IncludeDeps = true
+		case "enum-aliases":
+			result.EnumAliases = true
+		case "legacy-oneof-names":
+			result.LegacyOneOfNames = true
 		default:
 			err = fmt.Errorf("unknown parameter: \"%s\"", i)
 		}
@@ -56,6 +151,19 @@ func parseParameters(input *string) (parameters, error) {
 	return result, err
 }
 
+func isModuleSegment(in string) bool {
+	for i, r := range in {
+		if i == 0 && !unicode.IsUpper(r) {
+			return false
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return false
+		}
+	}
+
+	return in != ""
+}
+
 func main() {
 	if len(os.Args) == 2 && os.Args[1] == "--version" {
 		fmt.Fprintf(os.Stdout, "%v %v\n", filepath.Base(os.Args[0]), version)
@@ -78,30 +186,76 @@ func main() {
 		log.Fatalf("Could not unmarshal request: %v", err)
 	}
 
+	plugins := (uint64)(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL |
+		pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS)
+	resp := &pluginpb.CodeGeneratorResponse{
+		SupportedFeatures: &plugins,
+		MinimumEdition:    proto.Int32(int32(elm.MinimumEdition)),
+		MaximumEdition:    proto.Int32(int32(elm.MaximumEdition)),
+	}
+
+	files, err := generate(req)
+	if err != nil {
+		// Reported by protoc as a regular compiler diagnostic.
+		resp.Error = proto.String(err.Error())
+	} else {
+		resp.File = files
+	}
+
+	data, err = proto.Marshal(resp)
+	if err != nil {
+		log.Fatalf("Could not marshal response: %v [%v]", err, resp)
+	}
+
+	_, err = os.Stdout.Write(data)
+	if err != nil {
+		log.Fatalf("Could not write response to STDOUT: %v", err)
+	}
+}
+
+func generate(req *pluginpb.CodeGeneratorRequest) ([]*pluginpb.CodeGeneratorResponse_File, error) {
 	parameters, err := parseParameters(req.Parameter)
 	if err != nil {
-		log.Fatalf("Failed to parse parameters: %v", err)
+		return nil, errors.Wrap(err, "failed to parse parameters")
 	}
 
 	if parameters.Debug {
 		// Remove useless source code data.
-		for _, inFile := range req.GetProtoFile() {
+		debugReq := proto.Clone(req).(*pluginpb.CodeGeneratorRequest)
+		for _, inFile := range debugReq.GetProtoFile() {
 			inFile.SourceCodeInfo = nil
 		}
 
-		result, err := proto.Marshal(req)
+		result, err := proto.Marshal(debugReq)
 		if err != nil {
-			log.Fatalf("Failed to marshal request: %v", err)
+			return nil, errors.Wrap(err, "failed to marshal request")
 		}
 
 		log.Printf("Input data: %s", result)
 	}
 
-	plugins := (uint64)(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
-	resp := &pluginpb.CodeGeneratorResponse{
-		SupportedFeatures: &plugins,
+	filesToGenerate := map[string]bool{}
+	for _, name := range req.GetFileToGenerate() {
+		filesToGenerate[name] = true
 	}
+
+	registry := elm.NewRegistry(parameters.Elm)
 	for _, inFile := range req.GetProtoFile() {
+		registry.AddFile(inFile, moduleName(inFile, parameters))
+	}
+
+	var result []*pluginpb.CodeGeneratorResponse_File
+	var generated []*descriptorpb.FileDescriptorProto
+	var failures []string
+	// Generated module names, to the file generating them.
+	modules := map[string]string{}
+	for _, inFile := range req.GetProtoFile() {
+		// Dependencies are only generated on request, their descriptors are
+		// still available for type resolution.
+		if !filesToGenerate[inFile.GetName()] && !parameters.IncludeDeps {
+			continue
+		}
+
 		log.Printf("Processing file %s", inFile.GetName())
 		// Well Known Types.
 		if excludedFiles[inFile.GetName()] {
@@ -109,27 +263,58 @@ func main() {
 			continue
 		}
 
-		name := fileName(inFile.GetName())
-		content, err := templateFile(inFile, parameters)
+		// Files of the same package with the same name in different directories share a module
+		// name with module_from=package.
+		module := moduleName(inFile, parameters)
+		if other, ok := modules[module]; ok {
+			failures = append(failures, fileError(inFile, fmt.Errorf("module %s is already generated from %s", module, other)).Error())
+			continue
+		}
+		modules[module] = inFile.GetName()
+
+		name := fileName(inFile, parameters)
+		content, err := templateFile(inFile, registry, parameters)
 		if err != nil {
-			log.Fatalf("Could not template file: %v", err)
+			failures = append(failures, fileError(inFile, err).Error())
+			continue
+		}
+
+		if parameters.Services == httpServiceClients {
+			services, err := serviceFiles(inFile, registry, parameters)
+			if err != nil {
+				failures = append(failures, fileError(inFile, err).Error())
+				continue
+			}
+
+			result = append(result, services...)
 		}
 
-		resp.File = append(resp.File, &pluginpb.CodeGeneratorResponse_File{
+		if content == "" {
+			log.Printf("Skipping file without definitions")
+			continue
+		}
+
+		result = append(result, &pluginpb.CodeGeneratorResponse_File{
 			Name:    &name,
 			Content: &content,
 		})
+		generated = append(generated, inFile)
 	}
 
-	data, err = proto.Marshal(resp)
-	if err != nil {
-		log.Fatalf("Could not marshal response: %v [%v]", err, resp)
+	if len(failures) > 0 {
+		return nil, errors.New(strings.Join(failures, "\n"))
 	}
 
-	_, err = os.Stdout.Write(data)
-	if err != nil {
-		log.Fatalf("Could not write response to STDOUT: %v", err)
+	if requestsAny(req) {
+		registryFile, err := anyRegistryFile(generated, registry, parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, registryFile)
 	}
+
+	return result, nil
 }
 
 func hasMapEntries(inFile *descriptorpb.FileDescriptorProto) bool {
@@ -156,113 +341,135 @@ func hasMapEntriesInMessage(inMessage *descriptorpb.DescriptorProto) bool {
 	return false
 }
 
-func templateFile(inFile *descriptorpb.FileDescriptorProto, p parameters) (string, error) {
-	t := template.New("t")
+func templateFile(inFile *descriptorpb.FileDescriptorProto, r *elm.Registry, p parameters) (string, error) {
+	r = r.InModule(moduleName(inFile, p))
 
-	t, err := elm.EnumCustomTypeTemplate(t)
+	features, err := elm.FileFeatures(inFile)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to parse enum custom type template")
+		return "", err
 	}
 
-	t, err = elm.OneOfCustomTypeTemplate(t)
+	comments := newSourceComments(inFile)
+
+	topEnums, err := enumsToCustomTypes([]string{}, inFile.GetEnumType(), []int32{fileEnumTypePath}, features, comments, p)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to parse one-of custom type template")
+		return "", err
 	}
 
-	t, err = elm.TypeAliasTemplate(t)
+	messages, err := messages([]string{}, inFile.GetMessageType(), []int32{fileMessageTypePath}, features, comments, r, p)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to parse type alias template")
+		return "", err
 	}
 
-	t, err = t.Parse(`
-{{- define "nested-message" -}}
-{{ template "type-alias" .TypeAlias }}
-{{- range .OneOfCustomTypes }}
-
-
-{{ template "oneof-custom-type" . }}
-{{- end }}
-{{- range .EnumCustomTypes }}
-
-
-{{ template "enum-custom-type" . }}
-{{- end }}
-{{- range .NestedMessages }}
-
-
-{{ template "nested-message" . }}
-{{- end }}
-{{- end -}}
-`)
+	scope := ""
+	if inFile.GetPackage() != "" {
+		scope = "." + inFile.GetPackage()
+	}
 
+	extensions, err := extensions(scope, []string{}, inFile.GetExtension(), inFile.GetMessageType(), []int32{fileExtensionPath}, []int32{fileMessageTypePath}, r, p)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to parse nested PB message template")
+		return "", err
 	}
 
-	t, err = t.Parse(`module {{ .ModuleName }} exposing (..)
-
--- DO NOT EDIT
--- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
--- https://github.com/tiziano88/elm-protobuf
--- source file: {{ .SourceFile }}
-
-import Protobuf exposing (..)
-
-import Json.Decode as JD
-import Json.Encode as JE
-{{- if .ImportDict }}
-import Dict
-{{- end }}
-{{- range .AdditionalImports }}
-import {{ . }} exposing (..)
-{{ end }}
-
-
-uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
-{{- range .TopEnums }}
-
-
-{{ template "enum-custom-type" . }}
-{{- end }}
-{{- range .Messages }}
+	var exposed []string
+	for _, e := range topEnums {
+		exposed = append(exposed, e.Exposed()...)
+	}
+	for _, m := range messages {
+		exposed = append(exposed, m.exposed()...)
+	}
+	for _, e := range extensions {
+		exposed = append(exposed, e.Exposed()...)
+	}
 
+	// Elm modules must expose something.
+	if len(exposed) == 0 {
+		return "", nil
+	}
 
-{{ template "nested-message" . }}
-{{- end }}
-`)
-	if err != nil {
-		return "", err
+	imports := []elm.Import{
+		{Module: "Protobuf", Exposing: []string{".."}},
+		{Module: "Json.Decode", Alias: "JD"},
+		{Module: "Json.Encode", Alias: "JE"},
+	}
+	if p.Elm.Binary {
+		imports = append(imports, elm.Import{Module: "Protobuf.Binary", Alias: "PB"})
+	}
+	if hasMapEntries(inFile) {
+		imports = append(imports, elm.Import{Module: "Dict"})
 	}
 
-	buff := &bytes.Buffer{}
-	if err = t.Execute(buff, struct {
-		SourceFile        string
-		ModuleName        string
-		ImportDict        bool
-		AdditionalImports []string
-		TopEnums          []elm.EnumCustomType
-		Messages          []pbMessage
-	}{
-		SourceFile:        inFile.GetName(),
-		ModuleName:        moduleName(inFile.GetName()),
-		ImportDict:        hasMapEntries(inFile),
-		AdditionalImports: getAdditionalImports(inFile.GetDependency()),
-		TopEnums:          enumsToCustomTypes([]string{}, inFile.GetEnumType(), p),
-		Messages:          messages([]string{}, inFile.GetMessageType(), p),
-	}); err != nil {
-		return "", err
+	var declarations []elm.Declaration
+	for _, e := range topEnums {
+		declarations = append(declarations, e.Declarations()...)
+	}
+	for _, m := range messages {
+		declarations = append(declarations, m.declarations()...)
+	}
+	for _, e := range extensions {
+		declarations = append(declarations, e.Declarations()...)
 	}
 
-	return buff.String(), nil
+	return elm.Print(elm.Module{
+		Name:     moduleName(inFile, p),
+		Exposing: exposed,
+		Comments: []string{
+			"DO NOT EDIT",
+			"AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER",
+			"https://github.com/tiziano88/elm-protobuf",
+			"source file: " + inFile.GetName(),
+		},
+		Imports:      append(imports, r.Imports()...),
+		Declarations: declarations,
+	})
 }
 
 type pbMessage struct {
 	TypeAlias        elm.TypeAlias
+	WrapperTypes     []elm.WrapperType
 	OneOfCustomTypes []elm.OneOfCustomType
 	EnumCustomTypes  []elm.EnumCustomType
 	NestedMessages   []pbMessage
 }
 
+// declarations - top level declarations generated for a PB message and its nested definitions
+func (m pbMessage) declarations() []elm.Declaration {
+	result := m.TypeAlias.Declarations()
+	for _, w := range m.WrapperTypes {
+		result = append(result, w.Declarations()...)
+	}
+	for _, o := range m.OneOfCustomTypes {
+		result = append(result, o.Declarations()...)
+	}
+	for _, e := range m.EnumCustomTypes {
+		result = append(result, e.Declarations()...)
+	}
+	for _, n := range m.NestedMessages {
+		result = append(result, n.declarations()...)
+	}
+
+	return result
+}
+
+// exposed - top level declarations generated for a PB message and its nested definitions
+func (m pbMessage) exposed() []string {
+	result := m.TypeAlias.Exposed()
+	for _, w := range m.WrapperTypes {
+		result = append(result, w.Exposed()...)
+	}
+	for _, o := range m.OneOfCustomTypes {
+		result = append(result, o.Exposed()...)
+	}
+	for _, e := range m.EnumCustomTypes {
+		result = append(result, e.Exposed()...)
+	}
+	for _, n := range m.NestedMessages {
+		result = append(result, n.exposed()...)
+	}
+
+	return result
+}
+
 func isDeprecated(options interface{}) bool {
 	switch v := options.(type) {
 	case *descriptorpb.MessageOptions:
@@ -273,51 +480,93 @@ func isDeprecated(options interface{}) bool {
 		return v != nil && v.Deprecated != nil && *v.Deprecated
 	case *descriptorpb.EnumValueOptions:
 		return v != nil && v.Deprecated != nil && *v.Deprecated
+	case *descriptorpb.ServiceOptions:
+		return v != nil && v.Deprecated != nil && *v.Deprecated
+	case *descriptorpb.MethodOptions:
+		return v != nil && v.Deprecated != nil && *v.Deprecated
 	default:
 		return false
 	}
 }
 
-func enumsToCustomTypes(preface []string, enumPbs []*descriptorpb.EnumDescriptorProto, p parameters) []elm.EnumCustomType {
+func enumsToCustomTypes(preface []string, enumPbs []*descriptorpb.EnumDescriptorProto, path []int32, features elm.Features, c sourceComments, p parameters) ([]elm.EnumCustomType, error) {
 	var result []elm.EnumCustomType
-	for _, enumPb := range enumPbs {
+	for enumIndex, enumPb := range enumPbs {
 		if isDeprecated(enumPb.Options) && p.RemoveDeprecated {
 			continue
 		}
 
+		enumPath := appendPath(path, int32(enumIndex))
+
 		var values []elm.EnumVariant
-		for _, value := range enumPb.GetValue() {
+		canonical := map[int32]int{}
+		for valueIndex, value := range enumPb.GetValue() {
 			if isDeprecated(value.Options) && p.RemoveDeprecated {
 				continue
 			}
 
+			// With allow_alias, later names for a number decode to the first one.
+			if i, ok := canonical[value.GetNumber()]; ok {
+				values[i].Aliases = append(values[i].Aliases, elm.EnumAlias{
+					Name:     elm.EnumAliasConstantName(value.GetName(), preface),
+					JSONName: elm.EnumVariantJSONName(value),
+				})
+				continue
+			}
+
+			canonical[value.GetNumber()] = len(values)
 			values = append(values, elm.EnumVariant{
 				Name:     elm.NestedVariantName(value.GetName(), preface),
 				Number:   elm.ProtobufFieldNumber(value.GetNumber()),
 				JSONName: elm.EnumVariantJSONName(value),
+				Comment:  c.at(appendPath(enumPath, enumValuePath, int32(valueIndex))),
 			})
 		}
 
+		if len(values) == 0 {
+			return nil, definitionError{
+				path: enumPath,
+				err:  errors.New("enum has no values left to generate"),
+			}
+		}
+
 		enumType := elm.NestedType(enumPb.GetName(), preface)
 
-		result = append(result, elm.EnumCustomType{
+		customType := elm.EnumCustomType{
 			Name:                   enumType,
 			Decoder:                elm.DecoderName(enumType),
 			Encoder:                elm.EncoderName(enumType),
 			DefaultVariantVariable: elm.EnumDefaultVariantVariableName(enumType),
 			DefaultVariantValue:    values[0].Name,
 			Variants:               values,
-		})
+			AliasConstants:         p.EnumAliases,
+			UnrecognizedVariant:    elm.EnumUnrecognizedVariantName(enumType),
+			Strict:                 p.Elm.Strict != elm.Lenient,
+			Comment:                c.at(enumPath),
+		}
+
+		// Closed enums have no variant for unknown numbers, which fail decoding.
+		if features.Merge(enumPb.GetOptions().GetFeatures()).Closed() {
+			customType.UnrecognizedVariant = ""
+			customType.Strict = true
+		}
+
+		if p.Elm.Binary {
+			customType.BinaryDecoder = elm.BinaryDecoderName(enumType)
+			customType.BinaryEncoder = elm.BinaryEncoderName(enumType)
+		}
+
+		result = append(result, customType)
 	}
 
-	return result
+	return result, nil
 }
 
-func oneOfsToCustomTypes(preface []string, messagePb *descriptorpb.DescriptorProto, p parameters) []elm.OneOfCustomType {
+func oneOfsToCustomTypes(preface []string, messagePb *descriptorpb.DescriptorProto, path []int32, features elm.Features, c sourceComments, r *elm.Registry, p parameters) ([]elm.OneOfCustomType, error) {
 	var result []elm.OneOfCustomType
 
 	if isDeprecated(messagePb.Options) && p.RemoveDeprecated {
-		return result
+		return result, nil
 	}
 
 	for oneofIndex, oneOfPb := range messagePb.GetOneofDecl() {
@@ -327,7 +576,7 @@ func oneOfsToCustomTypes(preface []string, messagePb *descriptorpb.DescriptorPro
 		}
 
 		var variants []elm.OneOfVariant
-		for _, inField := range messagePb.GetField() {
+		for fieldIndex, inField := range messagePb.GetField() {
 			if isDeprecated(inField.Options) && p.RemoveDeprecated {
 				continue
 			}
@@ -336,25 +585,72 @@ func oneOfsToCustomTypes(preface []string, messagePb *descriptorpb.DescriptorPro
 				continue
 			}
 
-			variants = append(variants, elm.OneOfVariant{
-				Name:     elm.NestedVariantName(inField.GetName(), preface),
-				JSONName: elm.OneOfVariantJSONName(inField),
-				Type:     elm.BasicFieldType(inField),
-				Decoder:  elm.BasicFieldDecoder(inField),
-				Encoder:  elm.BasicFieldEncoder(inField),
-			})
+			fieldPath := appendPath(path, messageFieldPath, int32(fieldIndex))
+			variant, err := oneOfVariant(preface, delimitedAsGroup(inField, features.FieldFeatures(inField), r), r, p)
+			if err != nil {
+				return nil, definitionError{
+					path: fieldPath,
+					err:  err,
+				}
+			}
+			variant.Comment = c.at(fieldPath)
+
+			variants = append(variants, variant)
 		}
 
-		name := elm.NestedType(oneOfPb.GetName(), preface)
-		result = append(result, elm.OneOfCustomType{
+		name := r.OneOfType(oneOfPb.GetName(), preface)
+		customType := elm.OneOfCustomType{
 			Name:     name,
 			Decoder:  elm.DecoderName(name),
 			Encoder:  elm.EncoderName(name),
 			Variants: variants,
-		})
+			Strict:   p.Elm.Strict != elm.Lenient,
+			Comment:  c.at(appendPath(path, messageOneofDeclPath, int32(oneofIndex))),
+		}
+
+		if p.Elm.Binary {
+			customType.BinaryDecoder = elm.BinaryDecoderName(name)
+			customType.BinaryEncoder = elm.BinaryEncoderName(name)
+		}
+
+		result = append(result, customType)
 	}
 
-	return result
+	return result, nil
+}
+
+func oneOfVariant(preface []string, inField *descriptorpb.FieldDescriptorProto, r *elm.Registry, p parameters) (elm.OneOfVariant, error) {
+	fieldType, err := elm.BasicFieldType(r, inField)
+	if err != nil {
+		return elm.OneOfVariant{}, err
+	}
+
+	decoder, err := elm.BasicFieldDecoder(r, inField)
+	if err != nil {
+		return elm.OneOfVariant{}, err
+	}
+
+	encoder, err := elm.BasicFieldEncoder(r, inField)
+	if err != nil {
+		return elm.OneOfVariant{}, err
+	}
+
+	var binaryType elm.Expr
+	if p.Elm.Binary {
+		if binaryType, err = elm.BasicFieldBinaryType(r, inField); err != nil {
+			return elm.OneOfVariant{}, err
+		}
+	}
+
+	return elm.OneOfVariant{
+		Name:       r.OneOfVariantName(inField.GetName(), preface),
+		JSONName:   elm.OneOfVariantJSONName(inField),
+		Number:     elm.ProtobufFieldNumber(inField.GetNumber()),
+		Type:       fieldType,
+		Decoder:    decoder,
+		Encoder:    encoder,
+		BinaryType: binaryType,
+	}, nil
 }
 
 func syntheticFieldForOneOfIndex(messagePb *descriptorpb.DescriptorProto, oneofIndex int32) *descriptorpb.FieldDescriptorProto {
@@ -366,15 +662,34 @@ func syntheticFieldForOneOfIndex(messagePb *descriptorpb.DescriptorProto, oneofI
 	return nil
 }
 
-func messages(preface []string, messagePbs []*descriptorpb.DescriptorProto, p parameters) []pbMessage {
+func fieldIndex(messagePb *descriptorpb.DescriptorProto, fieldPb *descriptorpb.FieldDescriptorProto) int32 {
+	for i, field := range messagePb.GetField() {
+		if field == fieldPb {
+			return int32(i)
+		}
+	}
+	return -1
+}
+
+func messages(preface []string, messagePbs []*descriptorpb.DescriptorProto, path []int32, features elm.Features, c sourceComments, r *elm.Registry, p parameters) ([]pbMessage, error) {
 	var result []pbMessage
-	for _, messagePb := range messagePbs {
+	for messageIndex, messagePb := range messagePbs {
 		if isDeprecated(messagePb.Options) && p.RemoveDeprecated {
 			continue
 		}
 
+		messagePath := appendPath(path, int32(messageIndex))
+		newPreface := append([]string{messagePb.GetName()}, preface...)
+		messageFeatures := features.Merge(messagePb.GetOptions().GetFeatures())
+
+		oneOfPreface := newPreface
+		if p.LegacyOneOfNames {
+			oneOfPreface = []string{}
+		}
+
 		var newFields []elm.TypeAliasField
-		for _, fieldPb := range messagePb.GetField() {
+		var wrapperTypes []elm.WrapperType
+		for fieldIndex, fieldPb := range messagePb.GetField() {
 			if isDeprecated(fieldPb.Options) && p.RemoveDeprecated {
 				continue
 			}
@@ -383,156 +698,367 @@ func messages(preface []string, messagePbs []*descriptorpb.DescriptorProto, p pa
 				continue
 			}
 
-			nested := getNestedType(fieldPb, messagePb)
-			if nested != nil {
-				newFields = append(newFields, elm.TypeAliasField{
-					Name:    elm.FieldName(fieldPb.GetName()),
-					Type:    elm.MapType(nested),
-					Number:  elm.ProtobufFieldNumber(fieldPb.GetNumber()),
-					Encoder: elm.MapEncoder(fieldPb, nested),
-					Decoder: elm.MapDecoder(fieldPb, nested),
-				})
-			} else if isOptional(fieldPb) {
-				newFields = append(newFields, elm.TypeAliasField{
-					Name:    elm.FieldName(fieldPb.GetName()),
-					Type:    elm.MaybeType(elm.BasicFieldType(fieldPb)),
-					Number:  elm.ProtobufFieldNumber(fieldPb.GetNumber()),
-					Encoder: elm.MaybeEncoder(fieldPb),
-					Decoder: elm.MaybeDecoder(fieldPb),
-				})
-			} else if isRepeated(fieldPb) {
-				newFields = append(newFields, elm.TypeAliasField{
-					Name:    elm.FieldName(fieldPb.GetName()),
-					Type:    elm.ListType(elm.BasicFieldType(fieldPb)),
-					Number:  elm.ProtobufFieldNumber(fieldPb.GetNumber()),
-					Encoder: elm.ListEncoder(fieldPb),
-					Decoder: elm.ListDecoder(fieldPb),
-				})
-			} else {
-				newFields = append(newFields, elm.TypeAliasField{
-					Name:    elm.FieldName(fieldPb.GetName()),
-					Type:    elm.BasicFieldType(fieldPb),
-					Number:  elm.ProtobufFieldNumber(fieldPb.GetNumber()),
-					Encoder: elm.RequiredFieldEncoder(fieldPb),
-					Decoder: elm.RequiredFieldDecoder(fieldPb),
-				})
+			fieldPath := appendPath(messagePath, messageFieldPath, int32(fieldIndex))
+			fieldFeatures := messageFeatures.FieldFeatures(fieldPb)
+			newField, err := typeAliasField(delimitedAsGroup(fieldPb, fieldFeatures, r), fieldFeatures, r, p)
+			if err != nil {
+				return nil, definitionError{
+					path: fieldPath,
+					err:  err,
+				}
+			}
+			newField.Comment = c.at(fieldPath)
+
+			// Opaque types are custom types, which unlike type aliases can be recursive.
+			if r.RecursiveField(messagePb, fieldPb) && !p.Elm.Opaque {
+				wrapper := elm.NewWrapperType(r, fieldPb.GetName(), newPreface, newField.Type)
+				wrapperTypes = append(wrapperTypes, wrapper)
+				newField, err = elm.WrapField(newField, wrapper)
+				if err != nil {
+					return nil, definitionError{
+						path: fieldPath,
+						err:  err,
+					}
+				}
 			}
+
+			newFields = append(newFields, newField)
 		}
 
 		for oneofIndex, oneOfPb := range messagePb.GetOneofDecl() {
 			syntheticField := syntheticFieldForOneOfIndex(messagePb, (int32)(oneofIndex))
 			if syntheticField != nil {
-				newFields = append(newFields, elm.TypeAliasField{
-					Name:    elm.FieldName(syntheticField.GetName()),
-					Type:    elm.MaybeType(elm.BasicFieldType(syntheticField)),
-					Encoder: elm.MaybeEncoder(syntheticField),
-					Decoder: elm.MaybeDecoder(syntheticField),
-				})
+				fieldPath := appendPath(messagePath, messageFieldPath, fieldIndex(messagePb, syntheticField))
+				newField, err := syntheticOneOfField(syntheticField, r, p)
+				if err != nil {
+					return nil, definitionError{
+						path: fieldPath,
+						err:  err,
+					}
+				}
+				newField.Comment = c.at(fieldPath)
+
+				if r.RecursiveField(messagePb, syntheticField) && !p.Elm.Opaque {
+					wrapper := elm.NewWrapperType(r, syntheticField.GetName(), newPreface, newField.Type)
+					wrapperTypes = append(wrapperTypes, wrapper)
+					newField, err = elm.WrapField(newField, wrapper)
+					if err != nil {
+						return nil, definitionError{
+							path: fieldPath,
+							err:  err,
+						}
+					}
+				}
+
+				newFields = append(newFields, newField)
 			} else {
-				newFields = append(newFields, elm.TypeAliasField{
+				oneOfType := r.OneOfType(oneOfPb.GetName(), oneOfPreface)
+				newField := elm.TypeAliasField{
 					Name:    elm.FieldName(oneOfPb.GetName()),
-					Type:    elm.OneOfType(oneOfPb.GetName()),
-					Encoder: elm.OneOfEncoder(oneOfPb),
-					Decoder: elm.OneOfDecoder(oneOfPb),
-				})
+					Type:    oneOfType,
+					Encoder: elm.OneOfEncoder(oneOfPb, oneOfType),
+					Decoder: elm.OneOfDecoder(oneOfType),
+					Default: elm.OneOfDefaultValue(oneOfType),
+				}
+
+				if p.Elm.Binary {
+					newField.BinaryDecoder, newField.BinaryEncoder = elm.OneOfBinaryCodec(oneOfPb, oneOfType)
+				}
+
+				newFields = append(newFields, newField)
 			}
 		}
 
-		newPreface := append([]string{messagePb.GetName()}, preface...)
 		name := elm.NestedType(messagePb.GetName(), preface)
+
+		oneOfCustomTypes, err := oneOfsToCustomTypes(oneOfPreface, messagePb, messagePath, messageFeatures, c, r, p)
+		if err != nil {
+			return nil, err
+		}
+
+		enumCustomTypes, err := enumsToCustomTypes(newPreface, messagePb.GetEnumType(), appendPath(messagePath, messageEnumTypePath), messageFeatures, c, p)
+		if err != nil {
+			return nil, err
+		}
+
+		nestedMessages, err := messages(newPreface, messagePb.GetNestedType(), appendPath(messagePath, messageNestedTypePath), messageFeatures, c, r, p)
+		if err != nil {
+			return nil, err
+		}
+
+		typeAlias := elm.TypeAlias{
+			Name:    name,
+			Decoder: elm.DecoderName(name),
+			Encoder: elm.EncoderName(name),
+			Decode:  elm.MessageDecode(r, messagePb),
+			Fields:  newFields,
+			// Extensions are kept as JSON values, binary decoding skips them.
+			Extendable: len(messagePb.GetExtensionRange()) > 0,
+			Comment:    c.at(messagePath),
+		}
+
+		if p.Elm.Binary {
+			typeAlias.BinaryDecoder = elm.BinaryDecoderName(name)
+			typeAlias.BinaryEncoder = elm.BinaryEncoderName(name)
+		}
+
+		if p.Elm.Opaque {
+			typeAlias = elm.OpaqueTypeAlias(typeAlias)
+		}
+
 		result = append(result, pbMessage{
-			TypeAlias: elm.TypeAlias{
-				Name:    name,
-				Decoder: elm.DecoderName(name),
-				Encoder: elm.EncoderName(name),
-				Fields:  newFields,
-			},
-			OneOfCustomTypes: oneOfsToCustomTypes([]string{}, messagePb, p),
-			EnumCustomTypes:  enumsToCustomTypes(newPreface, messagePb.GetEnumType(), p),
-			NestedMessages:   messages(newPreface, messagePb.GetNestedType(), p),
+			TypeAlias:        typeAlias,
+			WrapperTypes:     wrapperTypes,
+			OneOfCustomTypes: oneOfCustomTypes,
+			EnumCustomTypes:  enumCustomTypes,
+			NestedMessages:   nestedMessages,
 		})
 	}
 
-	return result
+	return result, nil
 }
 
-func isOptional(inField *descriptorpb.FieldDescriptorProto) bool {
-	return inField.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL &&
-		inField.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
-}
+// extensions - accessors of the extensions declared in a PB file or message and its nested messages,
+// custom options extending descriptor.proto excepted
+func extensions(
+	scope string,
+	preface []string,
+	extensionPbs []*descriptorpb.FieldDescriptorProto,
+	messagePbs []*descriptorpb.DescriptorProto,
+	extensionPath []int32,
+	messagePath []int32,
+	r *elm.Registry,
+	p parameters,
+) ([]elm.Extension, error) {
+	var result []elm.Extension
+	for extensionIndex, extensionPb := range extensionPbs {
+		if isDeprecated(extensionPb.Options) && p.RemoveDeprecated {
+			continue
+		}
 
-func isRepeated(inField *descriptorpb.FieldDescriptorProto) bool {
-	return inField.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
-}
+		if strings.HasPrefix(extensionPb.GetExtendee(), ".google.protobuf.") {
+			continue
+		}
 
-func getLocalType(fullyQualifiedTypeName string) string {
-	splitName := strings.Split(fullyQualifiedTypeName, ".")
-	return splitName[len(splitName)-1]
-}
+		extension, err := elm.NewExtension(r, scope, preface, extensionPb)
+		if err != nil {
+			return nil, definitionError{
+				path: appendPath(extensionPath, int32(extensionIndex)),
+				err:  err,
+			}
+		}
+
+		result = append(result, extension)
+	}
 
-func getNestedType(inField *descriptorpb.FieldDescriptorProto, inMessage *descriptorpb.DescriptorProto) *descriptorpb.DescriptorProto {
-	localTypeName := getLocalType(inField.GetTypeName())
-	for _, nested := range inMessage.GetNestedType() {
-		if nested.GetName() == localTypeName && nested.GetOptions().GetMapEntry() {
-			return nested
+	for messageIndex, messagePb := range messagePbs {
+		if isDeprecated(messagePb.Options) && p.RemoveDeprecated {
+			continue
 		}
+
+		path := appendPath(messagePath, int32(messageIndex))
+		nested, err := extensions(
+			scope+"."+messagePb.GetName(),
+			append([]string{messagePb.GetName()}, preface...),
+			messagePb.GetExtension(),
+			messagePb.GetNestedType(),
+			appendPath(path, messageExtensionPath),
+			appendPath(path, messageNestedTypePath),
+			r,
+			p,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, nested...)
 	}
 
-	return nil
+	return result, nil
 }
 
-func fileName(inFilePath string) string {
-	inFileDir, inFileName := filepath.Split(inFilePath)
+func typeAliasField(fieldPb *descriptorpb.FieldDescriptorProto, features elm.Features, r *elm.Registry, p parameters) (elm.TypeAliasField, error) {
+	result := elm.TypeAliasField{
+		Name:   elm.FieldName(fieldPb.GetName()),
+		Number: elm.ProtobufFieldNumber(fieldPb.GetNumber()),
+	}
 
-	trimmed := strings.TrimSuffix(inFileName, ".proto")
-	shortFileName := stringextras.FirstUpper(trimmed)
+	var err error
+	if nested := r.MapEntry(fieldPb); nested != nil {
+		if result.Type, err = elm.MapType(r, nested); err != nil {
+			return result, err
+		}
+		if result.Encoder, err = elm.MapEncoder(r, fieldPb, nested); err != nil {
+			return result, err
+		}
+		if result.Decoder, err = elm.MapDecoder(r, fieldPb, nested); err != nil {
+			return result, err
+		}
+		if result.Default, err = elm.MapDefaultValue(nested); err != nil {
+			return result, err
+		}
+		if p.Elm.Binary {
+			result.BinaryDecoder, result.BinaryEncoder, err = elm.MapBinaryCodec(r, fieldPb, nested)
+		}
+		return result, err
+	}
 
-	fullFileName := ""
-	for _, segment := range strings.Split(inFileDir, "/") {
-		if segment == "" {
-			continue
+	basicType, err := elm.BasicFieldType(r, fieldPb)
+	if err != nil {
+		return result, err
+	}
+
+	if isRepeated(fieldPb) {
+		result.Type = elm.ListType(basicType)
+		result.Default = elm.List{}
+		if result.Encoder, err = elm.ListEncoder(r, fieldPb); err != nil {
+			return result, err
+		}
+		if result.Decoder, err = elm.ListDecoder(r, fieldPb); err != nil {
+			return result, err
+		}
+		if p.Elm.Binary {
+			result.BinaryDecoder, result.BinaryEncoder, err = elm.ListBinaryCodec(r, fieldPb, features.Expanded())
+		}
+	} else if isRequired(features) {
+		result.Type = basicType
+		if result.Encoder, err = elm.MandatoryFieldEncoder(r, fieldPb); err != nil {
+			return result, err
+		}
+		if result.Decoder, err = elm.MandatoryFieldDecoder(r, fieldPb); err != nil {
+			return result, err
+		}
+		if p.Elm.Binary {
+			result.BinaryDecoder, result.BinaryEncoder, err = elm.MandatoryFieldBinaryCodec(r, fieldPb)
 		}
+	} else if isOptional(fieldPb) || hasPresence(fieldPb, features) {
+		result.Type = elm.MaybeType(basicType)
+		result.Default = elm.VariantName("Nothing")
+		if result.Encoder, err = elm.MaybeEncoder(r, fieldPb); err != nil {
+			return result, err
+		}
+		if result.Decoder, err = elm.MaybeDecoder(r, fieldPb); err != nil {
+			return result, err
+		}
+		if p.Elm.Binary {
+			result.BinaryDecoder, result.BinaryEncoder, err = elm.MaybeBinaryCodec(r, fieldPb)
+		}
+	} else {
+		result.Type = basicType
+		if result.Encoder, err = elm.RequiredFieldEncoder(r, fieldPb); err != nil {
+			return result, err
+		}
+		if result.Decoder, err = elm.RequiredFieldDecoder(r, fieldPb); err != nil {
+			return result, err
+		}
+		if result.Default, err = elm.FieldDefaultValue(r, fieldPb); err != nil {
+			return result, err
+		}
+		if p.Elm.Binary {
+			result.BinaryDecoder, result.BinaryEncoder, err = elm.RequiredFieldBinaryCodec(r, fieldPb)
+		}
+	}
+
+	return result, err
+}
+
+func syntheticOneOfField(fieldPb *descriptorpb.FieldDescriptorProto, r *elm.Registry, p parameters) (elm.TypeAliasField, error) {
+	basicType, err := elm.BasicFieldType(r, fieldPb)
+	if err != nil {
+		return elm.TypeAliasField{}, err
+	}
 
-		fullFileName += stringextras.FirstUpper(segment) + "/"
+	encoder, err := elm.MaybeEncoder(r, fieldPb)
+	if err != nil {
+		return elm.TypeAliasField{}, err
+	}
+
+	decoder, err := elm.MaybeDecoder(r, fieldPb)
+	if err != nil {
+		return elm.TypeAliasField{}, err
 	}
 
-	return fullFileName + shortFileName + ".elm"
+	result := elm.TypeAliasField{
+		Name:    elm.FieldName(fieldPb.GetName()),
+		Type:    elm.MaybeType(basicType),
+		Encoder: encoder,
+		Decoder: decoder,
+		Default: elm.VariantName("Nothing"),
+	}
+
+	if p.Elm.Binary {
+		result.BinaryDecoder, result.BinaryEncoder, err = elm.MaybeBinaryCodec(r, fieldPb)
+	}
+
+	return result, err
 }
 
-func moduleName(inFilePath string) string {
-	inFileDir, inFileName := filepath.Split(inFilePath)
+func isOptional(inField *descriptorpb.FieldDescriptorProto) bool {
+	return inField.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL &&
+		(inField.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE ||
+			inField.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP)
+}
 
-	trimmed := strings.TrimSuffix(inFileName, ".proto")
-	shortModuleName := stringextras.FirstUpper(trimmed)
+// delimitedAsGroup - message fields with DELIMITED encoding in editions are proto2 groups on the
+// wire, map fields excepted
+func delimitedAsGroup(inField *descriptorpb.FieldDescriptorProto, features elm.Features, r *elm.Registry) *descriptorpb.FieldDescriptorProto {
+	if features.MessageEncoding != descriptorpb.FeatureSet_DELIMITED ||
+		inField.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE ||
+		r.MapEntry(inField) != nil {
+		return inField
+	}
 
-	fullModuleName := ""
-	for _, segment := range strings.Split(inFileDir, "/") {
+	result := proto.Clone(inField).(*descriptorpb.FieldDescriptorProto)
+	result.Type = descriptorpb.FieldDescriptorProto_TYPE_GROUP.Enum()
+	return result
+}
+
+func isRepeated(inField *descriptorpb.FieldDescriptorProto) bool {
+	return inField.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+}
+
+// isRequired - proto2 `required` fields, LEGACY_REQUIRED presence in editions
+func isRequired(features elm.Features) bool {
+	return features.FieldPresence == descriptorpb.FeatureSet_LEGACY_REQUIRED
+}
+
+// hasPresence - fields with explicit presence are absent unless set, those with a declared
+// default take it instead
+func hasPresence(inField *descriptorpb.FieldDescriptorProto, features elm.Features) bool {
+	return features.FieldPresence == descriptorpb.FeatureSet_EXPLICIT && inField.DefaultValue == nil
+}
+
+// moduleSegments - Elm module name of a PB file, split on "."
+func moduleSegments(inFile *descriptorpb.FileDescriptorProto, p parameters) []string {
+	var result []string
+	if p.ModulePrefix != "" {
+		result = strings.Split(p.ModulePrefix, ".")
+	}
+
+	inFileDir, inFileName := filepath.Split(inFile.GetName())
+
+	// Files without a package fall back to the file path.
+	dir := strings.Split(inFileDir, "/")
+	if p.ModuleFrom == moduleFromPackage && inFile.GetPackage() != "" {
+		dir = strings.Split(inFile.GetPackage(), ".")
+	}
+
+	for _, segment := range dir {
 		if segment == "" {
 			continue
 		}
 
-		fullModuleName += stringextras.FirstUpper(segment) + "."
+		result = append(result, stringextras.FirstUpper(segment))
 	}
 
-	return fullModuleName + shortModuleName
+	trimmed := strings.TrimSuffix(inFileName, ".proto")
+	return append(result, stringextras.FirstUpper(trimmed))
 }
 
-func getAdditionalImports(dependencies []string) []string {
-	var additions []string
-	for _, d := range dependencies {
-		if excludedFiles[d] {
-			continue
-		}
-
-		fullModuleName := ""
-		for _, segment := range strings.Split(strings.TrimSuffix(d, ".proto"), "/") {
-			if segment == "" {
-				continue
-			}
-			fullModuleName += stringextras.FirstUpper(segment) + "."
-		}
+func fileName(inFile *descriptorpb.FileDescriptorProto, p parameters) string {
+	return strings.Join(moduleSegments(inFile, p), "/") + ".elm"
+}
 
-		additions = append(additions, strings.TrimSuffix(fullModuleName, "."))
-	}
-	return additions
+func moduleName(inFile *descriptorpb.FileDescriptorProto, p parameters) string {
+	return strings.Join(moduleSegments(inFile, p), ".")
 }
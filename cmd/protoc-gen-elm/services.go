@@ -0,0 +1,327 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jalandis/elm-protobuf/pkg/elm"
+	"github.com/jalandis/elm-protobuf/pkg/stringextras"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// serviceClients - what the services of a PB file generate
+type serviceClients int
+
+const (
+	noServiceClients serviceClients = iota
+	httpServiceClients
+)
+
+// Arguments of the generated client functions, a method of the same name gets an underscore.
+var clientArguments = map[string]bool{
+	"config":  true,
+	"toMsg":   true,
+	"request": true,
+}
+
+// serviceFiles - HTTP client modules of the services of a PB file, one per service named after it
+// within the module of the file, e.g. `Library.LibraryService`
+func serviceFiles(inFile *descriptorpb.FileDescriptorProto, r *elm.Registry, p parameters) ([]*pluginpb.CodeGeneratorResponse_File, error) {
+	comments := newSourceComments(inFile)
+
+	var result []*pluginpb.CodeGeneratorResponse_File
+	for serviceIndex, servicePb := range inFile.GetService() {
+		if isDeprecated(servicePb.Options) && p.RemoveDeprecated {
+			continue
+		}
+
+		moduleName := moduleName(inFile, p) + "." + stringextras.FirstUpper(servicePb.GetName())
+		rs := r.InModule(moduleName).QualifiedRuntime()
+
+		fullName := servicePb.GetName()
+		if inFile.GetPackage() != "" {
+			fullName = inFile.GetPackage() + "." + fullName
+		}
+
+		servicePath := []int32{fileServicePath, int32(serviceIndex)}
+
+		var exposed []string
+		var declarations []elm.Declaration
+		for methodIndex, methodPb := range servicePb.GetMethod() {
+			if isDeprecated(methodPb.Options) && p.RemoveDeprecated {
+				continue
+			}
+
+			// Streams have no representation as a single HTTP response.
+			if methodPb.GetClientStreaming() || methodPb.GetServerStreaming() {
+				log.Printf("Skipping streaming method %s.%s", fullName, methodPb.GetName())
+				continue
+			}
+
+			methodPath := appendPath(servicePath, serviceMethodPath, int32(methodIndex))
+			declaration, err := httpClientFunction(fullName, methodPb, rs)
+			if err != nil {
+				return nil, definitionError{
+					path: methodPath,
+					err:  err,
+				}
+			}
+
+			comment := comments.at(methodPath)
+			declaration.Doc = comment.Doc()
+
+			exposed = append(exposed, string(declaration.Name))
+			declarations = append(declarations, comment.Declarations()...)
+			declarations = append(declarations, declaration)
+		}
+
+		// Services left without methods generate no module.
+		if len(exposed) == 0 {
+			continue
+		}
+
+		header := []string{
+			"DO NOT EDIT",
+			"AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER",
+			"https://github.com/tiziano88/elm-protobuf",
+			"source file: " + inFile.GetName(),
+			"service: " + fullName,
+		}
+		if lines := comments.at(servicePath).Lines(); len(lines) > 0 {
+			header = append(append(header, ""), lines...)
+		}
+
+		content, err := elm.Print(elm.Module{
+			Name:     moduleName,
+			Exposing: exposed,
+			Comments: header,
+			Imports: append([]elm.Import{
+				{Module: "Http"},
+				{Module: "Protobuf.Http", Alias: "PH"},
+			}, rs.Imports()...),
+			Declarations: declarations,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		name := strings.Replace(moduleName, ".", "/", -1) + ".elm"
+		result = append(result, &pluginpb.CodeGeneratorResponse_File{
+			Name:    &name,
+			Content: &content,
+		})
+	}
+
+	return result, nil
+}
+
+// httpClientFunction - function sending the request of a method to the endpoint of its
+// google.api.http option, `POST /package.Service/Method` with the whole request as body without one
+func httpClientFunction(service string, methodPb *descriptorpb.MethodDescriptorProto, r *elm.Registry) (elm.ValueDeclaration, error) {
+	rule, ok, err := methodHTTPRule(methodPb.GetOptions())
+	if err != nil {
+		return elm.ValueDeclaration{}, fmt.Errorf("invalid google.api.http option: %v", err)
+	}
+	if !ok {
+		rule = httpRule{Method: "POST", Path: "/" + service + "/" + methodPb.GetName(), Body: "*"}
+	}
+	if rule.Method == "" || rule.Path == "" {
+		return elm.ValueDeclaration{}, fmt.Errorf("google.api.http option has no pattern")
+	}
+
+	request, err := r.Lookup(methodPb.GetInputType())
+	if err != nil {
+		return elm.ValueDeclaration{}, err
+	}
+
+	response, err := r.Lookup(methodPb.GetOutputType())
+	if err != nil {
+		return elm.ValueDeclaration{}, err
+	}
+
+	segments, err := parsePathTemplate(rule.Path)
+	if err != nil {
+		return elm.ValueDeclaration{}, err
+	}
+
+	var path []elm.Expr
+	for _, segment := range segments {
+		if segment.FieldPath == nil {
+			path = append(path, elm.Apply(elm.VariableName("PH.literal"), elm.StringLiteral(segment.Literal)))
+			continue
+		}
+
+		segmentPath, fieldDefault, err := pathVariable(request, segment.FieldPath, r)
+		if err != nil {
+			return elm.ValueDeclaration{}, err
+		}
+
+		variable := elm.VariableName("PH.variable")
+		if segment.MultiSegment {
+			variable = "PH.multiSegmentVariable"
+		}
+		path = append(path, elm.Apply(variable, segmentPath, elm.StringLiteral(fieldDefault)))
+	}
+
+	var body elm.Expr = elm.VariantName("PH.NoBody")
+	switch rule.Body {
+	case "":
+	case "*":
+		body = elm.VariantName("PH.WholeRequest")
+	default:
+		field, err := messageField(request, rule.Body)
+		if err != nil {
+			return elm.ValueDeclaration{}, fmt.Errorf("body: %v", err)
+		}
+		body = elm.Apply(elm.VariantName("PH.RequestField"), elm.StringLiteral(field.GetJsonName()))
+	}
+
+	message := messageAsField(methodPb.GetInputType())
+	requestType, err := elm.BasicFieldType(r, message)
+	if err != nil {
+		return elm.ValueDeclaration{}, err
+	}
+	requestEncoder, err := elm.BasicFieldEncoder(r, message)
+	if err != nil {
+		return elm.ValueDeclaration{}, err
+	}
+
+	message = messageAsField(methodPb.GetOutputType())
+	responseType, err := elm.BasicFieldType(r, message)
+	if err != nil {
+		return elm.ValueDeclaration{}, err
+	}
+	responseDecoder, err := elm.BasicFieldDecoder(r, message)
+	if err != nil {
+		return elm.ValueDeclaration{}, err
+	}
+
+	var decoder elm.Expr = responseDecoder
+	if rule.ResponseBody != "" {
+		field, err := messageField(response, rule.ResponseBody)
+		if err != nil {
+			return elm.ValueDeclaration{}, fmt.Errorf("response_body: %v", err)
+		}
+		decoder = elm.Apply(elm.VariableName("PH.responseBody"), elm.StringLiteral(field.GetJsonName()), responseDecoder)
+	}
+
+	name := elm.FieldName(methodPb.GetName())
+	if clientArguments[string(name)] {
+		name += "_"
+	}
+
+	return elm.ValueDeclaration{
+		Name: name,
+		Annotation: elm.FunctionType{
+			Args: []elm.TypeExpr{
+				elm.Type("PH.Config"),
+				elm.FunctionType{
+					Args: []elm.TypeExpr{elm.TypeRef{
+						Name: "Result",
+						Args: []elm.TypeExpr{elm.Type("Http.Error"), responseType},
+					}},
+					Result: elm.Type("msg"),
+				},
+				requestType,
+			},
+			Result: elm.TypeRef{Name: "Cmd", Args: []elm.TypeExpr{elm.Type("msg")}},
+		},
+		Args: []elm.Pattern{elm.VariableName("config"), elm.VariableName("toMsg"), elm.VariableName("request")},
+		Body: elm.Apply(
+			elm.VariableName("PH.send"),
+			elm.Record{
+				Fields: []elm.RecordField{
+					{Name: "method", Value: elm.StringLiteral(rule.Method)},
+					{Name: "path", Value: elm.List{Items: path}},
+					{Name: "body", Value: body},
+					{Name: "decoder", Value: decoder},
+				},
+				Multiline: true,
+			},
+			elm.VariableName("config"),
+			elm.VariableName("toMsg"),
+			elm.Apply(requestEncoder, elm.VariableName("request")),
+		),
+	}, nil
+}
+
+// messageAsField - singular field of a message type, to reuse the field type and codec lookups
+func messageAsField(typeName string) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+		TypeName: proto.String(typeName),
+	}
+}
+
+// messageField - field of a message by PB name
+func messageField(symbol elm.Symbol, name string) (*descriptorpb.FieldDescriptorProto, error) {
+	for _, field := range symbol.Message.GetField() {
+		if field.GetName() == name {
+			return field, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown field %s of message %s", name, symbol.Message.GetName())
+}
+
+// pathVariable - JSON names of the fields leading to the value of a path variable, and the text
+// of that value when it is the default one, which JSON encoders omit
+func pathVariable(request elm.Symbol, fieldPath []string, r *elm.Registry) (elm.List, string, error) {
+	var jsonPath elm.List
+	symbol := request
+	for i, name := range fieldPath {
+		field, err := messageField(symbol, name)
+		if err != nil {
+			return elm.List{}, "", fmt.Errorf("path variable %s: %v", strings.Join(fieldPath, "."), err)
+		}
+		jsonPath.Items = append(jsonPath.Items, elm.StringLiteral(field.GetJsonName()))
+
+		isMessage := field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE ||
+			field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP
+		if isRepeated(field) || isMessage != (i < len(fieldPath)-1) {
+			return elm.List{}, "", fmt.Errorf(
+				"path variable %s must be a singular scalar or enum field, through singular message fields",
+				strings.Join(fieldPath, "."),
+			)
+		}
+
+		if !isMessage {
+			return jsonPath, fieldDefaultText(field, r), nil
+		}
+
+		if symbol, err = r.Lookup(field.GetTypeName()); err != nil {
+			return elm.List{}, "", err
+		}
+	}
+
+	return jsonPath, "", nil
+}
+
+// fieldDefaultText - default value of a scalar or enum field as a path segment
+func fieldDefaultText(field *descriptorpb.FieldDescriptorProto, r *elm.Registry) string {
+	// Declared bytes defaults are C escaped, while JSON has them in base64.
+	if field.DefaultValue != nil && field.GetType() != descriptorpb.FieldDescriptorProto_TYPE_BYTES {
+		return field.GetDefaultValue()
+	}
+
+	switch field.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING,
+		descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+		return ""
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return "false"
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		symbol, err := r.Lookup(field.GetTypeName())
+		if err != nil || len(symbol.Enum.GetValue()) == 0 {
+			return ""
+		}
+
+		return string(elm.EnumVariantJSONName(symbol.Enum.GetValue()[0]))
+	default:
+		return "0"
+	}
+}
@@ -1,13 +1,30 @@
 module Protobuf exposing
-    ( decode, required, optional, repeated, field
+    ( decode, required, optional, repeated, field, wrapped, mandatory
     , withDefault, intDecoder, fromResult
-    , requiredFieldEncoder, optionalEncoder, repeatedFieldEncoder, numericStringEncoder, mapEntriesFieldEncoder, mapEntries
+    , strictDecode, strictDecodeKnown, strictRequired, strictOptional, strictRepeated, strictMapEntries, strictOneOf
+    , strictKeyedMapEntries, strictBoolMapEntries, strictDecodeExtendable
+    , requiredFieldEncoder, optionalEncoder, repeatedFieldEncoder, numericStringEncoder, mandatoryFieldEncoder, mapEntriesFieldEncoder, mapEntries
+    , keyedMapEntries, keyedMapEntriesFieldEncoder, intKeyFromString, int64KeyFromString, uint64KeyFromString, boolMapEntries, boolMapEntriesFieldEncoder
+    , Extensions, noExtensions, extensions, extensionFields, getExtension, setExtension, getRepeatedExtension, setRepeatedExtension
     , Bytes, bytesFieldDecoder, bytesFieldEncoder
+    , elmBytesFieldDecoder, elmBytesFieldEncoder, emptyBytes, listToElmBytes, requiredBytesFieldEncoder
+    , Int64, int64Zero, int64FromInt, int64ToInt, int64FromString, uint64FromString, int64ToString, compareInt64
+    , int64Decoder, uint64Decoder, int64Encoder, int64StringDecoder, uint64StringDecoder, int64FromBits, uint64FromBits, int64ToBits
     , Timestamp, timestampDecoder, timestampEncoder
+    , Duration, durationDecoder, durationEncoder
+    , Struct, structDecoder, structEncoder
+    , Value(..), valueDecoder, valueEncoder
+    , ListValue, listValueDecoder, listValueEncoder
+    , nullValueDecoder, nullValueEncoder
+    , jsonStructDecoder, jsonValueDecoder, jsonListValueDecoder, jsonValueEncoder
+    , Any, anyDecoder, anyEncoder, anyTypeName, pack, unpack
+    , FieldMask, fieldMaskDecoder, fieldMaskEncoder
+    , Empty(..), emptyDecoder, emptyEncoder
     , intValueDecoder, intValueEncoder
     , stringValueDecoder, stringValueEncoder
     , boolValueDecoder, boolValueEncoder
     , bytesValueDecoder, bytesValueEncoder
+    , elmBytesValueDecoder, elmBytesValueEncoder
     , floatValueDecoder, floatValueEncoder
     )
 
@@ -19,25 +36,92 @@ Buffer compiler](https://github.com/tiziano88/elm-protobuf).
 
 # Decoder Helpers
 
-@docs decode, required, optional, repeated, field
+@docs decode, required, optional, repeated, field, wrapped, mandatory
 
 @docs withDefault, intDecoder, fromResult
 
+Generated with the `strict` parameter, decoders fail on malformed fields instead of defaulting them.
+
+@docs strictDecode, strictDecodeKnown, strictRequired, strictOptional, strictRepeated, strictMapEntries, strictOneOf
+
+@docs strictKeyedMapEntries, strictBoolMapEntries, strictDecodeExtendable
+
 
 # Encoder Helpers
 
-@docs requiredFieldEncoder, optionalEncoder, repeatedFieldEncoder, numericStringEncoder
+@docs requiredFieldEncoder, optionalEncoder, repeatedFieldEncoder, numericStringEncoder, mandatoryFieldEncoder
+
+
+# Maps
+
+Maps with string or integer keys are represented as a `Dict`, maps with bool keys as a list of
+entries, since `Bool` values can't be `Dict` keys.
+
+@docs mapEntries, mapEntriesFieldEncoder
+
+@docs keyedMapEntries, keyedMapEntriesFieldEncoder, intKeyFromString, int64KeyFromString, uint64KeyFromString
+
+@docs boolMapEntries, boolMapEntriesFieldEncoder
+
+
+# Extensions
+
+Messages with extension ranges keep the JSON values of their extensions, by their `[package.name]`
+key, which the accessors generated for each extension decode and encode.
+
+@docs Extensions, noExtensions, extensions, extensionFields, getExtension, setExtension, getRepeatedExtension, setRepeatedExtension
 
 
 # Bytes
 
+Bytes are encoded as base64 strings. They are represented either as a list of byte values, or as
+`elm/bytes` values when generated with the `bytes=elm-bytes` parameter.
+
 @docs Bytes, bytesFieldDecoder, bytesFieldEncoder
 
+@docs elmBytesFieldDecoder, elmBytesFieldEncoder, emptyBytes, listToElmBytes, requiredBytesFieldEncoder
+
+
+# 64 Bit Integers
+
+64 bit integers are encoded as decimal strings. They are represented as Elm `Int` values by default,
+beyond 2^53 they lose precision. The `int64=string` and `int64=int64` parameters represent them
+exactly, as decimal strings or `Int64` values.
+
+@docs Int64, int64Zero, int64FromInt, int64ToInt, int64FromString, uint64FromString, int64ToString, compareInt64
+
+@docs int64Decoder, uint64Decoder, int64Encoder, int64StringDecoder, uint64StringDecoder
+
+The bits conversions support `Protobuf.Binary`.
+
+@docs int64FromBits, uint64FromBits, int64ToBits
+
 
 # Well Known Types
 
 @docs Timestamp, timestampDecoder, timestampEncoder
 
+@docs Duration, durationDecoder, durationEncoder
+
+@docs Struct, structDecoder, structEncoder
+
+@docs Value, valueDecoder, valueEncoder
+
+@docs ListValue, listValueDecoder, listValueEncoder
+
+@docs nullValueDecoder, nullValueEncoder
+
+Struct, Value and ListValue are represented as raw JSON values when generated with the
+`struct=json` parameter.
+
+@docs jsonStructDecoder, jsonValueDecoder, jsonListValueDecoder, jsonValueEncoder
+
+@docs Any, anyDecoder, anyEncoder, anyTypeName, pack, unpack
+
+@docs FieldMask, fieldMaskDecoder, fieldMaskEncoder
+
+@docs Empty, emptyDecoder, emptyEncoder
+
 @docs intValueDecoder, intValueEncoder
 
 @docs stringValueDecoder, stringValueEncoder
@@ -46,10 +130,16 @@ Buffer compiler](https://github.com/tiziano88/elm-protobuf).
 
 @docs bytesValueDecoder, bytesValueEncoder
 
+@docs elmBytesValueDecoder, elmBytesValueEncoder
+
 @docs floatValueDecoder, floatValueEncoder
 
 -}
 
+import Bitwise
+import Bytes
+import Bytes.Decode as BD
+import Bytes.Encode as BE
 import ISO8601
 import Json.Decode as JD
 import Json.Encode as JE
@@ -71,6 +161,13 @@ required name decoder default d =
     field (withDefault default <| JD.field name decoder) d
 
 
+{-| Decodes a proto2 `required` field, fails when the field is missing.
+-}
+mandatory : String -> JD.Decoder a -> JD.Decoder (a -> b) -> JD.Decoder b
+mandatory name decoder d =
+    field (JD.field name decoder) d
+
+
 {-| Decodes an optional field.
 -}
 optional : String -> JD.Decoder a -> JD.Decoder (Maybe a -> b) -> JD.Decoder b
@@ -91,6 +188,93 @@ mapEntries name valueDecoder d =
     field (withDefault Dict.empty <| JD.field name <| JD.dict valueDecoder) d
 
 
+{-| Decodes a Dict with non string keys, parsed from the keys of the JSON object, e.g. with
+`intKeyFromString`.
+-}
+keyedMapEntries : String -> (String -> Maybe comparable) -> JD.Decoder a -> JD.Decoder (Dict.Dict comparable a -> b) -> JD.Decoder b
+keyedMapEntries name keyFromString valueDecoder d =
+    field (withDefault Dict.empty <| JD.field name <| keyedDictDecoder keyFromString valueDecoder) d
+
+
+{-| Decodes a map with bool keys, as a list of entries with unique keys, `False` first.
+-}
+boolMapEntries : String -> JD.Decoder a -> JD.Decoder (List ( Bool, a ) -> b) -> JD.Decoder b
+boolMapEntries name valueDecoder d =
+    field (withDefault [] <| JD.field name <| boolMapDecoder valueDecoder) d
+
+
+keyedDictDecoder : (String -> Maybe comparable) -> JD.Decoder a -> JD.Decoder (Dict.Dict comparable a)
+keyedDictDecoder keyFromString valueDecoder =
+    JD.map Dict.fromList (keyedPairsDecoder keyFromString valueDecoder)
+
+
+boolMapDecoder : JD.Decoder a -> JD.Decoder (List ( Bool, a ))
+boolMapDecoder valueDecoder =
+    JD.map boolMapFromList (keyedPairsDecoder boolKeyFromString valueDecoder)
+
+
+keyedPairsDecoder : (String -> Maybe k) -> JD.Decoder a -> JD.Decoder (List ( k, a ))
+keyedPairsDecoder keyFromString valueDecoder =
+    JD.keyValuePairs valueDecoder
+        |> JD.andThen
+            (\pairs ->
+                case List.filter (\( key, _ ) -> keyFromString key == Nothing) pairs of
+                    ( key, _ ) :: _ ->
+                        JD.fail ("invalid map key \"" ++ key ++ "\"")
+
+                    [] ->
+                        JD.succeed (List.filterMap (\( key, x ) -> Maybe.map (\k -> ( k, x )) (keyFromString key)) pairs)
+            )
+
+
+{-| Entries of a map with bool keys, the last entry of a key wins.
+-}
+boolMapFromList : List ( Bool, a ) -> List ( Bool, a )
+boolMapFromList entries =
+    List.filterMap
+        (\key ->
+            List.filter (\( k, _ ) -> k == key) entries
+                |> List.reverse
+                |> List.head
+        )
+        [ False, True ]
+
+
+{-| Parses an integer map key.
+-}
+intKeyFromString : String -> Maybe Int
+intKeyFromString =
+    String.toInt
+
+
+{-| Parses a signed 64 bit integer map key as a decimal string, for fields generated with the
+`int64=string` or `int64=int64` parameter.
+-}
+int64KeyFromString : String -> Maybe String
+int64KeyFromString =
+    int64FromString >> Maybe.map int64ToString
+
+
+{-| Same as `int64KeyFromString`, for unsigned keys.
+-}
+uint64KeyFromString : String -> Maybe String
+uint64KeyFromString =
+    uint64FromString >> Maybe.map int64ToString
+
+
+boolKeyFromString : String -> Maybe Bool
+boolKeyFromString v =
+    case v of
+        "true" ->
+            Just True
+
+        "false" ->
+            Just False
+
+        _ ->
+            Nothing
+
+
 {-| Decodes a field.
 -}
 field : JD.Decoder a -> JD.Decoder (a -> b) -> JD.Decoder b
@@ -98,6 +282,158 @@ field =
     JD.map2 (|>)
 
 
+{-| Decodes a field of a recursive message, wrapping its value in the custom type generated for
+the field since type aliases can't be recursive.
+-}
+wrapped : (a -> w) -> (JD.Decoder (a -> b) -> JD.Decoder b) -> JD.Decoder (w -> b) -> JD.Decoder b
+wrapped wrap fieldDecoder d =
+    fieldDecoder (JD.map (\f -> f << wrap) d)
+
+
+{-| Decodes a message, generated with the `strict` parameter. Fails unless the JSON value is an
+object.
+-}
+strictDecode : a -> JD.Decoder a
+strictDecode v =
+    JD.keyValuePairs JD.value |> JD.map (always v)
+
+
+{-| Decodes a message, generated with the `strict=reject-unknown` parameter. Fails unless the JSON
+value is an object with only the given keys.
+-}
+strictDecodeKnown : List String -> a -> JD.Decoder a
+strictDecodeKnown names v =
+    JD.keyValuePairs JD.value
+        |> JD.andThen
+            (\pairs ->
+                case List.filter (\name -> not (List.member name names)) (List.map Tuple.first pairs) of
+                    [] ->
+                        JD.succeed v
+
+                    unknown ->
+                        JD.fail ("unknown fields: " ++ String.join ", " unknown)
+            )
+
+
+{-| Decodes a message with extension ranges, generated with the `strict=reject-unknown` parameter.
+As `strictDecodeKnown`, extension keys are also accepted.
+-}
+strictDecodeExtendable : List String -> a -> JD.Decoder a
+strictDecodeExtendable names v =
+    JD.keyValuePairs JD.value
+        |> JD.andThen
+            (\pairs ->
+                case List.filter (\name -> not (List.member name names || isExtensionKey name)) (List.map Tuple.first pairs) of
+                    [] ->
+                        JD.succeed v
+
+                    unknown ->
+                        JD.fail ("unknown fields: " ++ String.join ", " unknown)
+            )
+
+
+{-| Decodes a required field, generated with the `strict` parameter. An absent or null field is the
+default value, unless the decoder accepts null, a present field must be valid.
+-}
+strictRequired : String -> JD.Decoder a -> a -> JD.Decoder (a -> b) -> JD.Decoder b
+strictRequired name decoder default d =
+    field (strictField name decoder default) d
+
+
+{-| Decodes an optional field, generated with the `strict` parameter.
+-}
+strictOptional : String -> JD.Decoder a -> JD.Decoder (Maybe a -> b) -> JD.Decoder b
+strictOptional name decoder d =
+    field (strictField name (JD.map Just decoder) Nothing) d
+
+
+{-| Decodes a repeated field, generated with the `strict` parameter.
+-}
+strictRepeated : String -> JD.Decoder a -> JD.Decoder (List a -> b) -> JD.Decoder b
+strictRepeated name decoder d =
+    field (strictField name (JD.list decoder) []) d
+
+
+{-| Decodes a Dict, generated with the `strict` parameter.
+-}
+strictMapEntries : String -> JD.Decoder a -> JD.Decoder (Dict.Dict String a -> b) -> JD.Decoder b
+strictMapEntries name valueDecoder d =
+    field (strictField name (JD.dict valueDecoder) Dict.empty) d
+
+
+{-| Decodes a Dict with non string keys, generated with the `strict` parameter.
+-}
+strictKeyedMapEntries : String -> (String -> Maybe comparable) -> JD.Decoder a -> JD.Decoder (Dict.Dict comparable a -> b) -> JD.Decoder b
+strictKeyedMapEntries name keyFromString valueDecoder d =
+    field (strictField name (keyedDictDecoder keyFromString valueDecoder) Dict.empty) d
+
+
+{-| Decodes a map with bool keys, generated with the `strict` parameter.
+-}
+strictBoolMapEntries : String -> JD.Decoder a -> JD.Decoder (List ( Bool, a ) -> b) -> JD.Decoder b
+strictBoolMapEntries name valueDecoder d =
+    field (strictField name (boolMapDecoder valueDecoder) []) d
+
+
+{-| Decodes a one-of, generated with the `strict` parameter. Fails when more than one of its fields
+is set.
+-}
+strictOneOf : a -> List ( String, JD.Decoder a ) -> JD.Decoder a
+strictOneOf default variants =
+    List.foldr
+        (\( name, decoder ) ->
+            JD.map2
+                (\isPresent set ->
+                    if isPresent then
+                        ( name, decoder ) :: set
+
+                    else
+                        set
+                )
+                (present name decoder)
+        )
+        (JD.succeed [])
+        variants
+        |> JD.andThen
+            (\set ->
+                case set of
+                    [] ->
+                        JD.succeed default
+
+                    [ ( name, decoder ) ] ->
+                        JD.field name decoder
+
+                    _ ->
+                        JD.fail ("more than one field of a oneof is set: " ++ String.join ", " (List.map Tuple.first set))
+            )
+
+
+strictField : String -> JD.Decoder a -> a -> JD.Decoder a
+strictField name decoder default =
+    present name decoder
+        |> JD.andThen
+            (\isPresent ->
+                if isPresent then
+                    JD.field name decoder
+
+                else
+                    JD.succeed default
+            )
+
+
+{-| Whether an object has a value for a key. JSON null is a missing value, except for decoders
+accepting it like the one of google.protobuf.Value.
+-}
+present : String -> JD.Decoder a -> JD.Decoder Bool
+present name decoder =
+    JD.oneOf
+        [ JD.field name (JD.null ())
+            |> JD.andThen (\_ -> JD.oneOf [ JD.field name decoder |> JD.map (always True), JD.succeed False ])
+        , JD.field name JD.value |> JD.map (always True)
+        , JD.succeed False
+        ]
+
+
 {-| Provides a default value for a field.
 -}
 withDefault : a -> JD.Decoder a -> JD.Decoder a
@@ -127,6 +463,13 @@ requiredFieldEncoder name encoder default v =
         Just ( name, encoder v )
 
 
+{-| Encodes a proto2 `required` field, always present.
+-}
+mandatoryFieldEncoder : String -> (a -> JE.Value) -> a -> Maybe ( String, JE.Value )
+mandatoryFieldEncoder name encoder v =
+    Just ( name, encoder v )
+
+
 {-| Encodes a repeated field.
 -}
 repeatedFieldEncoder : String -> (a -> JE.Value) -> List a -> Maybe ( String, JE.Value )
@@ -152,26 +495,584 @@ mapEntriesFieldEncoder name valueEncoder v =
             Just ( name, JE.object encodedItems)
 
 
+{-| Encodes a dictionary field with non string keys, e.g. with `String.fromInt`.
+-}
+keyedMapEntriesFieldEncoder : String -> (comparable -> String) -> (a -> JE.Value) -> Dict.Dict comparable a -> Maybe ( String, JE.Value )
+keyedMapEntriesFieldEncoder name keyToString valueEncoder v =
+    if Dict.isEmpty v then
+        Nothing
+
+    else
+        Just ( name, JE.object (List.map (\( key, x ) -> ( keyToString key, valueEncoder x )) (Dict.toList v)) )
+
+
+{-| Encodes a map field with bool keys.
+-}
+boolMapEntriesFieldEncoder : String -> (a -> JE.Value) -> List ( Bool, a ) -> Maybe ( String, JE.Value )
+boolMapEntriesFieldEncoder name valueEncoder v =
+    case boolMapFromList v of
+        [] ->
+            Nothing
+
+        entries ->
+            Just
+                ( name
+                , JE.object
+                    (List.map
+                        (\( key, x ) ->
+                            if key then
+                                ( "true", valueEncoder x )
+
+                            else
+                                ( "false", valueEncoder x )
+                        )
+                        entries
+                    )
+                )
+
+
 {-| Bytes field.
 -}
 type alias Bytes =
     List Int
 
 
-{-| Decodes a bytes field.
-TODO: Implement.
+{-| Decodes a bytes field from a base64 string, both the standard and the URL-safe alphabets are
+accepted, with or without padding.
 -}
 bytesFieldDecoder : JD.Decoder Bytes
 bytesFieldDecoder =
-    JD.succeed []
+    JD.string |> JD.andThen (base64ToBytes >> fromMaybe "could not decode base64 string")
 
 
-{-| Encodes a bytes field.
-TODO: Implement.
+{-| Encodes a bytes field as a standard base64 string.
 -}
 bytesFieldEncoder : Bytes -> JE.Value
-bytesFieldEncoder _ =
-    JE.list JE.int []
+bytesFieldEncoder =
+    bytesToBase64 >> JE.string
+
+
+{-| Decodes a bytes field from a base64 string to `elm/bytes`.
+-}
+elmBytesFieldDecoder : JD.Decoder Bytes.Bytes
+elmBytesFieldDecoder =
+    JD.map listToElmBytes bytesFieldDecoder
+
+
+{-| Encodes a bytes field from `elm/bytes` as a standard base64 string.
+-}
+elmBytesFieldEncoder : Bytes.Bytes -> JE.Value
+elmBytesFieldEncoder =
+    elmBytesToList >> bytesFieldEncoder
+
+
+{-| Empty `elm/bytes` value, the default of a bytes field.
+-}
+emptyBytes : Bytes.Bytes
+emptyBytes =
+    BE.encode (BE.sequence [])
+
+
+{-| Encodes a required `elm/bytes` field, skipped when empty.
+-}
+requiredBytesFieldEncoder : String -> Bytes.Bytes -> Maybe ( String, JE.Value )
+requiredBytesFieldEncoder name v =
+    if Bytes.width v == 0 then
+        Nothing
+
+    else
+        Just ( name, elmBytesFieldEncoder v )
+
+
+{-| Converts a list of byte values to `elm/bytes`, used for declared defaults of bytes fields.
+-}
+listToElmBytes : List Int -> Bytes.Bytes
+listToElmBytes v =
+    BE.encode (BE.sequence (List.map BE.unsignedInt8 v))
+
+
+elmBytesToList : Bytes.Bytes -> List Int
+elmBytesToList v =
+    let
+        step ( remaining, acc ) =
+            if remaining <= 0 then
+                BD.succeed (BD.Done (List.reverse acc))
+
+            else
+                BD.map (\x -> BD.Loop ( remaining - 1, x :: acc )) BD.unsignedInt8
+    in
+    BD.decode (BD.loop ( Bytes.width v, [] ) step) v
+        |> Maybe.withDefault []
+
+
+base64ToBytes : String -> Maybe Bytes
+base64ToBytes v =
+    String.toList v
+        |> List.reverse
+        |> dropPadding
+        |> List.foldl (\c acc -> Maybe.map2 (::) (base64Value c) acc) (Just [])
+        |> Maybe.andThen (\sextets -> sextetsToBytes sextets [])
+
+
+dropPadding : List Char -> List Char
+dropPadding reversed =
+    case reversed of
+        '=' :: rest ->
+            dropPadding rest
+
+        _ ->
+            reversed
+
+
+sextetsToBytes : List Int -> List Int -> Maybe Bytes
+sextetsToBytes sextets acc =
+    case sextets of
+        a :: b :: c :: d :: rest ->
+            sextetsToBytes rest (sextetsToByte 3 c d :: sextetsToByte 2 b c :: sextetsToByte 1 a b :: acc)
+
+        [ a, b, c ] ->
+            Just (List.reverse (sextetsToByte 2 b c :: sextetsToByte 1 a b :: acc))
+
+        [ a, b ] ->
+            Just (List.reverse (sextetsToByte 1 a b :: acc))
+
+        [] ->
+            Just (List.reverse acc)
+
+        _ ->
+            Nothing
+
+
+{-| Byte made of two consecutive sextets, position is the index of the byte within its group of three.
+-}
+sextetsToByte : Int -> Int -> Int -> Int
+sextetsToByte position first second =
+    Bitwise.and 0xFF <|
+        Bitwise.or
+            (Bitwise.shiftLeftBy (2 * position) first)
+            (Bitwise.shiftRightBy (6 - 2 * position) second)
+
+
+base64Value : Char -> Maybe Int
+base64Value c =
+    let
+        code =
+            Char.toCode c
+    in
+    if Char.isUpper c then
+        Just (code - 65)
+
+    else if Char.isLower c then
+        Just (code - 71)
+
+    else if Char.isDigit c then
+        Just (code + 4)
+
+    else if c == '+' || c == '-' then
+        Just 62
+
+    else if c == '/' || c == '_' then
+        Just 63
+
+    else
+        Nothing
+
+
+bytesToBase64 : Bytes -> String
+bytesToBase64 v =
+    bytesToBase64Help v []
+
+
+bytesToBase64Help : Bytes -> List String -> String
+bytesToBase64Help v acc =
+    case v of
+        a :: b :: c :: rest ->
+            bytesToBase64Help rest (base64Chars 4 a b c :: acc)
+
+        [ a, b ] ->
+            String.concat (List.reverse ((base64Chars 3 a b 0 ++ "=") :: acc))
+
+        [ a ] ->
+            String.concat (List.reverse ((base64Chars 2 a 0 0 ++ "==") :: acc))
+
+        [] ->
+            String.concat (List.reverse acc)
+
+
+base64Chars : Int -> Int -> Int -> Int -> String
+base64Chars count a b c =
+    let
+        triple =
+            Bitwise.or (Bitwise.shiftLeftBy 16 a) (Bitwise.or (Bitwise.shiftLeftBy 8 b) c)
+    in
+    [ 18, 12, 6, 0 ]
+        |> List.take count
+        |> List.map (\shift -> base64Char (Bitwise.and 63 (Bitwise.shiftRightBy shift triple)))
+        |> String.fromList
+
+
+base64Char : Int -> Char
+base64Char i =
+    Char.fromCode <|
+        if i < 26 then
+            i + 65
+
+        else if i < 52 then
+            i + 71
+
+        else if i < 62 then
+            i - 4
+
+        else if i == 62 then
+            43
+
+        else
+            47
+
+
+
+-- Extensions.
+
+
+{-| Extension fields of a message, JSON values by their `[package.name]` key.
+-}
+type alias Extensions =
+    Dict.Dict String JE.Value
+
+
+{-| Extensions of a message without any.
+-}
+noExtensions : Extensions
+noExtensions =
+    Dict.empty
+
+
+{-| Decodes the extensions of a message, every key in brackets.
+-}
+extensions : JD.Decoder (Extensions -> b) -> JD.Decoder b
+extensions d =
+    field (JD.map (Dict.filter (\key _ -> isExtensionKey key)) (JD.dict JD.value)) d
+
+
+{-| Encodes the extensions of a message, as fields of its JSON object.
+-}
+extensionFields : Extensions -> List (Maybe ( String, JE.Value ))
+extensionFields v =
+    List.map Just (Dict.toList v)
+
+
+{-| Value of an extension, Nothing when missing or malformed.
+-}
+getExtension : String -> JD.Decoder a -> Extensions -> Maybe a
+getExtension key decoder v =
+    Dict.get key v
+        |> Maybe.andThen (JD.decodeValue decoder >> Result.toMaybe)
+
+
+{-| Sets an extension, removed when Nothing.
+-}
+setExtension : String -> (a -> JE.Value) -> Maybe a -> Extensions -> Extensions
+setExtension key encoder x v =
+    case x of
+        Just value ->
+            Dict.insert key (encoder value) v
+
+        Nothing ->
+            Dict.remove key v
+
+
+{-| Values of a repeated extension, empty when missing or malformed.
+-}
+getRepeatedExtension : String -> JD.Decoder a -> Extensions -> List a
+getRepeatedExtension key decoder v =
+    getExtension key (JD.list decoder) v
+        |> Maybe.withDefault []
+
+
+{-| Sets a repeated extension, removed when empty.
+-}
+setRepeatedExtension : String -> (a -> JE.Value) -> List a -> Extensions -> Extensions
+setRepeatedExtension key encoder x v =
+    if List.isEmpty x then
+        Dict.remove key v
+
+    else
+        Dict.insert key (JE.list encoder x) v
+
+
+isExtensionKey : String -> Bool
+isExtensionKey key =
+    String.startsWith "[" key && String.endsWith "]" key
+
+
+
+-- 64 Bit Integers.
+
+
+{-| Exact 64 bit integer, for int64, uint64, sint64, fixed64 and sfixed64 fields generated with the
+`int64=int64` parameter. Both signed and unsigned fields are covered, from -2^63 to 2^64 - 1.
+
+Values can be compared with `==` and `compareInt64`.
+
+-}
+type Int64
+    = Int64 Bool Int Int
+
+
+{-| Sign, then high and low 32 bits of the magnitude. Zero is never negative, so that equal values
+have the same representation.
+-}
+makeInt64 : Bool -> Int -> Int -> Int64
+makeInt64 negative hi lo =
+    Int64 (negative && (hi /= 0 || lo /= 0)) hi lo
+
+
+{-| Zero, the default value of a 64 bit integer field.
+-}
+int64Zero : Int64
+int64Zero =
+    Int64 False 0 0
+
+
+{-| Converts an Int, exact from -(2^53 - 1) to 2^53 - 1.
+-}
+int64FromInt : Int -> Int64
+int64FromInt v =
+    let
+        magnitude =
+            abs v
+
+        lo =
+            modBy 4294967296 magnitude
+    in
+    makeInt64 (v < 0) (floor (toFloat (magnitude - lo) / 4294967296)) lo
+
+
+{-| Converts to an Int, values beyond 2^53 lose precision.
+-}
+int64ToInt : Int64 -> Int
+int64ToInt (Int64 negative hi lo) =
+    let
+        magnitude =
+            hi * 4294967296 + lo
+    in
+    if negative then
+        negate magnitude
+
+    else
+        magnitude
+
+
+{-| Parses a signed decimal string, e.g. "-9223372036854775808". Fails on anything else than an
+optional minus sign followed by digits, and on values out of the -2^63 to 2^63 - 1 range.
+-}
+int64FromString : String -> Maybe Int64
+int64FromString v =
+    case decimalFromString v of
+        Just ((Int64 negative hi lo) as n) ->
+            if hi < 0x80000000 || (negative && hi == 0x80000000 && lo == 0) then
+                Just n
+
+            else
+                Nothing
+
+        Nothing ->
+            Nothing
+
+
+{-| Parses an unsigned decimal string, e.g. "18446744073709551615". Fails on anything else than
+digits, and on values out of the 0 to 2^64 - 1 range.
+-}
+uint64FromString : String -> Maybe Int64
+uint64FromString v =
+    case decimalFromString v of
+        Just (Int64 True _ _) ->
+            Nothing
+
+        result ->
+            result
+
+
+{-| Parses a decimal string whose magnitude fits in 64 bits.
+-}
+decimalFromString : String -> Maybe Int64
+decimalFromString v =
+    let
+        ( negative, digits ) =
+            if String.startsWith "-" v then
+                ( True, String.dropLeft 1 v )
+
+            else
+                ( False, v )
+    in
+    if digits == "" || not (String.all Char.isDigit digits) then
+        Nothing
+
+    else
+        String.foldl (\c -> Maybe.andThen (magnitudeAppendDigit (Char.toCode c - 48))) (Just ( 0, 0 )) digits
+            |> Maybe.map (\( hi, lo ) -> makeInt64 negative hi lo)
+
+
+magnitudeAppendDigit : Int -> ( Int, Int ) -> Maybe ( Int, Int )
+magnitudeAppendDigit digit ( hi, lo ) =
+    let
+        low =
+            lo * 10 + digit
+
+        carry =
+            floor (toFloat low / 4294967296)
+
+        high =
+            hi * 10 + carry
+    in
+    if high >= 4294967296 then
+        Nothing
+
+    else
+        Just ( high, low - carry * 4294967296 )
+
+
+{-| Prints as a decimal string.
+-}
+int64ToString : Int64 -> String
+int64ToString (Int64 negative hi lo) =
+    if negative then
+        "-" ++ magnitudeToString hi lo ""
+
+    else
+        magnitudeToString hi lo ""
+
+
+magnitudeToString : Int -> Int -> String -> String
+magnitudeToString hi lo acc =
+    let
+        high =
+            floor (toFloat hi / 10)
+
+        rest =
+            (hi - high * 10) * 4294967296 + lo
+
+        low =
+            floor (toFloat rest / 10)
+
+        digits =
+            String.fromInt (rest - low * 10) ++ acc
+    in
+    if high == 0 && low == 0 then
+        digits
+
+    else
+        magnitudeToString high low digits
+
+
+{-| Compares two values.
+-}
+compareInt64 : Int64 -> Int64 -> Order
+compareInt64 (Int64 negativeA hiA loA) (Int64 negativeB hiB loB) =
+    case ( negativeA, negativeB ) of
+        ( False, False ) ->
+            compare ( hiA, loA ) ( hiB, loB )
+
+        ( True, True ) ->
+            compare ( hiB, loB ) ( hiA, loA )
+
+        ( True, False ) ->
+            LT
+
+        ( False, True ) ->
+            GT
+
+
+{-| Converts the low and high 32 bits of a two's complement signed integer.
+-}
+int64FromBits : Int -> Int -> Int64
+int64FromBits lo hi =
+    if Bitwise.and 0x80000000 hi /= 0 then
+        let
+            ( magnitudeLo, magnitudeHi ) =
+                negateBits lo hi
+        in
+        makeInt64 True magnitudeHi magnitudeLo
+
+    else
+        uint64FromBits lo hi
+
+
+{-| Converts the low and high 32 bits of an unsigned integer.
+-}
+uint64FromBits : Int -> Int -> Int64
+uint64FromBits lo hi =
+    makeInt64 False (Bitwise.shiftRightZfBy 0 hi) (Bitwise.shiftRightZfBy 0 lo)
+
+
+{-| Low and high 32 bits of a value, as two's complement.
+-}
+int64ToBits : Int64 -> ( Int, Int )
+int64ToBits (Int64 negative hi lo) =
+    if negative then
+        negateBits lo hi
+
+    else
+        ( lo, hi )
+
+
+negateBits : Int -> Int -> ( Int, Int )
+negateBits lo hi =
+    let
+        low =
+            Bitwise.shiftRightZfBy 0 (Bitwise.complement lo + 1)
+
+        carry =
+            if low == 0 then
+                1
+
+            else
+                0
+    in
+    ( low, Bitwise.shiftRightZfBy 0 (Bitwise.complement hi + carry) )
+
+
+{-| Decodes a signed Int64 from either a decimal string or a number, numbers beyond 2^53 have
+already lost precision when parsed. Values out of the int64 range fail.
+-}
+int64Decoder : JD.Decoder Int64
+int64Decoder =
+    decimalDecoder int64FromString "could not convert string to signed 64 bit integer"
+
+
+{-| Same as `int64Decoder`, for uint64 and fixed64 fields. Negative values fail.
+-}
+uint64Decoder : JD.Decoder Int64
+uint64Decoder =
+    decimalDecoder uint64FromString "could not convert string to unsigned 64 bit integer"
+
+
+decimalDecoder : (String -> Maybe Int64) -> String -> JD.Decoder Int64
+decimalDecoder fromString error =
+    JD.oneOf [ JD.string, JD.map String.fromInt JD.int ]
+        |> JD.andThen (fromString >> fromMaybe error)
+
+
+{-| Encodes an Int64 as a decimal JSON string.
+-}
+int64Encoder : Int64 -> JE.Value
+int64Encoder =
+    int64ToString >> JE.string
+
+
+{-| Decodes a 64 bit integer as a decimal string, for fields generated with the `int64=string`
+parameter. The string is normalized, e.g. "007" decodes to "7".
+-}
+int64StringDecoder : JD.Decoder String
+int64StringDecoder =
+    JD.map int64ToString int64Decoder
+
+
+{-| Same as `int64StringDecoder`, for uint64 and fixed64 fields.
+-}
+uint64StringDecoder : JD.Decoder String
+uint64StringDecoder =
+    JD.map int64ToString uint64Decoder
 
 
 
@@ -207,6 +1108,355 @@ timestampEncoder v =
     JE.string <| ISO8601.toString <| ISO8601.fromPosix v
 
 
+{-| Duration, a signed span of time with nanosecond precision. Seconds and nanos always have the
+same sign.
+-}
+type alias Duration =
+    { seconds : Int
+    , nanos : Int
+    }
+
+
+{-| Decodes a Duration from its JSON representation, e.g. `"1.5s"` or `"-0.000000001s"`.
+-}
+durationDecoder : JD.Decoder Duration
+durationDecoder =
+    JD.string |> JD.andThen (durationFromString >> fromMaybe "could not convert string to duration")
+
+
+{-| Encodes a Duration, with 0, 3, 6 or 9 fractional digits.
+-}
+durationEncoder : Duration -> JE.Value
+durationEncoder v =
+    let
+        sign =
+            if v.seconds < 0 || v.nanos < 0 then
+                "-"
+
+            else
+                ""
+
+        nanos =
+            abs v.nanos
+
+        fraction =
+            if nanos == 0 then
+                ""
+
+            else if modBy 1000000 nanos == 0 then
+                "." ++ String.padLeft 3 '0' (String.fromInt (nanos // 1000000))
+
+            else if modBy 1000 nanos == 0 then
+                "." ++ String.padLeft 6 '0' (String.fromInt (nanos // 1000))
+
+            else
+                "." ++ String.padLeft 9 '0' (String.fromInt nanos)
+    in
+    JE.string <| sign ++ String.fromInt (abs v.seconds) ++ fraction ++ "s"
+
+
+durationFromString : String -> Maybe Duration
+durationFromString v =
+    let
+        ( sign, unsigned ) =
+            if String.startsWith "-" v then
+                ( -1, String.dropLeft 1 v )
+
+            else
+                ( 1, v )
+    in
+    if not (String.endsWith "s" unsigned) then
+        Nothing
+
+    else
+        case String.split "." (String.dropRight 1 unsigned) of
+            [ seconds ] ->
+                Maybe.map (\s -> { seconds = sign * s, nanos = 0 }) (digitsToInt seconds)
+
+            [ seconds, fraction ] ->
+                if String.length fraction > 9 then
+                    Nothing
+
+                else
+                    Maybe.map2 (\s n -> { seconds = sign * s, nanos = sign * n })
+                        (digitsToInt seconds)
+                        (digitsToInt (String.padRight 9 '0' fraction))
+
+            _ ->
+                Nothing
+
+
+digitsToInt : String -> Maybe Int
+digitsToInt v =
+    if v /= "" && String.all Char.isDigit v then
+        String.toInt v
+
+    else
+        Nothing
+
+
+{-| Struct, a JSON object with dynamically typed values.
+-}
+type alias Struct =
+    Dict.Dict String Value
+
+
+{-| Value, a dynamically typed JSON value.
+-}
+type Value
+    = NullValue
+    | NumberValue Float
+    | StringValue String
+    | BoolValue Bool
+    | StructValue Struct
+    | ListValue ListValue
+
+
+{-| ListValue, a JSON array of dynamically typed values.
+-}
+type alias ListValue =
+    List Value
+
+
+{-| Decodes a Struct.
+-}
+structDecoder : JD.Decoder Struct
+structDecoder =
+    JD.dict (JD.lazy (\_ -> valueDecoder))
+
+
+{-| Encodes a Struct.
+-}
+structEncoder : Struct -> JE.Value
+structEncoder =
+    JE.dict identity valueEncoder
+
+
+{-| Decodes a Value.
+-}
+valueDecoder : JD.Decoder Value
+valueDecoder =
+    JD.oneOf
+        [ JD.null NullValue
+        , JD.map NumberValue JD.float
+        , JD.map StringValue JD.string
+        , JD.map BoolValue JD.bool
+        , JD.map ListValue (JD.lazy (\_ -> listValueDecoder))
+        , JD.map StructValue (JD.lazy (\_ -> structDecoder))
+        ]
+
+
+{-| Encodes a Value.
+-}
+valueEncoder : Value -> JE.Value
+valueEncoder v =
+    case v of
+        NullValue ->
+            JE.null
+
+        NumberValue x ->
+            JE.float x
+
+        StringValue x ->
+            JE.string x
+
+        BoolValue x ->
+            JE.bool x
+
+        StructValue x ->
+            structEncoder x
+
+        ListValue x ->
+            listValueEncoder x
+
+
+{-| Decodes a ListValue.
+-}
+listValueDecoder : JD.Decoder ListValue
+listValueDecoder =
+    JD.list (JD.lazy (\_ -> valueDecoder))
+
+
+{-| Encodes a ListValue.
+-}
+listValueEncoder : ListValue -> JE.Value
+listValueEncoder =
+    JE.list valueEncoder
+
+
+{-| Decodes a NullValue, only JSON `null` is accepted.
+-}
+nullValueDecoder : JD.Decoder ()
+nullValueDecoder =
+    JD.null ()
+
+
+{-| Encodes a NullValue as JSON `null`.
+-}
+nullValueEncoder : () -> JE.Value
+nullValueEncoder _ =
+    JE.null
+
+
+{-| Decodes a Struct as a raw JSON object.
+-}
+jsonStructDecoder : JD.Decoder JE.Value
+jsonStructDecoder =
+    JD.dict JD.value |> JD.andThen (\_ -> JD.value)
+
+
+{-| Decodes a Value as raw JSON.
+-}
+jsonValueDecoder : JD.Decoder JE.Value
+jsonValueDecoder =
+    JD.value
+
+
+{-| Decodes a ListValue as a raw JSON array.
+-}
+jsonListValueDecoder : JD.Decoder JE.Value
+jsonListValueDecoder =
+    JD.list JD.value |> JD.andThen (\_ -> JD.value)
+
+
+{-| Encodes a raw JSON Struct, Value or ListValue.
+-}
+jsonValueEncoder : JE.Value -> JE.Value
+jsonValueEncoder =
+    identity
+
+
+{-| Any, a message of any type along with the URL identifying its type. The value holds the JSON
+fields of the packed message, without `@type`.
+-}
+type alias Any =
+    { typeUrl : String
+    , value : JE.Value
+    }
+
+
+{-| Decodes an Any.
+-}
+anyDecoder : JD.Decoder Any
+anyDecoder =
+    JD.map2 Any
+        (JD.field "@type" JD.string)
+        (JD.keyValuePairs JD.value
+            |> JD.map (List.filter (\( key, _ ) -> key /= "@type") >> JE.object)
+        )
+
+
+{-| Encodes an Any.
+-}
+anyEncoder : Any -> JE.Value
+anyEncoder v =
+    case JD.decodeValue (JD.keyValuePairs JD.value) v.value of
+        Ok fields ->
+            JE.object (( "@type", JE.string v.typeUrl ) :: fields)
+
+        Err _ ->
+            JE.object [ ( "@type", JE.string v.typeUrl ) ]
+
+
+{-| Fully qualified PB name of the message packed in an Any, i.e. the type URL after its last `/`.
+-}
+anyTypeName : Any -> String
+anyTypeName v =
+    String.split "/" v.typeUrl
+        |> List.reverse
+        |> List.head
+        |> Maybe.withDefault ""
+
+
+{-| Packs a message into an Any.
+-}
+pack : String -> (a -> JE.Value) -> a -> Any
+pack typeUrl encoder v =
+    { typeUrl = typeUrl
+    , value = encoder v
+    }
+
+
+{-| Decodes the message packed in an Any.
+-}
+unpack : JD.Decoder a -> Any -> Result JD.Error a
+unpack decoder v =
+    JD.decodeValue decoder v.value
+
+
+{-| FieldMask, a set of symbolic field paths in their PB form, e.g. `"user.display_name"`.
+-}
+type alias FieldMask =
+    List String
+
+
+{-| Decodes a FieldMask from its JSON representation, a comma separated string of lowerCamelCase
+paths, e.g. `"user.displayName,photo"`.
+-}
+fieldMaskDecoder : JD.Decoder FieldMask
+fieldMaskDecoder =
+    JD.string
+        |> JD.map
+            (\v ->
+                if v == "" then
+                    []
+
+                else
+                    List.map camelToSnake (String.split "," v)
+            )
+
+
+{-| Encodes a FieldMask, paths are converted to lowerCamelCase and joined with commas.
+-}
+fieldMaskEncoder : FieldMask -> JE.Value
+fieldMaskEncoder v =
+    JE.string (String.join "," (List.map snakeToCamel v))
+
+
+snakeToCamel : String -> String
+snakeToCamel v =
+    case String.split "_" v of
+        first :: rest ->
+            first ++ String.concat (List.map (\word -> String.toUpper (String.left 1 word) ++ String.dropLeft 1 word) rest)
+
+        [] ->
+            v
+
+
+camelToSnake : String -> String
+camelToSnake v =
+    String.toList v
+        |> List.concatMap
+            (\c ->
+                if Char.isUpper c then
+                    [ '_', Char.toLower c ]
+
+                else
+                    [ c ]
+            )
+        |> String.fromList
+
+
+{-| Empty, a message with no fields.
+-}
+type Empty
+    = Empty
+
+
+{-| Decodes an Empty, any JSON object is accepted.
+-}
+emptyDecoder : JD.Decoder Empty
+emptyDecoder =
+    JD.keyValuePairs JD.value |> JD.map (\_ -> Empty)
+
+
+{-| Encodes an Empty as an empty JSON object.
+-}
+emptyEncoder : Empty -> JE.Value
+emptyEncoder _ =
+    JE.object []
+
+
 {-| Turns a Result in to a Decoder
 Taken from <https://github.com/elm-community/json-extra/blob/2.7.0/src/Json/Decode/Extra.elm#L388>
 -}
@@ -302,6 +1552,20 @@ bytesValueEncoder =
     bytesFieldEncoder
 
 
+{-| Decodes a BytesValue to `elm/bytes`.
+-}
+elmBytesValueDecoder : JD.Decoder Bytes.Bytes
+elmBytesValueDecoder =
+    elmBytesFieldDecoder
+
+
+{-| Encodes a BytesValue from `elm/bytes`.
+-}
+elmBytesValueEncoder : Bytes.Bytes -> JE.Value
+elmBytesValueEncoder =
+    elmBytesFieldEncoder
+
+
 {-| Decodes a FloatValue.
 -}
 floatValueDecoder : JD.Decoder Float
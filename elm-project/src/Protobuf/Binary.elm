@@ -0,0 +1,1400 @@
+module Protobuf.Binary exposing
+    ( Decoder, fromBytes, decode, lazy, map, required, optional, repeated, mapEntries, boolMapEntries, field, wrapped
+    , mandatory, defaulted
+    , Variant, oneOf, variant
+    , Encoder, FieldEncoder, toBytes, encode, requiredFieldEncoder, optionalEncoder, repeatedFieldEncoder, mapEntriesFieldEncoder
+    , boolMapEntriesFieldEncoder, mandatoryFieldEncoder, defaultedFieldEncoder, expandedFieldEncoder
+    , fieldEncoder, noFieldEncoder
+    , FieldType, int32, int64, uint32, uint64, sint32, sint64, fixed32, fixed64, sfixed32, sfixed64
+    , exactInt64, exactUint64, exactSint64, exactFixed64, exactSfixed64, decimal, unsignedDecimal
+    , float, double, bool, string, bytes, elmBytes, enum, closedEnum, embedded, group
+    , timestamp, duration, wrapper, struct, value, listValue, nullValue, jsonStruct, jsonValue, jsonListValue
+    , fieldMask, empty
+    )
+
+{-| Runtime library for the Google Protocol Buffers binary wire format.
+
+This is mostly useless on its own, it is meant to support the code generated by the [Elm Protocol
+Buffer compiler](https://github.com/tiziano88/elm-protobuf) with the `binary` parameter.
+
+Unknown fields are skipped when decoding. Repeated scalar fields are encoded packed, unless their
+`repeated_field_encoding` feature is `EXPANDED`, and accepted both packed and unpacked when decoding.
+
+Integers are represented as Elm `Int` values, 64 bit integers beyond 2^53 lose precision unless
+generated with the `int64=string` or `int64=int64` parameter, see the `exact` field types.
+
+
+# Decoder Helpers
+
+@docs Decoder, fromBytes, decode, lazy, map, required, optional, repeated, mapEntries, boolMapEntries, field, wrapped
+
+@docs mandatory, defaulted
+
+@docs Variant, oneOf, variant
+
+
+# Encoder Helpers
+
+@docs Encoder, FieldEncoder, toBytes, encode, requiredFieldEncoder, optionalEncoder, repeatedFieldEncoder, mapEntriesFieldEncoder
+
+@docs boolMapEntriesFieldEncoder, mandatoryFieldEncoder, defaultedFieldEncoder, expandedFieldEncoder
+
+@docs fieldEncoder, noFieldEncoder
+
+
+# Field Types
+
+@docs FieldType, int32, int64, uint32, uint64, sint32, sint64, fixed32, fixed64, sfixed32, sfixed64
+
+@docs exactInt64, exactUint64, exactSint64, exactFixed64, exactSfixed64, decimal, unsignedDecimal
+
+@docs float, double, bool, string, bytes, elmBytes, enum, closedEnum, embedded, group
+
+
+# Well Known Types
+
+@docs timestamp, duration, wrapper, struct, value, listValue, nullValue, jsonStruct, jsonValue, jsonListValue
+
+@docs fieldMask, empty
+
+-}
+
+import Bitwise
+import Bytes exposing (Endianness(..))
+import Bytes.Decode as BD
+import Bytes.Encode as BE
+import Dict
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf
+import Time
+
+
+
+-- WIRE FORMAT
+
+
+type Raw
+    = Varint Int Int
+    | Fixed64 Bytes.Bytes
+    | Delimited Bytes.Bytes
+    | Fixed32 Bytes.Bytes
+    | Group Bytes.Bytes
+    | EndGroup
+
+
+{-| Occurrences of each field of a message, by field number, latest first. Occurrences are tagged
+with their position in the message.
+-}
+type alias Fields =
+    Dict.Dict Int (List ( Int, Raw ))
+
+
+varintDecoder : BD.Decoder { lo : Int, hi : Int, size : Int }
+varintDecoder =
+    BD.loop { lo = 0, hi = 0, size = 0 } varintStep
+
+
+varintStep : { lo : Int, hi : Int, size : Int } -> BD.Decoder (BD.Step { lo : Int, hi : Int, size : Int } { lo : Int, hi : Int, size : Int })
+varintStep state =
+    if state.size >= 10 then
+        BD.fail
+
+    else
+        BD.unsignedInt8
+            |> BD.map
+                (\byte ->
+                    let
+                        payload =
+                            Bitwise.and 0x7F byte
+
+                        shift =
+                            7 * state.size
+
+                        lo =
+                            if shift < 32 then
+                                Bitwise.or state.lo (Bitwise.shiftLeftBy shift payload)
+
+                            else
+                                state.lo
+
+                        hi =
+                            if shift + 7 <= 32 then
+                                state.hi
+
+                            else if shift < 32 then
+                                Bitwise.or state.hi (Bitwise.shiftRightZfBy (32 - shift) payload)
+
+                            else
+                                Bitwise.or state.hi (Bitwise.shiftLeftBy (shift - 32) payload)
+
+                        next =
+                            { lo = lo, hi = hi, size = state.size + 1 }
+                    in
+                    if Bitwise.and 0x80 byte == 0 then
+                        BD.Done next
+
+                    else
+                        BD.Loop next
+                )
+
+
+varintEncoder : Int -> Int -> BE.Encoder
+varintEncoder lo hi =
+    BE.sequence (List.map BE.unsignedInt8 (varintBytes lo hi))
+
+
+varintBytes : Int -> Int -> List Int
+varintBytes lo hi =
+    let
+        low7 =
+            Bitwise.and 0x7F lo
+
+        nextLo =
+            Bitwise.or (Bitwise.shiftRightZfBy 7 lo) (Bitwise.shiftLeftBy 25 hi)
+
+        nextHi =
+            Bitwise.shiftRightZfBy 7 hi
+    in
+    if nextLo == 0 && nextHi == 0 then
+        [ low7 ]
+
+    else
+        Bitwise.or 0x80 low7 :: varintBytes nextLo nextHi
+
+
+unsigned : Int -> Int
+unsigned v =
+    Bitwise.shiftRightZfBy 0 v
+
+
+{-| Low and high 32 bits of an integer, as two's complement.
+-}
+split64 : Int -> ( Int, Int )
+split64 v =
+    let
+        lo =
+            modBy 4294967296 v
+    in
+    ( lo, floor (toFloat (v - lo) / 4294967296) )
+
+
+join64 : Int -> Int -> Int
+join64 lo hi =
+    Bitwise.or 0 hi * 4294967296 + unsigned lo
+
+
+joinUnsigned64 : Int -> Int -> Int
+joinUnsigned64 lo hi =
+    unsigned hi * 4294967296 + unsigned lo
+
+
+rawFieldDecoder : BD.Decoder ( Int, Raw, Int )
+rawFieldDecoder =
+    varintDecoder
+        |> BD.andThen
+            (\tag ->
+                rawValueDecoder (Bitwise.shiftRightZfBy 3 tag.lo) (Bitwise.and 7 tag.lo)
+                    |> BD.map (\( raw, size ) -> ( Bitwise.shiftRightZfBy 3 tag.lo, raw, tag.size + size ))
+            )
+
+
+rawValueDecoder : Int -> Int -> BD.Decoder ( Raw, Int )
+rawValueDecoder number wireType =
+    case wireType of
+        0 ->
+            varintDecoder |> BD.map (\v -> ( Varint v.lo v.hi, v.size ))
+
+        1 ->
+            BD.bytes 8 |> BD.map (\v -> ( Fixed64 v, 8 ))
+
+        2 ->
+            varintDecoder
+                |> BD.andThen
+                    (\length ->
+                        BD.bytes (unsigned length.lo)
+                            |> BD.map (\v -> ( Delimited v, length.size + unsigned length.lo ))
+                    )
+
+        3 ->
+            BD.loop ( 0, [] ) (groupStep number)
+                |> BD.map (\( size, content ) -> ( Group (BE.encode (BE.sequence (List.reverse content))), size ))
+
+        4 ->
+            BD.succeed ( EndGroup, 0 )
+
+        5 ->
+            BD.bytes 4 |> BD.map (\v -> ( Fixed32 v, 4 ))
+
+        _ ->
+            BD.fail
+
+
+{-| Fields of a group up to its end tag, encoded back so that the group decodes like a message.
+-}
+groupStep : Int -> ( Int, List BE.Encoder ) -> BD.Decoder (BD.Step ( Int, List BE.Encoder ) ( Int, List BE.Encoder ))
+groupStep number ( consumed, content ) =
+    rawFieldDecoder
+        |> BD.andThen
+            (\( fieldNumber, raw, size ) ->
+                case raw of
+                    EndGroup ->
+                        if fieldNumber == number then
+                            BD.succeed (BD.Done ( consumed + size, content ))
+
+                        else
+                            BD.fail
+
+                    _ ->
+                        BD.succeed (BD.Loop ( consumed + size, rawFieldEncoder fieldNumber raw :: content ))
+            )
+
+
+rawFieldEncoder : Int -> Raw -> BE.Encoder
+rawFieldEncoder number raw =
+    case raw of
+        Varint lo hi ->
+            BE.sequence [ tagEncoder number 0, varintEncoder lo hi ]
+
+        Fixed64 v ->
+            BE.sequence [ tagEncoder number 1, BE.bytes v ]
+
+        Delimited v ->
+            BE.sequence [ tagEncoder number 2, delimitedEncoder (BE.bytes v) ]
+
+        Group v ->
+            BE.sequence [ tagEncoder number 3, BE.bytes v, tagEncoder number 4 ]
+
+        EndGroup ->
+            BE.sequence []
+
+        Fixed32 v ->
+            BE.sequence [ tagEncoder number 5, BE.bytes v ]
+
+
+fieldsDecoder : Int -> BD.Decoder Fields
+fieldsDecoder width =
+    BD.loop ( 0, 0, Dict.empty ) (fieldsStep width)
+
+
+fieldsStep : Int -> ( Int, Int, Fields ) -> BD.Decoder (BD.Step ( Int, Int, Fields ) Fields)
+fieldsStep width ( position, consumed, fields ) =
+    if consumed == width then
+        BD.succeed (BD.Done fields)
+
+    else if consumed > width then
+        BD.fail
+
+    else
+        rawFieldDecoder
+            |> BD.andThen
+                (\( number, raw, size ) ->
+                    case raw of
+                        EndGroup ->
+                            BD.fail
+
+                        _ ->
+                            BD.succeed
+                                (BD.Loop
+                                    ( position + 1
+                                    , consumed + size
+                                    , Dict.update number (\previous -> Just (( position, raw ) :: Maybe.withDefault [] previous)) fields
+                                    )
+                                )
+                )
+
+
+{-| Values of a packed repeated field.
+-}
+packedDecoder : Int -> Int -> BD.Decoder (List Raw)
+packedDecoder wireType width =
+    BD.loop ( 0, [] )
+        (\( consumed, values ) ->
+            if consumed == width then
+                BD.succeed (BD.Done (List.reverse values))
+
+            else if consumed > width then
+                BD.fail
+
+            else
+                rawValueDecoder 0 wireType
+                    |> BD.map (\( raw, size ) -> BD.Loop ( consumed + size, raw :: values ))
+        )
+
+
+tagEncoder : Int -> Int -> BE.Encoder
+tagEncoder number wireType =
+    varintEncoder (Bitwise.or (Bitwise.shiftLeftBy 3 number) wireType) 0
+
+
+delimitedEncoder : BE.Encoder -> BE.Encoder
+delimitedEncoder v =
+    BE.sequence [ varintEncoder (BE.getWidth v) 0, v ]
+
+
+
+-- DECODER HELPERS
+
+
+{-| Decoder of a message.
+-}
+type Decoder a
+    = Decoder (Fields -> Maybe a)
+
+
+{-| Decodes a message from its binary encoding.
+-}
+fromBytes : Decoder a -> Bytes.Bytes -> Maybe a
+fromBytes (Decoder decoder) v =
+    BD.decode (fieldsDecoder (Bytes.width v)) v
+        |> Maybe.andThen decoder
+
+
+{-| Starts the decoding of a message, fields are decoded in order with the other decoder helpers.
+-}
+decode : a -> Decoder a
+decode v =
+    Decoder (\_ -> Just v)
+
+
+{-| Defers the creation of a decoder, used by recursive messages.
+-}
+lazy : (() -> Decoder a) -> Decoder a
+lazy thunk =
+    Decoder
+        (\fields ->
+            let
+                (Decoder decoder) =
+                    thunk ()
+            in
+            decoder fields
+        )
+
+
+{-| Transforms a decoded value, used by messages generated with the `opaque` parameter.
+-}
+map : (a -> b) -> Decoder a -> Decoder b
+map f (Decoder decoder) =
+    Decoder (\fields -> Maybe.map f (decoder fields))
+
+
+{-| Decodes a field, with the default value of its type when missing.
+-}
+required : Int -> FieldType a -> Decoder (a -> b) -> Decoder b
+required number fieldType =
+    field
+        (Decoder
+            (\fields ->
+                case lastValue fieldType (knownOccurrences fieldType number fields) of
+                    Just v ->
+                        v
+
+                    Nothing ->
+                        defaultValue fieldType
+            )
+        )
+
+
+{-| Decodes a proto2 `required` field, fails when missing.
+-}
+mandatory : Int -> FieldType a -> Decoder (a -> b) -> Decoder b
+mandatory number fieldType =
+    field
+        (Decoder
+            (\fields ->
+                lastValue fieldType (knownOccurrences fieldType number fields)
+                    |> Maybe.andThen identity
+            )
+        )
+
+
+{-| Decodes a field with a declared default value, used when missing.
+-}
+defaulted : Int -> FieldType a -> a -> Decoder (a -> b) -> Decoder b
+defaulted number fieldType default =
+    field
+        (Decoder
+            (\fields ->
+                case lastValue fieldType (knownOccurrences fieldType number fields) of
+                    Just v ->
+                        v
+
+                    Nothing ->
+                        Just default
+            )
+        )
+
+
+{-| Decodes a field, Nothing when missing.
+-}
+optional : Int -> FieldType a -> Decoder (Maybe a -> b) -> Decoder b
+optional number fieldType =
+    field
+        (Decoder
+            (\fields ->
+                case lastValue fieldType (knownOccurrences fieldType number fields) of
+                    Just v ->
+                        Maybe.map Just v
+
+                    Nothing ->
+                        Just Nothing
+            )
+        )
+
+
+{-| Decodes a repeated field, packed or not.
+-}
+repeated : Int -> FieldType a -> Decoder (List a -> b) -> Decoder b
+repeated number (FieldType fieldType) =
+    field
+        (Decoder
+            (\fields ->
+                occurrences number fields
+                    |> List.map
+                        (\raw ->
+                            case raw of
+                                Delimited v ->
+                                    if fieldType.wireType == 2 then
+                                        knownValues (FieldType fieldType) [ raw ]
+
+                                    else
+                                        BD.decode (packedDecoder fieldType.wireType (Bytes.width v)) v
+                                            |> Maybe.andThen (knownValues (FieldType fieldType))
+
+                                _ ->
+                                    knownValues (FieldType fieldType) [ raw ]
+                        )
+                    |> combine
+                    |> Maybe.map List.concat
+            )
+        )
+
+
+{-| Decodes a map field, later entries replace earlier ones with the same key.
+-}
+mapEntries : Int -> FieldType comparable -> FieldType a -> Decoder (Dict.Dict comparable a -> b) -> Decoder b
+mapEntries number keyType valueType =
+    field (mapDecoder Dict.fromList number keyType valueType)
+
+
+{-| Decodes a map field with bool keys, as a list of entries with unique keys, `False` first.
+-}
+boolMapEntries : Int -> FieldType a -> Decoder (List ( Bool, a ) -> b) -> Decoder b
+boolMapEntries number valueType =
+    field (mapDecoder boolMapFromList number bool valueType)
+
+
+{-| Entries with a value dropped by its type are dropped too.
+-}
+mapDecoder : (List ( k, a ) -> m) -> Int -> FieldType k -> FieldType a -> Decoder m
+mapDecoder fromList number keyType ((FieldType valueFieldType) as valueType) =
+    let
+        (Decoder entryDecoder) =
+            decode Tuple.pair
+                |> required 1 keyType
+                |> required 2 valueType
+    in
+    Decoder
+        (\fields ->
+            occurrences number fields
+                |> List.map
+                    (\raw ->
+                        case raw of
+                            Delimited v ->
+                                BD.decode (fieldsDecoder (Bytes.width v)) v
+                                    |> Maybe.andThen
+                                        (\entry ->
+                                            if List.all valueFieldType.known (occurrences 2 entry) then
+                                                Maybe.map Just (entryDecoder entry)
+
+                                            else
+                                                Just Nothing
+                                        )
+
+                            _ ->
+                                Nothing
+                    )
+                |> combine
+                |> Maybe.map (List.filterMap identity >> fromList)
+        )
+
+
+{-| Entries of a map with bool keys, the last entry of a key wins.
+-}
+boolMapFromList : List ( Bool, a ) -> List ( Bool, a )
+boolMapFromList entries =
+    List.filterMap
+        (\key ->
+            List.filter (\( k, _ ) -> k == key) entries
+                |> List.reverse
+                |> List.head
+        )
+        [ False, True ]
+
+
+{-| Decodes a value from the fields of the message, used for one-ofs.
+-}
+field : Decoder a -> Decoder (a -> b) -> Decoder b
+field (Decoder decoder) (Decoder f) =
+    Decoder (\fields -> Maybe.map2 (<|) (f fields) (decoder fields))
+
+
+{-| Decodes a field of a recursive message, wrapping its value in the custom type generated for
+the field.
+-}
+wrapped : (a -> w) -> (Decoder (a -> b) -> Decoder b) -> Decoder (w -> b) -> Decoder b
+wrapped wrap fieldDecoder (Decoder f) =
+    fieldDecoder (Decoder (\fields -> Maybe.map (\g -> g << wrap) (f fields)))
+
+
+{-| Field of a one-of.
+-}
+type Variant a
+    = Variant Int (Raw -> Bool) (Raw -> Maybe a)
+
+
+{-| Decodes a one-of, the variant of the field found last in the message wins.
+-}
+oneOf : a -> List (Variant a) -> Decoder a
+oneOf unspecified variants =
+    Decoder
+        (\fields ->
+            variants
+                |> List.filterMap
+                    (\(Variant number known decoder) ->
+                        Dict.get number fields
+                            |> Maybe.withDefault []
+                            |> List.filter (Tuple.second >> known)
+                            |> List.head
+                            |> Maybe.map (\( position, raw ) -> ( position, decoder raw ))
+                    )
+                |> List.sortBy Tuple.first
+                |> List.reverse
+                |> List.head
+                |> Maybe.map Tuple.second
+                |> Maybe.withDefault (Just unspecified)
+        )
+
+
+{-| Variant of a one-of for a field.
+-}
+variant : Int -> (a -> b) -> FieldType a -> Variant b
+variant number tag (FieldType fieldType) =
+    Variant number fieldType.known (fieldType.decode >> Maybe.map tag)
+
+
+{-| Occurrences of a field, in order.
+-}
+occurrences : Int -> Fields -> List Raw
+occurrences number fields =
+    Dict.get number fields
+        |> Maybe.withDefault []
+        |> List.reverse
+        |> List.map Tuple.second
+
+
+{-| Occurrences of a field, in order, without the values dropped by its type.
+-}
+knownOccurrences : FieldType a -> Int -> Fields -> List Raw
+knownOccurrences (FieldType fieldType) number fields =
+    List.filter fieldType.known (occurrences number fields)
+
+
+{-| Values of a repeated field, without the values dropped by its type.
+-}
+knownValues : FieldType a -> List Raw -> Maybe (List a)
+knownValues (FieldType fieldType) raws =
+    List.filter fieldType.known raws
+        |> List.map fieldType.decode
+        |> combine
+
+
+{-| Value of a non repeated field, the last occurrence wins except for messages which are merged.
+-}
+lastValue : FieldType a -> List Raw -> Maybe (Maybe a)
+lastValue (FieldType fieldType) raws =
+    if fieldType.merge && not (List.isEmpty raws) then
+        raws
+            |> List.filterMap
+                (\raw ->
+                    case raw of
+                        Delimited v ->
+                            Just (BE.bytes v)
+
+                        Group v ->
+                            Just (BE.bytes v)
+
+                        _ ->
+                            Nothing
+                )
+            |> BE.sequence
+            |> BE.encode
+            |> concatenatedRaw fieldType.wireType
+            |> fieldType.decode
+            |> Just
+
+    else
+        List.reverse raws
+            |> List.head
+            |> Maybe.map fieldType.decode
+
+
+concatenatedRaw : Int -> Bytes.Bytes -> Raw
+concatenatedRaw wireType v =
+    if wireType == 3 then
+        Group v
+
+    else
+        Delimited v
+
+
+defaultValue : FieldType a -> Maybe a
+defaultValue (FieldType fieldType) =
+    fieldType.decode <|
+        case fieldType.wireType of
+            0 ->
+                Varint 0 0
+
+            1 ->
+                Fixed64 (BE.encode (BE.sequence [ BE.unsignedInt32 LE 0, BE.unsignedInt32 LE 0 ]))
+
+            5 ->
+                Fixed32 (BE.encode (BE.unsignedInt32 LE 0))
+
+            _ ->
+                Delimited (BE.encode (BE.sequence []))
+
+
+combine : List (Maybe a) -> Maybe (List a)
+combine =
+    List.foldr (Maybe.map2 (::)) (Just [])
+
+
+
+-- ENCODER HELPERS
+
+
+{-| Binary encoding of a message.
+-}
+type alias Encoder =
+    BE.Encoder
+
+
+{-| Binary encoding of the fields of a message.
+-}
+type FieldEncoder
+    = FieldEncoder (List BE.Encoder)
+
+
+{-| Encodes a message to bytes.
+-}
+toBytes : Encoder -> Bytes.Bytes
+toBytes =
+    BE.encode
+
+
+{-| Encodes the fields of a message.
+-}
+encode : List FieldEncoder -> Encoder
+encode fields =
+    BE.sequence (List.concatMap (\(FieldEncoder v) -> v) fields)
+
+
+{-| Encodes a field, omitted when it has the default value of its type.
+-}
+requiredFieldEncoder : Int -> FieldType a -> a -> FieldEncoder
+requiredFieldEncoder number (FieldType fieldType) v =
+    if fieldType.isDefault v then
+        noFieldEncoder
+
+    else
+        fieldEncoder number (FieldType fieldType) v
+
+
+{-| Encodes a proto2 `required` field, always present.
+-}
+mandatoryFieldEncoder : Int -> FieldType a -> a -> FieldEncoder
+mandatoryFieldEncoder =
+    fieldEncoder
+
+
+{-| Encodes a field with a declared default value, omitted when equal to it.
+-}
+defaultedFieldEncoder : Int -> FieldType a -> a -> a -> FieldEncoder
+defaultedFieldEncoder number fieldType default v =
+    if v == default then
+        noFieldEncoder
+
+    else
+        fieldEncoder number fieldType v
+
+
+{-| Encodes an optional field, omitted when Nothing.
+-}
+optionalEncoder : Int -> FieldType a -> Maybe a -> FieldEncoder
+optionalEncoder number fieldType v =
+    case v of
+        Just x ->
+            fieldEncoder number fieldType x
+
+        Nothing ->
+            noFieldEncoder
+
+
+{-| Encodes a repeated field, packed for scalar types.
+-}
+repeatedFieldEncoder : Int -> FieldType a -> List a -> FieldEncoder
+repeatedFieldEncoder number (FieldType fieldType) v =
+    if List.isEmpty v then
+        noFieldEncoder
+
+    else if fieldType.wireType == 2 || fieldType.wireType == 3 then
+        expandedFieldEncoder number (FieldType fieldType) v
+
+    else
+        FieldEncoder [ tagEncoder number 2, delimitedEncoder (BE.sequence (List.map fieldType.encode v)) ]
+
+
+{-| Encodes a repeated field, one tag per value, for fields with `EXPANDED` encoding.
+-}
+expandedFieldEncoder : Int -> FieldType a -> List a -> FieldEncoder
+expandedFieldEncoder number fieldType v =
+    FieldEncoder (List.concatMap (taggedEncoders number fieldType) v)
+
+
+{-| Encodes a map field, one entry message per key.
+-}
+mapEntriesFieldEncoder : Int -> FieldType comparable -> FieldType a -> Dict.Dict comparable a -> FieldEncoder
+mapEntriesFieldEncoder number keyType valueType v =
+    mapFieldEncoder number keyType valueType (Dict.toList v)
+
+
+{-| Encodes a map field with bool keys.
+-}
+boolMapEntriesFieldEncoder : Int -> FieldType a -> List ( Bool, a ) -> FieldEncoder
+boolMapEntriesFieldEncoder number valueType v =
+    mapFieldEncoder number bool valueType (boolMapFromList v)
+
+
+mapFieldEncoder : Int -> FieldType k -> FieldType a -> List ( k, a ) -> FieldEncoder
+mapFieldEncoder number keyType valueType entries =
+    entries
+        |> List.map
+            (\( key, x ) ->
+                BE.sequence
+                    [ tagEncoder number 2
+                    , delimitedEncoder (encode [ fieldEncoder 1 keyType key, fieldEncoder 2 valueType x ])
+                    ]
+            )
+        |> FieldEncoder
+
+
+{-| Encodes a field, even when it has the default value of its type. Used for one-ofs.
+-}
+fieldEncoder : Int -> FieldType a -> a -> FieldEncoder
+fieldEncoder number fieldType v =
+    FieldEncoder (taggedEncoders number fieldType v)
+
+
+{-| A value preceded by its tag, groups followed by their end tag.
+-}
+taggedEncoders : Int -> FieldType a -> a -> List BE.Encoder
+taggedEncoders number (FieldType fieldType) v =
+    if fieldType.wireType == 3 then
+        [ tagEncoder number 3, fieldType.encode v, tagEncoder number 4 ]
+
+    else
+        [ tagEncoder number fieldType.wireType, fieldType.encode v ]
+
+
+{-| Encodes nothing, used for unspecified one-ofs.
+-}
+noFieldEncoder : FieldEncoder
+noFieldEncoder =
+    FieldEncoder []
+
+
+
+-- FIELD TYPES
+
+
+{-| Wire encoding of the values of a PB field type.
+-}
+type FieldType a
+    = FieldType
+        { wireType : Int
+        , decode : Raw -> Maybe a
+        , encode : a -> BE.Encoder
+        , isDefault : a -> Bool
+        , merge : Bool
+        , known : Raw -> Bool
+        }
+
+
+varintType : (Int -> Int -> a) -> (a -> ( Int, Int )) -> (a -> Bool) -> FieldType a
+varintType fromVarint toVarint isDefault =
+    FieldType
+        { wireType = 0
+        , decode =
+            \raw ->
+                case raw of
+                    Varint lo hi ->
+                        Just (fromVarint lo hi)
+
+                    _ ->
+                        Nothing
+        , encode = \v -> toVarint v |> (\( lo, hi ) -> varintEncoder lo hi)
+        , isDefault = isDefault
+        , merge = False
+        , known = \_ -> True
+        }
+
+
+fixedType : Int -> BD.Decoder a -> (a -> BE.Encoder) -> (a -> Bool) -> FieldType a
+fixedType wireType decoder encoder isDefault =
+    FieldType
+        { wireType = wireType
+        , decode =
+            \raw ->
+                case raw of
+                    Fixed32 v ->
+                        BD.decode decoder v
+
+                    Fixed64 v ->
+                        BD.decode decoder v
+
+                    _ ->
+                        Nothing
+        , encode = encoder
+        , isDefault = isDefault
+        , merge = False
+        , known = \_ -> True
+        }
+
+
+delimitedType : (Bytes.Bytes -> Maybe a) -> (a -> BE.Encoder) -> (a -> Bool) -> Bool -> FieldType a
+delimitedType decoder encoder isDefault merge =
+    FieldType
+        { wireType = 2
+        , decode =
+            \raw ->
+                case raw of
+                    Delimited v ->
+                        decoder v
+
+                    _ ->
+                        Nothing
+        , encode = \v -> delimitedEncoder (encoder v)
+        , isDefault = isDefault
+        , merge = merge
+        , known = \_ -> True
+        }
+
+
+isZero : Int -> Bool
+isZero v =
+    v == 0
+
+
+{-| PB int32.
+-}
+int32 : FieldType Int
+int32 =
+    varintType (\lo _ -> Bitwise.or 0 lo) split64 isZero
+
+
+{-| PB int64.
+-}
+int64 : FieldType Int
+int64 =
+    varintType join64 split64 isZero
+
+
+{-| PB uint32.
+-}
+uint32 : FieldType Int
+uint32 =
+    varintType (\lo _ -> unsigned lo) (\v -> ( v, 0 )) isZero
+
+
+{-| PB uint64.
+-}
+uint64 : FieldType Int
+uint64 =
+    varintType joinUnsigned64 split64 isZero
+
+
+{-| PB sint32, zigzag encoded.
+-}
+sint32 : FieldType Int
+sint32 =
+    varintType
+        (\lo _ -> Bitwise.xor (Bitwise.shiftRightZfBy 1 lo) (negate (Bitwise.and 1 lo)))
+        (\v -> ( Bitwise.xor (Bitwise.shiftLeftBy 1 v) (Bitwise.shiftRightBy 31 v), 0 ))
+        isZero
+
+
+{-| PB sint64, zigzag encoded.
+-}
+sint64 : FieldType Int
+sint64 =
+    varintType
+        (\lo hi ->
+            let
+                magnitude =
+                    joinUnsigned64 (Bitwise.or (Bitwise.shiftRightZfBy 1 lo) (Bitwise.shiftLeftBy 31 hi)) (Bitwise.shiftRightZfBy 1 hi)
+            in
+            if Bitwise.and 1 lo == 0 then
+                magnitude
+
+            else
+                negate magnitude - 1
+        )
+        (\v ->
+            if v >= 0 then
+                split64 (2 * v)
+
+            else
+                split64 (-2 * v - 1)
+        )
+        isZero
+
+
+{-| PB fixed32.
+-}
+fixed32 : FieldType Int
+fixed32 =
+    fixedType 5 (BD.unsignedInt32 LE) (BE.unsignedInt32 LE) isZero
+
+
+{-| PB sfixed32.
+-}
+sfixed32 : FieldType Int
+sfixed32 =
+    fixedType 5 (BD.signedInt32 LE) (BE.signedInt32 LE) isZero
+
+
+{-| PB fixed64.
+-}
+fixed64 : FieldType Int
+fixed64 =
+    fixedType 1 (BD.map2 joinUnsigned64 (BD.unsignedInt32 LE) (BD.unsignedInt32 LE)) fixed64Encoder isZero
+
+
+{-| PB sfixed64.
+-}
+sfixed64 : FieldType Int
+sfixed64 =
+    fixedType 1 (BD.map2 join64 (BD.unsignedInt32 LE) (BD.unsignedInt32 LE)) fixed64Encoder isZero
+
+
+fixed64Encoder : Int -> BE.Encoder
+fixed64Encoder v =
+    let
+        ( lo, hi ) =
+            split64 v
+    in
+    BE.sequence [ BE.unsignedInt32 LE (unsigned lo), BE.unsignedInt32 LE (unsigned hi) ]
+
+
+{-| PB int64 as an exact `Protobuf.Int64`.
+-}
+exactInt64 : FieldType Protobuf.Int64
+exactInt64 =
+    varintType Protobuf.int64FromBits Protobuf.int64ToBits isInt64Zero
+
+
+{-| PB uint64 as an exact `Protobuf.Int64`.
+-}
+exactUint64 : FieldType Protobuf.Int64
+exactUint64 =
+    varintType Protobuf.uint64FromBits Protobuf.int64ToBits isInt64Zero
+
+
+{-| PB sint64 as an exact `Protobuf.Int64`, zigzag encoded.
+-}
+exactSint64 : FieldType Protobuf.Int64
+exactSint64 =
+    varintType
+        (\lo hi ->
+            let
+                mask =
+                    negate (Bitwise.and 1 lo)
+            in
+            Protobuf.int64FromBits
+                (Bitwise.xor (Bitwise.or (Bitwise.shiftRightZfBy 1 lo) (Bitwise.shiftLeftBy 31 hi)) mask)
+                (Bitwise.xor (Bitwise.shiftRightZfBy 1 hi) mask)
+        )
+        (\v ->
+            let
+                ( lo, hi ) =
+                    Protobuf.int64ToBits v
+
+                sign =
+                    Bitwise.shiftRightBy 31 hi
+            in
+            ( Bitwise.xor (Bitwise.shiftLeftBy 1 lo) sign
+            , Bitwise.xor (Bitwise.or (Bitwise.shiftLeftBy 1 hi) (Bitwise.shiftRightZfBy 31 lo)) sign
+            )
+        )
+        isInt64Zero
+
+
+{-| PB fixed64 as an exact `Protobuf.Int64`.
+-}
+exactFixed64 : FieldType Protobuf.Int64
+exactFixed64 =
+    fixedType 1 (BD.map2 Protobuf.uint64FromBits (BD.unsignedInt32 LE) (BD.unsignedInt32 LE)) exactFixed64Encoder isInt64Zero
+
+
+{-| PB sfixed64 as an exact `Protobuf.Int64`.
+-}
+exactSfixed64 : FieldType Protobuf.Int64
+exactSfixed64 =
+    fixedType 1 (BD.map2 Protobuf.int64FromBits (BD.unsignedInt32 LE) (BD.unsignedInt32 LE)) exactFixed64Encoder isInt64Zero
+
+
+exactFixed64Encoder : Protobuf.Int64 -> BE.Encoder
+exactFixed64Encoder v =
+    let
+        ( lo, hi ) =
+            Protobuf.int64ToBits v
+    in
+    BE.sequence [ BE.unsignedInt32 LE (unsigned lo), BE.unsignedInt32 LE (unsigned hi) ]
+
+
+isInt64Zero : Protobuf.Int64 -> Bool
+isInt64Zero v =
+    v == Protobuf.int64Zero
+
+
+{-| Exact signed 64 bit integer field type as a decimal string, for fields generated with the
+`int64=string` parameter. Strings that are not integers in the int64 range are encoded as zero.
+-}
+decimal : FieldType Protobuf.Int64 -> FieldType String
+decimal =
+    decimalType Protobuf.int64FromString
+
+
+{-| Same as `decimal`, for uint64 and fixed64 fields.
+-}
+unsignedDecimal : FieldType Protobuf.Int64 -> FieldType String
+unsignedDecimal =
+    decimalType Protobuf.uint64FromString
+
+
+decimalType : (String -> Maybe Protobuf.Int64) -> FieldType Protobuf.Int64 -> FieldType String
+decimalType fromString (FieldType fieldType) =
+    FieldType
+        { wireType = fieldType.wireType
+        , decode = fieldType.decode >> Maybe.map Protobuf.int64ToString
+        , encode = fromString >> Maybe.withDefault Protobuf.int64Zero >> fieldType.encode
+        , isDefault = fromString >> Maybe.withDefault Protobuf.int64Zero >> fieldType.isDefault
+        , merge = fieldType.merge
+        , known = fieldType.known
+        }
+
+
+{-| PB float.
+-}
+float : FieldType Float
+float =
+    fixedType 5 (BD.float32 LE) (BE.float32 LE) (\v -> v == 0)
+
+
+{-| PB double.
+-}
+double : FieldType Float
+double =
+    fixedType 1 (BD.float64 LE) (BE.float64 LE) (\v -> v == 0)
+
+
+{-| PB bool.
+-}
+bool : FieldType Bool
+bool =
+    varintType
+        (\lo hi -> lo /= 0 || hi /= 0)
+        (\v ->
+            if v then
+                ( 1, 0 )
+
+            else
+                ( 0, 0 )
+        )
+        not
+
+
+{-| PB string.
+-}
+string : FieldType String
+string =
+    delimitedType (\v -> BD.decode (BD.string (Bytes.width v)) v) BE.string String.isEmpty False
+
+
+{-| PB bytes, as a list of byte values.
+-}
+bytes : FieldType Protobuf.Bytes
+bytes =
+    delimitedType
+        (\v -> BD.decode (BD.loop ( Bytes.width v, [] ) bytesStep) v)
+        (BE.sequence << List.map BE.unsignedInt8)
+        List.isEmpty
+        False
+
+
+bytesStep : ( Int, List Int ) -> BD.Decoder (BD.Step ( Int, List Int ) (List Int))
+bytesStep ( remaining, values ) =
+    if remaining <= 0 then
+        BD.succeed (BD.Done (List.reverse values))
+
+    else
+        BD.unsignedInt8 |> BD.map (\v -> BD.Loop ( remaining - 1, v :: values ))
+
+
+{-| PB bytes, as elm/bytes.
+-}
+elmBytes : FieldType Bytes.Bytes
+elmBytes =
+    delimitedType Just BE.bytes (\v -> Bytes.width v == 0) False
+
+
+{-| PB enum, from and to its numeric value.
+-}
+enum : (Int -> a) -> (a -> Int) -> FieldType a
+enum fromInt toInt =
+    varintType (\lo _ -> fromInt (Bitwise.or 0 lo)) (toInt >> split64) (toInt >> isZero)
+
+
+{-| PB closed enum, numeric values without a variant are dropped like unknown fields: the field is
+decoded as if they were missing from the message.
+-}
+closedEnum : (Int -> Maybe a) -> (a -> Int) -> FieldType a
+closedEnum fromInt toInt =
+    let
+        (FieldType fieldType) =
+            enum identity identity
+    in
+    FieldType
+        { wireType = fieldType.wireType
+        , decode = fieldType.decode >> Maybe.andThen fromInt
+        , encode = toInt >> fieldType.encode
+        , isDefault = toInt >> fieldType.isDefault
+        , merge = fieldType.merge
+        , known =
+            \raw ->
+                case fieldType.decode raw of
+                    Just v ->
+                        fromInt v /= Nothing
+
+                    Nothing ->
+                        True
+        }
+
+
+{-| PB message.
+-}
+embedded : Decoder a -> (a -> Encoder) -> FieldType a
+embedded decoder encoder =
+    delimitedType (fromBytes decoder) encoder (\_ -> False) True
+
+
+{-| PB group, or message with `DELIMITED` encoding, delimited by a start and an end tag instead of
+its length.
+-}
+group : Decoder a -> (a -> Encoder) -> FieldType a
+group decoder encoder =
+    FieldType
+        { wireType = 3
+        , decode =
+            \raw ->
+                case raw of
+                    Group v ->
+                        fromBytes decoder v
+
+                    _ ->
+                        Nothing
+        , encode = encoder
+        , isDefault = \_ -> False
+        , merge = True
+        , known = \_ -> True
+        }
+
+
+
+-- WELL KNOWN TYPES
+
+
+{-| google.protobuf.Timestamp.
+-}
+timestamp : FieldType Protobuf.Timestamp
+timestamp =
+    embedded
+        (decode (\seconds nanos -> Time.millisToPosix (seconds * 1000 + nanos // 1000000))
+            |> required 1 int64
+            |> required 2 int32
+        )
+        (\v ->
+            let
+                millis =
+                    Time.posixToMillis v
+
+                seconds =
+                    floor (toFloat millis / 1000)
+            in
+            encode
+                [ requiredFieldEncoder 1 int64 seconds
+                , requiredFieldEncoder 2 int32 ((millis - seconds * 1000) * 1000000)
+                ]
+        )
+
+
+{-| google.protobuf.Duration.
+-}
+duration : FieldType Protobuf.Duration
+duration =
+    embedded
+        (decode Protobuf.Duration
+            |> required 1 int64
+            |> required 2 int32
+        )
+        (\v ->
+            encode
+                [ requiredFieldEncoder 1 int64 v.seconds
+                , requiredFieldEncoder 2 int32 v.nanos
+                ]
+        )
+
+
+{-| Wrapper message of a scalar type, e.g. google.protobuf.Int32Value.
+-}
+wrapper : FieldType a -> FieldType a
+wrapper fieldType =
+    embedded
+        (decode identity |> required 1 fieldType)
+        (\v -> encode [ requiredFieldEncoder 1 fieldType v ])
+
+
+{-| google.protobuf.Struct.
+-}
+struct : FieldType Protobuf.Struct
+struct =
+    embedded
+        (lazy (\_ -> decode identity |> mapEntries 1 string value))
+        (\v -> encode [ mapEntriesFieldEncoder 1 string value v ])
+
+
+{-| google.protobuf.Value.
+-}
+value : FieldType Protobuf.Value
+value =
+    embedded
+        (lazy
+            (\_ ->
+                oneOf Protobuf.NullValue
+                    [ variant 1 (\_ -> Protobuf.NullValue) nullValue
+                    , variant 2 Protobuf.NumberValue double
+                    , variant 3 Protobuf.StringValue string
+                    , variant 4 Protobuf.BoolValue bool
+                    , variant 5 Protobuf.StructValue struct
+                    , variant 6 Protobuf.ListValue listValue
+                    ]
+            )
+        )
+        (\v ->
+            encode
+                [ case v of
+                    Protobuf.NullValue ->
+                        fieldEncoder 1 nullValue ()
+
+                    Protobuf.NumberValue x ->
+                        fieldEncoder 2 double x
+
+                    Protobuf.StringValue x ->
+                        fieldEncoder 3 string x
+
+                    Protobuf.BoolValue x ->
+                        fieldEncoder 4 bool x
+
+                    Protobuf.StructValue x ->
+                        fieldEncoder 5 struct x
+
+                    Protobuf.ListValue x ->
+                        fieldEncoder 6 listValue x
+                ]
+        )
+
+
+{-| google.protobuf.ListValue.
+-}
+listValue : FieldType Protobuf.ListValue
+listValue =
+    embedded
+        (lazy (\_ -> decode identity |> repeated 1 value))
+        (\v -> encode [ repeatedFieldEncoder 1 value v ])
+
+
+{-| google.protobuf.NullValue.
+-}
+nullValue : FieldType ()
+nullValue =
+    enum (\_ -> ()) (\_ -> 0)
+
+
+{-| google.protobuf.Struct as a raw JSON object.
+-}
+jsonStruct : FieldType JE.Value
+jsonStruct =
+    jsonType Protobuf.structDecoder Protobuf.structEncoder Dict.empty struct
+
+
+{-| google.protobuf.Value as raw JSON.
+-}
+jsonValue : FieldType JE.Value
+jsonValue =
+    jsonType Protobuf.valueDecoder Protobuf.valueEncoder Protobuf.NullValue value
+
+
+{-| google.protobuf.ListValue as a raw JSON array.
+-}
+jsonListValue : FieldType JE.Value
+jsonListValue =
+    jsonType Protobuf.listValueDecoder Protobuf.listValueEncoder [] listValue
+
+
+{-| Raw JSON values are converted with the JSON codecs of a type, invalid values are encoded as
+the fallback.
+-}
+jsonType : JD.Decoder a -> (a -> JE.Value) -> a -> FieldType a -> FieldType JE.Value
+jsonType jsonDecoder jsonEncoder fallback (FieldType fieldType) =
+    FieldType
+        { wireType = fieldType.wireType
+        , decode = fieldType.decode >> Maybe.map jsonEncoder
+        , encode =
+            \v ->
+                case JD.decodeValue jsonDecoder v of
+                    Ok x ->
+                        fieldType.encode x
+
+                    Err _ ->
+                        fieldType.encode fallback
+        , isDefault = \_ -> False
+        , merge = fieldType.merge
+        , known = fieldType.known
+        }
+
+
+{-| google.protobuf.FieldMask.
+-}
+fieldMask : FieldType Protobuf.FieldMask
+fieldMask =
+    embedded
+        (decode identity |> repeated 1 string)
+        (\v -> encode [ repeatedFieldEncoder 1 string v ])
+
+
+{-| google.protobuf.Empty.
+-}
+empty : FieldType Protobuf.Empty
+empty =
+    embedded (decode Protobuf.Empty) (\_ -> encode [])
@@ -0,0 +1,245 @@
+module Protobuf.Http exposing
+    ( Config, config
+    , Endpoint, Segment, literal, variable, multiSegmentVariable, Body(..), responseBody, send
+    )
+
+{-| Runtime library for HTTP clients of Google Protocol Buffers services.
+
+This is mostly useless on its own, it is meant to support the code generated by the [Elm Protocol
+Buffer compiler](https://github.com/tiziano88/elm-protobuf) with the `services=http` parameter, which
+binds each service method to an HTTP endpoint following its `google.api.http` annotation, as
+[grpc-gateway](https://github.com/grpc-ecosystem/grpc-gateway) does.
+
+Requests are built from the JSON encoding of the request message: path variables take the value of
+the field they name, the body is the whole message or one of its fields, and the fields bound to
+neither are sent as query parameters, nested fields as `parent.child` and repeated ones as one
+parameter per value.
+
+
+# Configuration
+
+@docs Config, config
+
+
+# Endpoints
+
+@docs Endpoint, Segment, literal, variable, multiSegmentVariable, Body, responseBody, send
+
+-}
+
+import Http
+import Json.Decode as JD
+import Json.Encode as JE
+import Url
+import Url.Builder
+
+
+
+-- CONFIGURATION
+
+
+{-| Settings shared by the requests of a client, `baseUrl` prefixing the path of every endpoint,
+e.g. `"https://api.example.com"`, and `timeout` being in milliseconds.
+-}
+type alias Config =
+    { baseUrl : String
+    , headers : List Http.Header
+    , timeout : Maybe Float
+    }
+
+
+{-| Configuration without headers nor timeout.
+-}
+config : String -> Config
+config baseUrl =
+    { baseUrl = baseUrl
+    , headers = []
+    , timeout = Nothing
+    }
+
+
+
+-- ENDPOINTS
+
+
+{-| HTTP binding of a service method.
+-}
+type alias Endpoint a =
+    { method : String
+    , path : List Segment
+    , body : Body
+    , decoder : JD.Decoder a
+    }
+
+
+{-| Part of the path template of an endpoint.
+-}
+type Segment
+    = Literal String
+    | Variable Bool (List String) String
+
+
+{-| Constant part of a path.
+-}
+literal : String -> Segment
+literal =
+    Literal
+
+
+{-| Field of the request by the JSON names of its path, `default` standing for an absent field,
+percent-encoded as a single path segment.
+-}
+variable : List String -> String -> Segment
+variable =
+    Variable False
+
+
+{-| Same as `variable`, for a field spanning several path segments, e.g. `shelves/1/books/2`,
+whose slashes are kept.
+-}
+multiSegmentVariable : List String -> String -> Segment
+multiSegmentVariable =
+    Variable True
+
+
+{-| Request message fields sent as the HTTP body, the others being query parameters unless bound to
+the path: none, the whole message, or the field with the given JSON name.
+-}
+type Body
+    = NoBody
+    | WholeRequest
+    | RequestField String
+
+
+{-| Decoder of a response whose HTTP body is the field with the given JSON name of the response
+message.
+-}
+responseBody : String -> JD.Decoder a -> JD.Decoder a
+responseBody name decoder =
+    JD.value
+        |> JD.andThen
+            (\v ->
+                case JD.decodeValue decoder (JE.object [ ( name, v ) ]) of
+                    Ok x ->
+                        JD.succeed x
+
+                    Err e ->
+                        JD.fail (JD.errorToString e)
+            )
+
+
+{-| Sends the JSON encoded request to an endpoint.
+-}
+send : Endpoint a -> Config -> (Result Http.Error a -> msg) -> JE.Value -> Cmd msg
+send endpoint c toMsg request =
+    let
+        bound =
+            List.filterMap variablePath endpoint.path
+
+        ( body, query ) =
+            case endpoint.body of
+                NoBody ->
+                    ( Http.emptyBody, queryParameters bound [] request )
+
+                WholeRequest ->
+                    ( Http.jsonBody request, [] )
+
+                RequestField name ->
+                    ( Http.jsonBody (Result.withDefault JE.null (JD.decodeValue (JD.field name JD.value) request))
+                    , queryParameters ([ name ] :: bound) [] request
+                    )
+    in
+    Http.request
+        { method = endpoint.method
+        , headers = c.headers
+        , url = c.baseUrl ++ String.concat (List.map (pathSegment request) endpoint.path) ++ Url.Builder.toQuery query
+        , body = body
+        , expect = Http.expectJson toMsg endpoint.decoder
+        , timeout = c.timeout
+        , tracker = Nothing
+        }
+
+
+variablePath : Segment -> Maybe (List String)
+variablePath segment =
+    case segment of
+        Literal _ ->
+            Nothing
+
+        Variable _ path _ ->
+            Just path
+
+
+pathSegment : JE.Value -> Segment -> String
+pathSegment request segment =
+    case segment of
+        Literal s ->
+            s
+
+        Variable False path default ->
+            Url.percentEncode (fieldText path default request)
+
+        Variable True path default ->
+            fieldText path default request
+                |> String.split "/"
+                |> List.map Url.percentEncode
+                |> String.join "/"
+
+
+fieldText : List String -> String -> JE.Value -> String
+fieldText path default request =
+    JD.decodeValue (JD.at path JD.value) request
+        |> Result.map text
+        |> Result.withDefault default
+
+
+text : JE.Value -> String
+text v =
+    case JD.decodeValue JD.string v of
+        Ok s ->
+            s
+
+        Err _ ->
+            JE.encode 0 v
+
+
+{-| Query parameters of the fields of a JSON object not bound to the path or the body, by their
+path from the request.
+-}
+queryParameters : List (List String) -> List String -> JE.Value -> List Url.Builder.QueryParameter
+queryParameters bound parent v =
+    case JD.decodeValue (JD.keyValuePairs JD.value) v of
+        Ok fields ->
+            List.concatMap
+                (\( name, x ) ->
+                    if List.member (parent ++ [ name ]) bound then
+                        []
+
+                    else
+                        queryParameters bound (parent ++ [ name ]) x
+                )
+                fields
+
+        Err _ ->
+            case JD.decodeValue (JD.list JD.value) v of
+                Ok values ->
+                    List.concatMap (queryParameter parent) values
+
+                Err _ ->
+                    queryParameter parent v
+
+
+queryParameter : List String -> JE.Value -> List Url.Builder.QueryParameter
+queryParameter path v =
+    case JD.decodeValue (JD.null ()) v of
+        Ok () ->
+            []
+
+        Err _ ->
+            case JD.decodeValue (JD.keyValuePairs JD.value) v of
+                Ok _ ->
+                    -- Repeated messages have no query parameter representation.
+                    []
+
+                Err _ ->
+                    [ Url.Builder.string (String.join "." path) (text v) ]
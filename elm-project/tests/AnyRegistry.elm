@@ -0,0 +1,159 @@
+module AnyRegistry exposing (Message(..), pack, unpack)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- type URL registry of the messages generated in the same run
+
+import Dir.Other_dir as DirOther_dir
+import Fuzzer
+import Integers
+import Json.Decode as JD
+import Keywords
+import Map
+import Other
+import Packed
+import Protobuf
+import Recursive
+import Simple
+import Wrappers
+
+
+type Message
+    = OtherDir DirOther_dir.OtherDir
+    | Fuzz Fuzzer.Fuzz
+    | ThirtyTwo Integers.ThirtyTwo
+    | SixtyFour Integers.SixtyFour
+    | Keywords Keywords.Keywords
+    | MapValue Map.MapValue
+    | MessageWithMaps Map.MessageWithMaps
+    | Other Other.Other
+    | Packed Packed.Packed
+    | Rec Recursive.Rec
+    | Outer Recursive.Outer
+    | Inner Recursive.Inner
+    | Empty Simple.Empty
+    | Simple Simple.Simple
+    | Foo Simple.Foo
+    | Wrappers Wrappers.Wrappers
+    | Unknown_ Protobuf.Any
+
+
+unpack : Protobuf.Any -> Message
+unpack any =
+    case Protobuf.anyTypeName any of
+        "OtherDir" ->
+            unpackAs OtherDir DirOther_dir.otherDirDecoder any
+
+        "Fuzz" ->
+            unpackAs Fuzz Fuzzer.fuzzDecoder any
+
+        "ThirtyTwo" ->
+            unpackAs ThirtyTwo Integers.thirtyTwoDecoder any
+
+        "SixtyFour" ->
+            unpackAs SixtyFour Integers.sixtyFourDecoder any
+
+        "Keywords" ->
+            unpackAs Keywords Keywords.keywordsDecoder any
+
+        "MapValue" ->
+            unpackAs MapValue Map.mapValueDecoder any
+
+        "MessageWithMaps" ->
+            unpackAs MessageWithMaps Map.messageWithMapsDecoder any
+
+        "Other" ->
+            unpackAs Other Other.otherDecoder any
+
+        "Packed" ->
+            unpackAs Packed Packed.packedDecoder any
+
+        "Rec" ->
+            unpackAs Rec Recursive.recDecoder any
+
+        "Outer" ->
+            unpackAs Outer Recursive.outerDecoder any
+
+        "Inner" ->
+            unpackAs Inner Recursive.innerDecoder any
+
+        "Empty" ->
+            unpackAs Empty Simple.emptyDecoder any
+
+        "Simple" ->
+            unpackAs Simple Simple.simpleDecoder any
+
+        "Foo" ->
+            unpackAs Foo Simple.fooDecoder any
+
+        "Wrappers" ->
+            unpackAs Wrappers Wrappers.wrappersDecoder any
+
+        _ ->
+            Unknown_ any
+
+
+pack : Message -> Protobuf.Any
+pack message =
+    case message of
+        OtherDir v ->
+            Protobuf.pack "type.googleapis.com/OtherDir" DirOther_dir.otherDirEncoder v
+
+        Fuzz v ->
+            Protobuf.pack "type.googleapis.com/Fuzz" Fuzzer.fuzzEncoder v
+
+        ThirtyTwo v ->
+            Protobuf.pack "type.googleapis.com/ThirtyTwo" Integers.thirtyTwoEncoder v
+
+        SixtyFour v ->
+            Protobuf.pack "type.googleapis.com/SixtyFour" Integers.sixtyFourEncoder v
+
+        Keywords v ->
+            Protobuf.pack "type.googleapis.com/Keywords" Keywords.keywordsEncoder v
+
+        MapValue v ->
+            Protobuf.pack "type.googleapis.com/MapValue" Map.mapValueEncoder v
+
+        MessageWithMaps v ->
+            Protobuf.pack "type.googleapis.com/MessageWithMaps" Map.messageWithMapsEncoder v
+
+        Other v ->
+            Protobuf.pack "type.googleapis.com/Other" Other.otherEncoder v
+
+        Packed v ->
+            Protobuf.pack "type.googleapis.com/Packed" Packed.packedEncoder v
+
+        Rec v ->
+            Protobuf.pack "type.googleapis.com/Rec" Recursive.recEncoder v
+
+        Outer v ->
+            Protobuf.pack "type.googleapis.com/Outer" Recursive.outerEncoder v
+
+        Inner v ->
+            Protobuf.pack "type.googleapis.com/Inner" Recursive.innerEncoder v
+
+        Empty v ->
+            Protobuf.pack "type.googleapis.com/Empty" Simple.emptyEncoder v
+
+        Simple v ->
+            Protobuf.pack "type.googleapis.com/Simple" Simple.simpleEncoder v
+
+        Foo v ->
+            Protobuf.pack "type.googleapis.com/Foo" Simple.fooEncoder v
+
+        Wrappers v ->
+            Protobuf.pack "type.googleapis.com/Wrappers" Wrappers.wrappersEncoder v
+
+        Unknown_ any ->
+            any
+
+
+unpackAs : (a -> Message) -> JD.Decoder a -> Protobuf.Any -> Message
+unpackAs variant decoder any =
+    case Protobuf.unpack decoder any of
+        Ok v ->
+            variant v
+
+        Err _ ->
+            Unknown_ any
@@ -0,0 +1,222 @@
+module Binary.Legacy exposing (Legacy, Legacy_Note, Tier(..), getMarks, getOrigin, legacyBinaryDecoder, legacyBinaryEncoder, legacyDecoder, legacyEncoder, legacy_NoteBinaryDecoder, legacy_NoteBinaryEncoder, legacy_NoteDecoder, legacy_NoteEncoder, setMarks, setOrigin, tierBinaryDecoder, tierBinaryEncoder, tierDecoder, tierDefault, tierEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: binary/legacy.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+import Protobuf.Binary as PB
+
+
+type Tier
+    = Basic -- 1
+    | Premium -- 2
+
+
+tierDecoder : JD.Decoder Tier
+tierDecoder =
+    let
+        lookup s =
+            case s of
+                "BASIC" ->
+                    JD.succeed Basic
+
+                "PREMIUM" ->
+                    JD.succeed Premium
+
+                _ ->
+                    JD.fail ("unknown value \"" ++ s ++ "\"")
+
+        fromNumber n =
+            case n of
+                1 ->
+                    JD.succeed Basic
+
+                2 ->
+                    JD.succeed Premium
+
+                _ ->
+                    JD.fail ("unknown value " ++ String.fromInt n)
+    in
+    JD.oneOf [ JD.andThen lookup JD.string, JD.andThen fromNumber JD.int ]
+
+
+tierDefault : Tier
+tierDefault =
+    Basic
+
+
+tierEncoder : Tier -> JE.Value
+tierEncoder v =
+    let
+        lookup s =
+            case s of
+                Basic ->
+                    JE.string "BASIC"
+
+                Premium ->
+                    JE.string "PREMIUM"
+    in
+    lookup v
+
+
+tierBinaryDecoder : Int -> Maybe Tier
+tierBinaryDecoder v =
+    case v of
+        1 ->
+            Just Basic
+
+        2 ->
+            Just Premium
+
+        _ ->
+            Nothing
+
+
+tierBinaryEncoder : Tier -> Int
+tierBinaryEncoder v =
+    case v of
+        Basic ->
+            1
+
+        Premium ->
+            2
+
+
+type alias Legacy =
+    { id : String -- 1
+    , count : Maybe Int -- 2
+    , limit : Int -- 3
+    , label : String -- 4
+    , tier : Maybe Tier -- 5
+    , scores : List Int -- 6
+    , note : Maybe Legacy_Note -- 7
+    , tiers : List Tier -- 8
+    , plan : Tier -- 9
+    , extensions_ : Extensions
+    }
+
+
+legacyDecoder : JD.Decoder Legacy
+legacyDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Legacy
+                |> mandatory "id" JD.string
+                |> optional "count" intDecoder
+                |> required "limit" intDecoder (-5)
+                |> required "label" JD.string "none"
+                |> optional "tier" tierDecoder
+                |> repeated "scores" intDecoder
+                |> optional "note" legacy_NoteDecoder
+                |> repeated "tiers" tierDecoder
+                |> required "plan" tierDecoder Premium
+                |> extensions
+
+
+legacyEncoder : Legacy -> JE.Value
+legacyEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ mandatoryFieldEncoder "id" JE.string v.id
+            , optionalEncoder "count" JE.int v.count
+            , requiredFieldEncoder "limit" JE.int (-5) v.limit
+            , requiredFieldEncoder "label" JE.string "none" v.label
+            , optionalEncoder "tier" tierEncoder v.tier
+            , repeatedFieldEncoder "scores" JE.int v.scores
+            , optionalEncoder "note" legacy_NoteEncoder v.note
+            , repeatedFieldEncoder "tiers" tierEncoder v.tiers
+            , requiredFieldEncoder "plan" tierEncoder Premium v.plan
+            ]
+                ++ extensionFields v.extensions_
+
+
+legacyBinaryDecoder : PB.Decoder Legacy
+legacyBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Legacy
+                |> PB.mandatory 1 PB.string
+                |> PB.optional 2 PB.int32
+                |> PB.defaulted 3 PB.int32 (-5)
+                |> PB.defaulted 4 PB.string "none"
+                |> PB.optional 5 (PB.closedEnum tierBinaryDecoder tierBinaryEncoder)
+                |> PB.repeated 6 PB.int32
+                |> PB.optional 7 (PB.group legacy_NoteBinaryDecoder legacy_NoteBinaryEncoder)
+                |> PB.repeated 8 (PB.closedEnum tierBinaryDecoder tierBinaryEncoder)
+                |> PB.defaulted 9 (PB.closedEnum tierBinaryDecoder tierBinaryEncoder) Premium
+                |> PB.field (PB.decode noExtensions)
+
+
+legacyBinaryEncoder : Legacy -> PB.Encoder
+legacyBinaryEncoder v =
+    PB.encode
+        [ PB.mandatoryFieldEncoder 1 PB.string v.id
+        , PB.optionalEncoder 2 PB.int32 v.count
+        , PB.defaultedFieldEncoder 3 PB.int32 (-5) v.limit
+        , PB.defaultedFieldEncoder 4 PB.string "none" v.label
+        , PB.optionalEncoder 5 (PB.closedEnum tierBinaryDecoder tierBinaryEncoder) v.tier
+        , PB.expandedFieldEncoder 6 PB.int32 v.scores
+        , PB.optionalEncoder 7 (PB.group legacy_NoteBinaryDecoder legacy_NoteBinaryEncoder) v.note
+        , PB.expandedFieldEncoder 8 (PB.closedEnum tierBinaryDecoder tierBinaryEncoder) v.tiers
+        , PB.defaultedFieldEncoder 9 (PB.closedEnum tierBinaryDecoder tierBinaryEncoder) Premium v.plan
+        ]
+
+
+type alias Legacy_Note =
+    { text : Maybe String -- 1
+    }
+
+
+legacy_NoteDecoder : JD.Decoder Legacy_Note
+legacy_NoteDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Legacy_Note
+                |> optional "text" JD.string
+
+
+legacy_NoteEncoder : Legacy_Note -> JE.Value
+legacy_NoteEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "text" JE.string v.text
+            ]
+
+
+legacy_NoteBinaryDecoder : PB.Decoder Legacy_Note
+legacy_NoteBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Legacy_Note
+                |> PB.optional 1 PB.string
+
+
+legacy_NoteBinaryEncoder : Legacy_Note -> PB.Encoder
+legacy_NoteBinaryEncoder v =
+    PB.encode
+        [ PB.optionalEncoder 1 PB.string v.text
+        ]
+
+
+getOrigin : Legacy -> Maybe String
+getOrigin v =
+    getExtension "[origin]" JD.string v.extensions_
+
+
+setOrigin : Maybe String -> Legacy -> Legacy
+setOrigin x v =
+    { v | extensions_ = setExtension "[origin]" JE.string x v.extensions_ }
+
+
+getMarks : Legacy -> List Int
+getMarks v =
+    getRepeatedExtension "[marks]" intDecoder v.extensions_
+
+
+setMarks : List Int -> Legacy -> Legacy
+setMarks x v =
+    { v | extensions_ = setRepeatedExtension "[marks]" JE.int x v.extensions_ }
@@ -0,0 +1,396 @@
+module Binary.Wire exposing (Composite, Composite_ByNameEntry, Composite_Choice(..), Level(..), Scalars, compositeBinaryDecoder, compositeBinaryEncoder, compositeDecoder, compositeEncoder, composite_ByNameEntryBinaryDecoder, composite_ByNameEntryBinaryEncoder, composite_ByNameEntryDecoder, composite_ByNameEntryEncoder, composite_ChoiceBinaryDecoder, composite_ChoiceBinaryEncoder, composite_ChoiceDecoder, composite_ChoiceEncoder, levelBinaryDecoder, levelBinaryEncoder, levelDecoder, levelDefault, levelEncoder, scalarsBinaryDecoder, scalarsBinaryEncoder, scalarsDecoder, scalarsEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: binary/wire.proto
+
+import Dict
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+import Protobuf.Binary as PB
+
+
+type Level
+    = LevelUnspecified -- 0
+    | Low -- 1
+    | High -- 2
+    | LevelUnrecognized_ Int
+
+
+levelDecoder : JD.Decoder Level
+levelDecoder =
+    let
+        lookup s =
+            case s of
+                "LEVEL_UNSPECIFIED" ->
+                    LevelUnspecified
+
+                "LOW" ->
+                    Low
+
+                "HIGH" ->
+                    High
+
+                _ ->
+                    LevelUnspecified
+
+        fromNumber n =
+            case n of
+                0 ->
+                    LevelUnspecified
+
+                1 ->
+                    Low
+
+                2 ->
+                    High
+
+                _ ->
+                    LevelUnrecognized_ n
+    in
+    JD.oneOf [ JD.map lookup JD.string, JD.map fromNumber JD.int ]
+
+
+levelDefault : Level
+levelDefault =
+    LevelUnspecified
+
+
+levelEncoder : Level -> JE.Value
+levelEncoder v =
+    let
+        lookup s =
+            case s of
+                LevelUnspecified ->
+                    JE.string "LEVEL_UNSPECIFIED"
+
+                Low ->
+                    JE.string "LOW"
+
+                High ->
+                    JE.string "HIGH"
+
+                LevelUnrecognized_ n ->
+                    JE.int n
+    in
+    lookup v
+
+
+levelBinaryDecoder : Int -> Level
+levelBinaryDecoder v =
+    case v of
+        0 ->
+            LevelUnspecified
+
+        1 ->
+            Low
+
+        2 ->
+            High
+
+        _ ->
+            LevelUnrecognized_ v
+
+
+levelBinaryEncoder : Level -> Int
+levelBinaryEncoder v =
+    case v of
+        LevelUnspecified ->
+            0
+
+        Low ->
+            1
+
+        High ->
+            2
+
+        LevelUnrecognized_ n ->
+            n
+
+
+type alias Scalars =
+    { int32Field : Int -- 1
+    , int64Field : Int -- 2
+    , uint32Field : Int -- 3
+    , uint64Field : Int -- 4
+    , sint32Field : Int -- 5
+    , sint64Field : Int -- 6
+    , fixed32Field : Int -- 7
+    , fixed64Field : Int -- 8
+    , sfixed32Field : Int -- 9
+    , sfixed64Field : Int -- 10
+    , floatField : Float -- 11
+    , doubleField : Float -- 12
+    , boolField : Bool -- 13
+    , stringField : String -- 14
+    , bytesField : Bytes -- 15
+    , level : Level -- 16
+    }
+
+
+scalarsDecoder : JD.Decoder Scalars
+scalarsDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Scalars
+                |> required "int32Field" intDecoder 0
+                |> required "int64Field" intDecoder 0
+                |> required "uint32Field" intDecoder 0
+                |> required "uint64Field" intDecoder 0
+                |> required "sint32Field" intDecoder 0
+                |> required "sint64Field" intDecoder 0
+                |> required "fixed32Field" intDecoder 0
+                |> required "fixed64Field" intDecoder 0
+                |> required "sfixed32Field" intDecoder 0
+                |> required "sfixed64Field" intDecoder 0
+                |> required "floatField" JD.float 0.0
+                |> required "doubleField" JD.float 0.0
+                |> required "boolField" JD.bool False
+                |> required "stringField" JD.string ""
+                |> required "bytesField" bytesFieldDecoder []
+                |> required "level" levelDecoder levelDefault
+
+
+scalarsEncoder : Scalars -> JE.Value
+scalarsEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "int32Field" JE.int 0 v.int32Field
+            , requiredFieldEncoder "int64Field" numericStringEncoder 0 v.int64Field
+            , requiredFieldEncoder "uint32Field" JE.int 0 v.uint32Field
+            , requiredFieldEncoder "uint64Field" numericStringEncoder 0 v.uint64Field
+            , requiredFieldEncoder "sint32Field" JE.int 0 v.sint32Field
+            , requiredFieldEncoder "sint64Field" numericStringEncoder 0 v.sint64Field
+            , requiredFieldEncoder "fixed32Field" JE.int 0 v.fixed32Field
+            , requiredFieldEncoder "fixed64Field" numericStringEncoder 0 v.fixed64Field
+            , requiredFieldEncoder "sfixed32Field" JE.int 0 v.sfixed32Field
+            , requiredFieldEncoder "sfixed64Field" numericStringEncoder 0 v.sfixed64Field
+            , requiredFieldEncoder "floatField" JE.float 0.0 v.floatField
+            , requiredFieldEncoder "doubleField" JE.float 0.0 v.doubleField
+            , requiredFieldEncoder "boolField" JE.bool False v.boolField
+            , requiredFieldEncoder "stringField" JE.string "" v.stringField
+            , requiredFieldEncoder "bytesField" bytesFieldEncoder [] v.bytesField
+            , requiredFieldEncoder "level" levelEncoder levelDefault v.level
+            ]
+
+
+scalarsBinaryDecoder : PB.Decoder Scalars
+scalarsBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Scalars
+                |> PB.required 1 PB.int32
+                |> PB.required 2 PB.int64
+                |> PB.required 3 PB.uint32
+                |> PB.required 4 PB.uint64
+                |> PB.required 5 PB.sint32
+                |> PB.required 6 PB.sint64
+                |> PB.required 7 PB.fixed32
+                |> PB.required 8 PB.fixed64
+                |> PB.required 9 PB.sfixed32
+                |> PB.required 10 PB.sfixed64
+                |> PB.required 11 PB.float
+                |> PB.required 12 PB.double
+                |> PB.required 13 PB.bool
+                |> PB.required 14 PB.string
+                |> PB.required 15 PB.bytes
+                |> PB.required 16 (PB.enum levelBinaryDecoder levelBinaryEncoder)
+
+
+scalarsBinaryEncoder : Scalars -> PB.Encoder
+scalarsBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.int32 v.int32Field
+        , PB.requiredFieldEncoder 2 PB.int64 v.int64Field
+        , PB.requiredFieldEncoder 3 PB.uint32 v.uint32Field
+        , PB.requiredFieldEncoder 4 PB.uint64 v.uint64Field
+        , PB.requiredFieldEncoder 5 PB.sint32 v.sint32Field
+        , PB.requiredFieldEncoder 6 PB.sint64 v.sint64Field
+        , PB.requiredFieldEncoder 7 PB.fixed32 v.fixed32Field
+        , PB.requiredFieldEncoder 8 PB.fixed64 v.fixed64Field
+        , PB.requiredFieldEncoder 9 PB.sfixed32 v.sfixed32Field
+        , PB.requiredFieldEncoder 10 PB.sfixed64 v.sfixed64Field
+        , PB.requiredFieldEncoder 11 PB.float v.floatField
+        , PB.requiredFieldEncoder 12 PB.double v.doubleField
+        , PB.requiredFieldEncoder 13 PB.bool v.boolField
+        , PB.requiredFieldEncoder 14 PB.string v.stringField
+        , PB.requiredFieldEncoder 15 PB.bytes v.bytesField
+        , PB.requiredFieldEncoder 16 (PB.enum levelBinaryDecoder levelBinaryEncoder) v.level
+        ]
+
+
+type alias Composite =
+    { scalars : Maybe Scalars -- 1
+    , packed : List Int -- 2
+    , names : List String -- 3
+    , children : List Scalars -- 4
+    , byName : Dict.Dict String Scalars -- 5
+    , timestamp : Maybe Timestamp -- 8
+    , duration : Maybe Duration -- 9
+    , wrapped : Maybe Int -- 10
+    , value : Maybe Protobuf.Value -- 11
+    , choice : Composite_Choice
+    }
+
+
+compositeDecoder : JD.Decoder Composite
+compositeDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Composite
+                |> optional "scalars" scalarsDecoder
+                |> repeated "packed" intDecoder
+                |> repeated "names" JD.string
+                |> repeated "children" scalarsDecoder
+                |> mapEntries "byName" scalarsDecoder
+                |> optional "timestamp" timestampDecoder
+                |> optional "duration" durationDecoder
+                |> optional "wrapped" intValueDecoder
+                |> optional "value" Protobuf.valueDecoder
+                |> field composite_ChoiceDecoder
+
+
+compositeEncoder : Composite -> JE.Value
+compositeEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "scalars" scalarsEncoder v.scalars
+            , repeatedFieldEncoder "packed" JE.int v.packed
+            , repeatedFieldEncoder "names" JE.string v.names
+            , repeatedFieldEncoder "children" scalarsEncoder v.children
+            , mapEntriesFieldEncoder "byName" scalarsEncoder v.byName
+            , optionalEncoder "timestamp" timestampEncoder v.timestamp
+            , optionalEncoder "duration" durationEncoder v.duration
+            , optionalEncoder "wrapped" intValueEncoder v.wrapped
+            , optionalEncoder "value" Protobuf.valueEncoder v.value
+            , composite_ChoiceEncoder v.choice
+            ]
+
+
+compositeBinaryDecoder : PB.Decoder Composite
+compositeBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Composite
+                |> PB.optional 1 (PB.embedded scalarsBinaryDecoder scalarsBinaryEncoder)
+                |> PB.repeated 2 PB.int32
+                |> PB.repeated 3 PB.string
+                |> PB.repeated 4 (PB.embedded scalarsBinaryDecoder scalarsBinaryEncoder)
+                |> PB.mapEntries 5 PB.string (PB.embedded scalarsBinaryDecoder scalarsBinaryEncoder)
+                |> PB.optional 8 PB.timestamp
+                |> PB.optional 9 PB.duration
+                |> PB.optional 10 (PB.wrapper PB.int32)
+                |> PB.optional 11 PB.value
+                |> PB.field composite_ChoiceBinaryDecoder
+
+
+compositeBinaryEncoder : Composite -> PB.Encoder
+compositeBinaryEncoder v =
+    PB.encode
+        [ PB.optionalEncoder 1 (PB.embedded scalarsBinaryDecoder scalarsBinaryEncoder) v.scalars
+        , PB.repeatedFieldEncoder 2 PB.int32 v.packed
+        , PB.repeatedFieldEncoder 3 PB.string v.names
+        , PB.repeatedFieldEncoder 4 (PB.embedded scalarsBinaryDecoder scalarsBinaryEncoder) v.children
+        , PB.mapEntriesFieldEncoder 5 PB.string (PB.embedded scalarsBinaryDecoder scalarsBinaryEncoder) v.byName
+        , PB.optionalEncoder 8 PB.timestamp v.timestamp
+        , PB.optionalEncoder 9 PB.duration v.duration
+        , PB.optionalEncoder 10 (PB.wrapper PB.int32) v.wrapped
+        , PB.optionalEncoder 11 PB.value v.value
+        , composite_ChoiceBinaryEncoder v.choice
+        ]
+
+
+type Composite_Choice
+    = Composite_ChoiceUnspecified
+    | Composite_Text String
+    | Composite_Nested Composite
+
+
+composite_ChoiceDecoder : JD.Decoder Composite_Choice
+composite_ChoiceDecoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map Composite_Text (JD.field "text" JD.string)
+                , JD.map Composite_Nested (JD.field "nested" compositeDecoder)
+                , JD.succeed Composite_ChoiceUnspecified
+                ]
+
+
+composite_ChoiceEncoder : Composite_Choice -> Maybe ( String, JE.Value )
+composite_ChoiceEncoder v =
+    case v of
+        Composite_ChoiceUnspecified ->
+            Nothing
+
+        Composite_Text x ->
+            Just ( "text", JE.string x )
+
+        Composite_Nested x ->
+            Just ( "nested", compositeEncoder x )
+
+
+composite_ChoiceBinaryDecoder : PB.Decoder Composite_Choice
+composite_ChoiceBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.oneOf Composite_ChoiceUnspecified
+                [ PB.variant 6 Composite_Text PB.string
+                , PB.variant 7 Composite_Nested (PB.embedded compositeBinaryDecoder compositeBinaryEncoder)
+                ]
+
+
+composite_ChoiceBinaryEncoder : Composite_Choice -> PB.FieldEncoder
+composite_ChoiceBinaryEncoder v =
+    case v of
+        Composite_ChoiceUnspecified ->
+            PB.noFieldEncoder
+
+        Composite_Text x ->
+            PB.fieldEncoder 6 PB.string x
+
+        Composite_Nested x ->
+            PB.fieldEncoder 7 (PB.embedded compositeBinaryDecoder compositeBinaryEncoder) x
+
+
+type alias Composite_ByNameEntry =
+    { key : String -- 1
+    , value : Maybe Scalars -- 2
+    }
+
+
+composite_ByNameEntryDecoder : JD.Decoder Composite_ByNameEntry
+composite_ByNameEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Composite_ByNameEntry
+                |> required "key" JD.string ""
+                |> optional "value" scalarsDecoder
+
+
+composite_ByNameEntryEncoder : Composite_ByNameEntry -> JE.Value
+composite_ByNameEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.string "" v.key
+            , optionalEncoder "value" scalarsEncoder v.value
+            ]
+
+
+composite_ByNameEntryBinaryDecoder : PB.Decoder Composite_ByNameEntry
+composite_ByNameEntryBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Composite_ByNameEntry
+                |> PB.required 1 PB.string
+                |> PB.optional 2 (PB.embedded scalarsBinaryDecoder scalarsBinaryEncoder)
+
+
+composite_ByNameEntryBinaryEncoder : Composite_ByNameEntry -> PB.Encoder
+composite_ByNameEntryBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.string v.key
+        , PB.optionalEncoder 2 (PB.embedded scalarsBinaryDecoder scalarsBinaryEncoder) v.value
+        ]
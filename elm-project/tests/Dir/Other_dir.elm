@@ -1,17 +1,13 @@
-module Dir.Other_dir exposing (..)
+module Dir.Other_dir exposing (OtherDir, otherDirDecoder, otherDirEncoder)
 
 -- DO NOT EDIT
 -- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
 -- https://github.com/tiziano88/elm-protobuf
 -- source file: dir/other_dir.proto
 
-import Protobuf exposing (..)
-
 import Json.Decode as JD
 import Json.Encode as JE
-
-
-uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+import Protobuf exposing (..)
 
 
 type alias OtherDir =
@@ -21,12 +17,15 @@ type alias OtherDir =
 
 otherDirDecoder : JD.Decoder OtherDir
 otherDirDecoder =
-    JD.lazy <| \_ -> decode OtherDir
-        |> required "stringField" JD.string ""
+    JD.lazy <|
+        \_ ->
+            decode OtherDir
+                |> required "stringField" JD.string ""
 
 
 otherDirEncoder : OtherDir -> JE.Value
 otherDirEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "stringField" JE.string "" v.stringField)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "stringField" JE.string "" v.stringField
+            ]
@@ -1,14 +0,0 @@
-module Empty exposing (..)
-
--- DO NOT EDIT
--- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
--- https://github.com/tiziano88/elm-protobuf
--- source file: empty.proto
-
-import Protobuf exposing (..)
-
-import Json.Decode as JD
-import Json.Encode as JE
-
-
-uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
@@ -1,17 +1,13 @@
-module Fuzzer exposing (..)
+module Fuzzer exposing (Fuzz, fuzzDecoder, fuzzEncoder)
 
 -- DO NOT EDIT
 -- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
 -- https://github.com/tiziano88/elm-protobuf
 -- source file: fuzzer.proto
 
-import Protobuf exposing (..)
-
 import Json.Decode as JD
 import Json.Encode as JE
-
-
-uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+import Protobuf exposing (..)
 
 
 type alias Fuzz =
@@ -25,20 +21,23 @@ type alias Fuzz =
 
 fuzzDecoder : JD.Decoder Fuzz
 fuzzDecoder =
-    JD.lazy <| \_ -> decode Fuzz
-        |> required "stringField" JD.string ""
-        |> required "int32Field" intDecoder 0
-        |> optional "stringValueField" stringValueDecoder
-        |> optional "int32ValueField" intValueDecoder
-        |> optional "timestampField" timestampDecoder
+    JD.lazy <|
+        \_ ->
+            decode Fuzz
+                |> required "stringField" JD.string ""
+                |> required "int32Field" intDecoder 0
+                |> optional "stringValueField" stringValueDecoder
+                |> optional "int32ValueField" intValueDecoder
+                |> optional "timestampField" timestampDecoder
 
 
 fuzzEncoder : Fuzz -> JE.Value
 fuzzEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "stringField" JE.string "" v.stringField)
-        , (requiredFieldEncoder "int32Field" JE.int 0 v.int32Field)
-        , (optionalEncoder "stringValueField" stringValueEncoder v.stringValueField)
-        , (optionalEncoder "int32ValueField" intValueEncoder v.int32ValueField)
-        , (optionalEncoder "timestampField" timestampEncoder v.timestampField)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "stringField" JE.string "" v.stringField
+            , requiredFieldEncoder "int32Field" JE.int 0 v.int32Field
+            , optionalEncoder "stringValueField" stringValueEncoder v.stringValueField
+            , optionalEncoder "int32ValueField" intValueEncoder v.int32ValueField
+            , optionalEncoder "timestampField" timestampEncoder v.timestampField
+            ]
@@ -0,0 +1,124 @@
+module Int64.Exact exposing (Snowflake, Snowflake_LabelsEntry, snowflakeBinaryDecoder, snowflakeBinaryEncoder, snowflakeDecoder, snowflakeEncoder, snowflake_LabelsEntryBinaryDecoder, snowflake_LabelsEntryBinaryEncoder, snowflake_LabelsEntryDecoder, snowflake_LabelsEntryEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: int64/exact.proto
+
+import Dict
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+import Protobuf.Binary as PB
+
+
+type alias Snowflake =
+    { id : Protobuf.Int64 -- 1
+    , unsigned : Protobuf.Int64 -- 2
+    , zigzag : Protobuf.Int64 -- 3
+    , fixed : Protobuf.Int64 -- 4
+    , sfixed : Protobuf.Int64 -- 5
+    , ids : List Protobuf.Int64 -- 6
+    , wrapped : Maybe Protobuf.Int64 -- 7
+    , labels : Dict.Dict String String -- 8
+    }
+
+
+snowflakeDecoder : JD.Decoder Snowflake
+snowflakeDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Snowflake
+                |> required "id" Protobuf.int64Decoder Protobuf.int64Zero
+                |> required "unsigned" Protobuf.uint64Decoder Protobuf.int64Zero
+                |> required "zigzag" Protobuf.int64Decoder Protobuf.int64Zero
+                |> required "fixed" Protobuf.uint64Decoder Protobuf.int64Zero
+                |> required "sfixed" Protobuf.int64Decoder Protobuf.int64Zero
+                |> repeated "ids" Protobuf.int64Decoder
+                |> optional "wrapped" Protobuf.int64Decoder
+                |> keyedMapEntries "labels" uint64KeyFromString JD.string
+
+
+snowflakeEncoder : Snowflake -> JE.Value
+snowflakeEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "id" Protobuf.int64Encoder Protobuf.int64Zero v.id
+            , requiredFieldEncoder "unsigned" Protobuf.int64Encoder Protobuf.int64Zero v.unsigned
+            , requiredFieldEncoder "zigzag" Protobuf.int64Encoder Protobuf.int64Zero v.zigzag
+            , requiredFieldEncoder "fixed" Protobuf.int64Encoder Protobuf.int64Zero v.fixed
+            , requiredFieldEncoder "sfixed" Protobuf.int64Encoder Protobuf.int64Zero v.sfixed
+            , repeatedFieldEncoder "ids" Protobuf.int64Encoder v.ids
+            , optionalEncoder "wrapped" Protobuf.int64Encoder v.wrapped
+            , keyedMapEntriesFieldEncoder "labels" identity JE.string v.labels
+            ]
+
+
+snowflakeBinaryDecoder : PB.Decoder Snowflake
+snowflakeBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Snowflake
+                |> PB.required 1 PB.exactInt64
+                |> PB.required 2 PB.exactUint64
+                |> PB.required 3 PB.exactSint64
+                |> PB.required 4 PB.exactFixed64
+                |> PB.required 5 PB.exactSfixed64
+                |> PB.repeated 6 PB.exactInt64
+                |> PB.optional 7 (PB.wrapper PB.exactInt64)
+                |> PB.mapEntries 8 (PB.unsignedDecimal PB.exactUint64) PB.string
+
+
+snowflakeBinaryEncoder : Snowflake -> PB.Encoder
+snowflakeBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.exactInt64 v.id
+        , PB.requiredFieldEncoder 2 PB.exactUint64 v.unsigned
+        , PB.requiredFieldEncoder 3 PB.exactSint64 v.zigzag
+        , PB.requiredFieldEncoder 4 PB.exactFixed64 v.fixed
+        , PB.requiredFieldEncoder 5 PB.exactSfixed64 v.sfixed
+        , PB.repeatedFieldEncoder 6 PB.exactInt64 v.ids
+        , PB.optionalEncoder 7 (PB.wrapper PB.exactInt64) v.wrapped
+        , PB.mapEntriesFieldEncoder 8 (PB.unsignedDecimal PB.exactUint64) PB.string v.labels
+        ]
+
+
+type alias Snowflake_LabelsEntry =
+    { key : Protobuf.Int64 -- 1
+    , value : String -- 2
+    }
+
+
+snowflake_LabelsEntryDecoder : JD.Decoder Snowflake_LabelsEntry
+snowflake_LabelsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Snowflake_LabelsEntry
+                |> required "key" Protobuf.uint64Decoder Protobuf.int64Zero
+                |> required "value" JD.string ""
+
+
+snowflake_LabelsEntryEncoder : Snowflake_LabelsEntry -> JE.Value
+snowflake_LabelsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" Protobuf.int64Encoder Protobuf.int64Zero v.key
+            , requiredFieldEncoder "value" JE.string "" v.value
+            ]
+
+
+snowflake_LabelsEntryBinaryDecoder : PB.Decoder Snowflake_LabelsEntry
+snowflake_LabelsEntryBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Snowflake_LabelsEntry
+                |> PB.required 1 PB.exactUint64
+                |> PB.required 2 PB.string
+
+
+snowflake_LabelsEntryBinaryEncoder : Snowflake_LabelsEntry -> PB.Encoder
+snowflake_LabelsEntryBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.exactUint64 v.key
+        , PB.requiredFieldEncoder 2 PB.string v.value
+        ]
@@ -17,7 +17,17 @@ import Test exposing (..)
 import Time
 import Wrappers as W
 import Dict
-import Empty exposing (..)
+import AnyRegistry
+import Binary.Legacy as L
+import Binary.Wire as B
+import Bytes
+import Bytes.Decode as BD
+import Bytes.Encode as BE
+import Protobuf.Binary as PB
+import Int64.Exact as I64
+import Strict.Checked as S
+import Opaque.Order as O
+import Protobuf.Http as PH
 
 
 suite : Test
@@ -32,6 +42,8 @@ suite =
         , test "JSON decode message with repeated field" <| \() -> decode T.fooDecoder fooJson |> equal (Ok foo)
         , test "JSON encode message with map field" <| \() -> encode M.messageWithMapsEncoder map |> equal mapJson
         , test "JSON decode message with map field" <| \() -> decode M.messageWithMapsDecoder mapJson |> equal (Ok map)
+        , test "JSON decode map with invalid int key" <| \() -> decode M.messageWithMapsDecoder "{ \"int32ToStrings\": { \"x\": \"a\" } }" |> Result.map .int32ToStrings |> equal (Ok Dict.empty)
+        , test "JSON decode map with invalid bool key" <| \() -> decode M.messageWithMapsDecoder "{ \"boolToMessages\": { \"yes\": {} } }" |> Result.map .boolToMessages |> equal (Ok [])
         , test "JSON encode 32-bit ints as numbers" <| \() -> encode I.thirtyTwoEncoder msg32 |> equal json32numbers
         , test "JSON decode numbers to 32-bit ints" <| \() -> decode I.thirtyTwoDecoder json32numbers |> equal (Ok msg32)
         , test "JSON decode numeric strings to 32-bit ints" <| \() -> decode I.thirtyTwoDecoder json32strings |> equal (Ok msg32)
@@ -50,17 +62,182 @@ suite =
                 , test "oo2" <| \() -> decode T.fooDecoder oo2SetJson |> equal (Ok oo2Set)
                 ]
             ]
+        , describe "enum"
+            [ test "decode name" <| \() -> decode T.colourDecoder "\"GREEN\"" |> equal (Ok T.Green)
+            , test "decode number" <| \() -> decode T.colourDecoder "2" |> equal (Ok T.Green)
+            , test "decode unknown name" <| \() -> decode T.colourDecoder "\"PURPLE\"" |> equal (Ok T.ColourUnspecified)
+            , test "decode unknown number" <| \() -> decode T.colourDecoder "7" |> equal (Ok (T.ColourUnrecognized_ 7))
+            , test "encode unknown number" <| \() -> encode T.colourEncoder (T.ColourUnrecognized_ 7) |> equal "7"
+            , test "decode alias" <| \() -> decode T.shapeDecoder "\"ROUND\"" |> equal (Ok T.Circle)
+            , test "encode alias" <| \() -> encode T.shapeEncoder T.Circle |> equal "\"CIRCLE\""
+            ]
         , describe "recursion"
             [ test "decode empty JSON" <| \() -> decode R.recDecoder emptyJson |> equal (Ok recDefault)
             , describe "decode"
                 [ test "1-level JSON" <| \() -> decode R.recDecoder recJson1 |> equal (Ok rec1)
                 , test "2-level JSON" <| \() -> decode R.recDecoder recJson2 |> equal (Ok rec2)
+                , test "nested fields JSON" <| \() -> decode R.recDecoder recJson3 |> equal (Ok rec3)
+                , test "mutually recursive JSON" <| \() -> decode R.outerDecoder outerJson |> equal (Ok outer)
                 ]
+            , test "encode nested fields" <| \() -> encode R.recEncoder rec3 |> equal recJson3
+            , test "unwrap" <| \() -> R.unwrapRec_Children (R.Rec_Children [ recDefault ]) |> equal [ recDefault ]
             ]
         , describe "timestamp"
             [ test "encode" <| \() -> encode T.fooEncoder timestampFoo |> equal timestampJson
             , test "decode" <| \() -> decode T.fooDecoder timestampJson |> equal (Ok timestampFoo)
             ]
+        , describe "duration"
+            [ test "decode" <| \() -> JD.decodeString durationDecoder "\"1.5s\"" |> equal (Ok { seconds = 1, nanos = 500000000 })
+            , test "decode negative" <| \() -> JD.decodeString durationDecoder "\"-0.000000001s\"" |> equal (Ok { seconds = 0, nanos = -1 })
+            , test "decode whole seconds" <| \() -> JD.decodeString durationDecoder "\"-3s\"" |> equal (Ok { seconds = -3, nanos = 0 })
+            , test "decode invalid" <| \() -> JD.decodeString durationDecoder "\"1.5\"" |> Result.toMaybe |> equal Nothing
+            , test "encode" <| \() -> JE.encode 0 (durationEncoder { seconds = 1, nanos = 500000000 }) |> equal "\"1.500s\""
+            , test "encode negative" <| \() -> JE.encode 0 (durationEncoder { seconds = 0, nanos = -1 }) |> equal "\"-0.000000001s\""
+            , test "encode micros" <| \() -> JE.encode 0 (durationEncoder { seconds = 2, nanos = 10000 }) |> equal "\"2.000010s\""
+            ]
+        , describe "struct"
+            [ test "decode" <| \() -> JD.decodeString structDecoder structJson |> equal (Ok struct)
+            , test "encode" <| \() -> JE.encode 0 (structEncoder struct) |> equal (String.replace " " "" (String.replace "\n" "" structJson))
+            , test "decode null value" <| \() -> JD.decodeString valueDecoder "null" |> equal (Ok NullValue)
+            , test "encode null value" <| \() -> JE.encode 0 (nullValueEncoder ()) |> equal "null"
+            ]
+        , describe "field mask"
+            [ test "decode" <| \() -> JD.decodeString fieldMaskDecoder "\"user.displayName,photo\"" |> equal (Ok [ "user.display_name", "photo" ])
+            , test "decode empty" <| \() -> JD.decodeString fieldMaskDecoder "\"\"" |> equal (Ok [])
+            , test "encode" <| \() -> JE.encode 0 (fieldMaskEncoder [ "user.display_name", "photo" ]) |> equal "\"user.displayName,photo\""
+            ]
+        , describe "empty"
+            [ test "decode" <| \() -> JD.decodeString emptyDecoder "{}" |> equal (Ok Protobuf.Empty)
+            , test "encode" <| \() -> JE.encode 0 (emptyEncoder Protobuf.Empty) |> equal "{}"
+            ]
+        , describe "any"
+            [ test "unpack packed message" <| \() -> AnyRegistry.pack (AnyRegistry.Simple msg) |> AnyRegistry.unpack |> equal (AnyRegistry.Simple msg)
+            , test "pack type URL" <| \() -> AnyRegistry.pack (AnyRegistry.Simple msg) |> anyEncoder |> JD.decodeValue (JD.field "@type" JD.string) |> equal (Ok "type.googleapis.com/Simple")
+            , test "unpack unknown message" <|
+                \() ->
+                    case decode anyDecoder unknownAnyJson |> Result.map AnyRegistry.unpack of
+                        Ok (AnyRegistry.Unknown_ v) ->
+                            equal "type.googleapis.com/Unknown" v.typeUrl
+
+                        _ ->
+                            fail "expected an unknown message"
+            ]
+        , describe "bytes"
+            [ test "encode" <| \() -> JE.encode 0 (bytesFieldEncoder [ 1, 2, 3, 251, 255 ]) |> equal "\"AQID+/8=\""
+            , test "decode" <| \() -> JD.decodeString bytesFieldDecoder "\"AQID+/8=\"" |> equal (Ok [ 1, 2, 3, 251, 255 ])
+            , test "decode URL-safe without padding" <| \() -> JD.decodeString bytesFieldDecoder "\"AQID-_8\"" |> equal (Ok [ 1, 2, 3, 251, 255 ])
+            , test "decode invalid" <| \() -> JD.decodeString bytesFieldDecoder "\"A\"" |> Result.toMaybe |> equal Nothing
+            , fuzz (list (intRange 0 255)) "round trip" <|
+                assertEncodeDecode bytesFieldEncoder bytesFieldDecoder
+            ]
+        , describe "binary"
+            [ test "decode varint" <| \() -> PB.fromBytes B.scalarsBinaryDecoder (binaryBytes [ 0x08, 0x96, 0x01 ]) |> Maybe.map .int32Field |> equal (Just 150)
+            , test "decode negative int32" <| \() -> PB.fromBytes B.scalarsBinaryDecoder (binaryBytes [ 0x08, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01 ]) |> Maybe.map .int32Field |> equal (Just (-1))
+            , test "decode zigzag" <| \() -> PB.fromBytes B.scalarsBinaryDecoder (binaryBytes [ 0x28, 0x03 ]) |> Maybe.map .sint32Field |> equal (Just -2)
+            , test "decode string" <| \() -> PB.fromBytes B.scalarsBinaryDecoder (binaryBytes [ 0x72, 0x07, 0x74, 0x65, 0x73, 0x74, 0x69, 0x6E, 0x67 ]) |> Maybe.map .stringField |> equal (Just "testing")
+            , test "decode packed" <| \() -> PB.fromBytes B.compositeBinaryDecoder (binaryBytes [ 0x12, 0x06, 0x03, 0x8E, 0x02, 0x9E, 0xA7, 0x05 ]) |> Maybe.map .packed |> equal (Just [ 3, 270, 86942 ])
+            , test "decode unpacked" <| \() -> PB.fromBytes B.compositeBinaryDecoder (binaryBytes [ 0x10, 0x03, 0x10, 0x8E, 0x02 ]) |> Maybe.map .packed |> equal (Just [ 3, 270 ])
+            , test "skip unknown fields" <| \() -> PB.fromBytes B.scalarsBinaryDecoder (binaryBytes [ 0x98, 0x06, 0x01, 0xA2, 0x06, 0x02, 0x61, 0x62, 0x93, 0x03, 0x08, 0x01, 0x94, 0x03, 0x08, 0x96, 0x01 ]) |> Maybe.map .int32Field |> equal (Just 150)
+            , test "merge messages" <| \() -> PB.fromBytes B.compositeBinaryDecoder (binaryBytes [ 0x0A, 0x02, 0x08, 0x01, 0x0A, 0x02, 0x18, 0x02 ]) |> Maybe.andThen .scalars |> Maybe.map (\s -> ( s.int32Field, s.uint32Field )) |> equal (Just ( 1, 2 ))
+            , test "last oneof field wins" <|
+                \() ->
+                    case PB.fromBytes B.compositeBinaryDecoder (binaryBytes [ 0x32, 0x01, 0x61, 0x3A, 0x00 ]) |> Maybe.map .choice of
+                        Just (B.Composite_Nested _) ->
+                            pass
+
+                        _ ->
+                            fail "expected the nested variant"
+            , test "decode truncated" <| \() -> PB.fromBytes B.scalarsBinaryDecoder (binaryBytes [ 0x08 ]) |> equal Nothing
+            , test "keep unknown enum number" <| \() -> PB.fromBytes B.scalarsBinaryDecoder (binaryBytes [ 0x80, 0x01, 0x09 ]) |> Maybe.map (\s -> binaryList (B.scalarsBinaryEncoder s)) |> equal (Just [ 0x80, 0x01, 0x09 ])
+            , test "encode varint" <| \() -> PB.fromBytes B.scalarsBinaryDecoder (binaryBytes []) |> Maybe.map (\s -> binaryList (B.scalarsBinaryEncoder { s | int32Field = 150 })) |> equal (Just [ 0x08, 0x96, 0x01 ])
+            , test "encode packed" <| \() -> PB.fromBytes B.compositeBinaryDecoder (binaryBytes []) |> Maybe.map (\c -> binaryList (B.compositeBinaryEncoder { c | packed = [ 3, 270, 86942 ] })) |> equal (Just [ 0x12, 0x06, 0x03, 0x8E, 0x02, 0x9E, 0xA7, 0x05 ])
+            , test "round trip scalars" <| \() -> PB.fromBytes B.scalarsBinaryDecoder (PB.toBytes (B.scalarsBinaryEncoder binaryScalars)) |> equal (Just binaryScalars)
+            , test "round trip composite" <| \() -> PB.fromBytes B.compositeBinaryDecoder (PB.toBytes (B.compositeBinaryEncoder binaryComposite)) |> equal (Just binaryComposite)
+            ]
+        , describe "proto2"
+            [ test "JSON decode defaults" <| \() -> decode L.legacyDecoder "{\"id\":\"a\"}" |> equal (Ok legacy)
+            , test "JSON decode missing required" <| \() -> decode L.legacyDecoder "{\"count\":1}" |> Result.toMaybe |> equal Nothing
+            , test "JSON round trip" <| \() -> assertEncodeDecode L.legacyEncoder L.legacyDecoder { legacy | count = Just 0, limit = 3 }
+            , test "binary decode defaults" <| \() -> PB.fromBytes L.legacyBinaryDecoder (binaryBytes [ 0x0A, 0x01, 0x61 ]) |> equal (Just legacy)
+            , test "binary decode missing required" <| \() -> PB.fromBytes L.legacyBinaryDecoder (binaryBytes [ 0x10, 0x01 ]) |> equal Nothing
+            , test "binary encode defaults" <| \() -> binaryList (L.legacyBinaryEncoder legacy) |> equal [ 0x0A, 0x01, 0x61 ]
+            , test "binary encode present zero" <| \() -> binaryList (L.legacyBinaryEncoder { legacy | count = Just 0 }) |> equal [ 0x0A, 0x01, 0x61, 0x10, 0x00 ]
+            , test "JSON decode unknown closed enum" <| \() -> decode L.legacyDecoder "{\"id\":\"a\",\"tier\":7}" |> equal (Ok legacy)
+            , test "binary decode unknown closed enum" <| \() -> PB.fromBytes L.legacyBinaryDecoder (binaryBytes [ 0x0A, 0x01, 0x61, 0x28, 0x07 ]) |> equal (Just legacy)
+            , test "binary decode unknown closed enum keeps known value" <| \() -> PB.fromBytes L.legacyBinaryDecoder (binaryBytes [ 0x0A, 0x01, 0x61, 0x28, 0x02, 0x28, 0x07 ]) |> Maybe.map .tier |> equal (Just (Just L.Premium))
+            , test "binary decode unknown defaulted closed enum" <| \() -> PB.fromBytes L.legacyBinaryDecoder (binaryBytes [ 0x0A, 0x01, 0x61, 0x48, 0x07 ]) |> Maybe.map .plan |> equal (Just L.Premium)
+            , test "binary decode unknown repeated closed enum" <| \() -> PB.fromBytes L.legacyBinaryDecoder (binaryBytes [ 0x0A, 0x01, 0x61, 0x40, 0x01, 0x40, 0x07, 0x40, 0x02 ]) |> Maybe.map .tiers |> equal (Just [ L.Basic, L.Premium ])
+            , test "binary decode unknown packed closed enum" <| \() -> PB.fromBytes L.legacyBinaryDecoder (binaryBytes [ 0x0A, 0x01, 0x61, 0x42, 0x03, 0x01, 0x07, 0x02 ]) |> Maybe.map .tiers |> equal (Just [ L.Basic, L.Premium ])
+            , test "binary encode expanded" <| \() -> binaryList (L.legacyBinaryEncoder { legacy | scores = [ 1, 2 ] }) |> equal [ 0x0A, 0x01, 0x61, 0x30, 0x01, 0x30, 0x02 ]
+            , test "binary decode packed into expanded" <| \() -> PB.fromBytes L.legacyBinaryDecoder (binaryBytes [ 0x0A, 0x01, 0x61, 0x32, 0x02, 0x01, 0x02 ]) |> Maybe.map .scores |> equal (Just [ 1, 2 ])
+            , test "binary encode group" <| \() -> binaryList (L.legacyBinaryEncoder { legacy | note = Just { text = Just "x" } }) |> equal [ 0x0A, 0x01, 0x61, 0x3B, 0x0A, 0x01, 0x78, 0x3C ]
+            , test "binary decode group" <| \() -> PB.fromBytes L.legacyBinaryDecoder (binaryBytes [ 0x0A, 0x01, 0x61, 0x3B, 0x0A, 0x01, 0x78, 0x3C ]) |> equal (Just { legacy | note = Just { text = Just "x" } })
+            , test "JSON decode group" <| \() -> decode L.legacyDecoder "{\"id\":\"a\",\"note\":{\"text\":\"x\"}}" |> Result.map .note |> equal (Ok (Just { text = Just "x" }))
+            , test "JSON decode extension" <| \() -> decode L.legacyDecoder "{\"id\":\"a\",\"[origin]\":\"web\",\"[marks]\":[1,2]}" |> Result.map (\v -> ( L.getOrigin v, L.getMarks v )) |> equal (Ok ( Just "web", [ 1, 2 ] ))
+            , test "JSON round trip extension" <| \() -> encode L.legacyEncoder (L.setOrigin (Just "web") legacy) |> decode L.legacyDecoder |> Result.map L.getOrigin |> equal (Ok (Just "web"))
+            , test "unset extension" <| \() -> L.setMarks [] (L.setMarks [ 1 ] legacy) |> equal legacy
+            ]
+        , describe "int64"
+            [ test "print max" <| \() -> int64FromString "9223372036854775807" |> Maybe.map int64ToString |> equal (Just "9223372036854775807")
+            , test "print min" <| \() -> int64FromString "-9223372036854775808" |> Maybe.map int64ToString |> equal (Just "-9223372036854775808")
+            , test "print max unsigned" <| \() -> uint64FromString "18446744073709551615" |> Maybe.map int64ToString |> equal (Just "18446744073709551615")
+            , test "print normalized" <| \() -> List.map (int64FromString >> Maybe.map int64ToString) [ "-0", "007" ] |> equal [ Just "0", Just "7" ]
+            , test "parse signed bounds" <| \() -> List.map (int64FromString >> Maybe.map int64ToString) [ "9223372036854775807", "-9223372036854775808" ] |> equal [ Just "9223372036854775807", Just "-9223372036854775808" ]
+            , test "parse signed out of range" <| \() -> List.map int64FromString [ "9223372036854775808", "-9223372036854775809", "18446744073709551615", "-18446744073709551615" ] |> equal [ Nothing, Nothing, Nothing, Nothing ]
+            , test "parse unsigned bounds" <| \() -> List.map (uint64FromString >> Maybe.map int64ToString) [ "0", "9223372036854775808", "18446744073709551615" ] |> equal [ Just "0", Just "9223372036854775808", Just "18446744073709551615" ]
+            , test "parse unsigned out of range" <| \() -> List.map uint64FromString [ "18446744073709551616", "-1" ] |> equal [ Nothing, Nothing ]
+            , test "parse invalid" <| \() -> List.map int64FromString [ "", "-", "1.5", "1e3", " 1" ] |> equal [ Nothing, Nothing, Nothing, Nothing, Nothing ]
+            , test "from int" <| \() -> int64ToString (int64FromInt (-4294967297)) |> equal "-4294967297"
+            , test "to int" <| \() -> int64ToInt (int64Value "-4294967297") |> equal (-4294967297)
+            , test "compare beyond 2^53" <| \() -> compareInt64 (int64Value "9007199254740993") (int64Value "9007199254740992") |> equal GT
+            , test "compare negative" <| \() -> List.sortWith compareInt64 (List.map int64Value [ "1", "-1", "-9223372036854775808", "0" ]) |> List.map int64ToString |> equal [ "-9223372036854775808", "-1", "0", "1" ]
+            , test "JSON decode" <| \() -> decode I64.snowflakeDecoder int64Json |> equal (Ok int64Snowflake)
+            , test "JSON encode" <| \() -> encode I64.snowflakeEncoder int64Snowflake |> equal int64Json
+            , test "JSON decode signed bounds" <| \() -> List.map (decode int64Decoder >> Result.map int64ToString) [ "\"9223372036854775807\"", "\"-9223372036854775808\"" ] |> equal [ Ok "9223372036854775807", Ok "-9223372036854775808" ]
+            , test "JSON reject signed out of range" <| \() -> List.map (decode int64Decoder >> Result.toMaybe) [ "\"9223372036854775808\"", "\"18446744073709551615\"" ] |> equal [ Nothing, Nothing ]
+            , test "JSON decode unsigned max" <| \() -> decode uint64Decoder "\"18446744073709551615\"" |> Result.map int64ToString |> equal (Ok "18446744073709551615")
+            , test "JSON reject unsigned negative" <| \() -> List.map (decode uint64Decoder >> Result.toMaybe) [ "\"-1\"", "-1" ] |> equal [ Nothing, Nothing ]
+            , test "JSON decode unsigned field" <| \() -> decode I64.snowflakeDecoder "{ \"unsigned\": \"-1\", \"labels\": { \"18446744073709551615\": \"x\" } }" |> Result.map (\v -> ( v.unsigned, Dict.keys v.labels )) |> equal (Ok ( int64Zero, [ "18446744073709551615" ] ))
+            , test "JSON decode normalized map key" <| \() -> decode I64.snowflakeDecoder "{ \"labels\": { \"007\": \"x\" } }" |> Result.map .labels |> equal (Ok (Dict.fromList [ ( "7", "x" ) ]))
+            , test "binary encode negative" <| \() -> binaryList (I64.snowflakeBinaryEncoder { int64SnowflakeDefault | id = int64Value "-1" }) |> equal [ 0x08, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01 ]
+            , test "binary encode zigzag" <| \() -> binaryList (I64.snowflakeBinaryEncoder { int64SnowflakeDefault | zigzag = int64Value "-2" }) |> equal [ 0x18, 0x03 ]
+            , test "binary decode max" <| \() -> PB.fromBytes I64.snowflakeBinaryDecoder (binaryBytes [ 0x08, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x7F ]) |> Maybe.map (.id >> int64ToString) |> equal (Just "9223372036854775807")
+            , test "binary decode min" <| \() -> PB.fromBytes I64.snowflakeBinaryDecoder (binaryBytes [ 0x08, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01 ]) |> Maybe.map (.id >> int64ToString) |> equal (Just "-9223372036854775808")
+            , test "binary decode unsigned max" <| \() -> PB.fromBytes I64.snowflakeBinaryDecoder (binaryBytes [ 0x10, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01 ]) |> Maybe.map (.unsigned >> int64ToString) |> equal (Just "18446744073709551615")
+            , test "binary round trip" <| \() -> PB.fromBytes I64.snowflakeBinaryDecoder (PB.toBytes (I64.snowflakeBinaryEncoder int64Snowflake)) |> equal (Just int64Snowflake)
+            ]
+        , describe "strict"
+            [ test "decode" <| \() -> decode S.accountDecoder strictJson |> equal (Ok strictAccount)
+            , test "decode absent and null" <| \() -> decode S.accountDecoder "{ \"name\": null, \"limits\": null, \"quota\": null, \"email\": null }" |> equal (Ok strictAccountDefault)
+            , test "decode null Value" <| \() -> decode S.accountDecoder "{ \"extra\": null }" |> Result.map .extra |> equal (Ok (Just NullValue))
+            , test "decode null Value oneof field" <| \() -> decode S.accountDecoder "{ \"other\": null }" |> Result.map .contact |> equal (Ok (S.Account_Other NullValue))
+            , test "reject null Value oneof field with another one" <| \() -> decode S.accountDecoder "{ \"other\": null, \"email\": \"a\" }" |> Result.toMaybe |> equal Nothing
+            , test "reject wrong type" <| \() -> decode S.accountDecoder "{ \"name\": 1 }" |> Result.toMaybe |> equal Nothing
+            , test "reject unknown enum value" <| \() -> decode S.accountDecoder "{ \"status\": \"GONE\" }" |> Result.toMaybe |> equal Nothing
+            , test "keep unknown enum number" <| \() -> decode S.accountDecoder "{ \"status\": 9 }" |> Result.map .status |> equal (Ok (S.StatusUnrecognized_ 9))
+            , test "reject two oneof fields" <| \() -> decode S.accountDecoder "{ \"email\": \"a\", \"phone\": \"b\" }" |> Result.toMaybe |> equal Nothing
+            , test "reject unknown field" <| \() -> decode S.accountDecoder "{ \"nickname\": \"a\" }" |> Result.toMaybe |> equal Nothing
+            , test "reject non object" <| \() -> decode S.accountDecoder "[]" |> Result.toMaybe |> equal Nothing
+            , test "error path" <|
+                \() ->
+                    case decode S.accountDecoder "{ \"profile\": { \"aliases\": [ \"a\", 1 ] } }" of
+                        Err e ->
+                            JD.errorToString e |> String.contains "json.profile.aliases[1]" |> equal True
+
+                        Ok _ ->
+                            fail "expected a decoding error"
+            , test "round trip" <| \() -> encode S.accountEncoder strictAccount |> decode S.accountDecoder |> equal (Ok strictAccount)
+            ]
+        , describe "opaque"
+            [ test "constructor" <| \() -> ( O.getOrderId opaqueOrder, O.getOrderQuantity opaqueOrder, O.getOrderPayment opaqueOrder ) |> equal ( "a", 1, O.Order_PaymentUnspecified )
+            , test "setter" <| \() -> O.setOrderTags [ "x" ] opaqueOrder |> O.getOrderTags |> equal [ "x" ]
+            , test "JSON round trip" <| \() -> encode O.orderEncoder opaqueNested |> decode O.orderDecoder |> equal (Ok opaqueNested)
+            , test "binary round trip" <| \() -> PB.fromBytes O.orderBinaryDecoder (PB.toBytes (O.orderBinaryEncoder opaqueNested)) |> equal (Just opaqueNested)
+            , test "extension" <| \() -> encode O.orderEncoder (O.setGift (Just "x") opaqueOrder) |> decode O.orderDecoder |> Result.map O.getGift |> equal (Ok (Just "x"))
+            ]
+        , describe "http"
+            [ test "response body" <| \() -> decode (PH.responseBody "repeatedIntField" T.fooDecoder) "[ 1, 2 ]" |> Result.map .repeatedIntField |> equal (Ok [ 1, 2 ])
+            ]
         , describe "wrappers"
             -- TODO: Preserve nulls.
             [ test "encodeEmpty" <| \() -> encode W.wrappersEncoder wrappersEmpty |> equal wrappersJsonEmpty
@@ -114,6 +291,216 @@ genFuzz s1 i1 s2 i2 t =
     }
 
 
+unknownAnyJson : String
+unknownAnyJson =
+    String.trim """
+{
+  "@type": "type.googleapis.com/Unknown",
+  "field": true
+}
+"""
+
+
+struct : Struct
+struct =
+    Dict.fromList
+        [ ( "list", ListValue [ NumberValue 1.5, BoolValue True, NullValue ] )
+        , ( "nested", StructValue (Dict.fromList [ ( "name", StringValue "x" ) ]) )
+        ]
+
+
+structJson : String
+structJson =
+    String.trim """
+{
+  "list": [1.5, true, null],
+  "nested": {"name": "x"}
+}
+"""
+
+
+binaryBytes : List Int -> Bytes.Bytes
+binaryBytes values =
+    BE.encode (BE.sequence (List.map BE.unsignedInt8 values))
+
+
+binaryList : PB.Encoder -> List Int
+binaryList encoder =
+    let
+        encoded =
+            PB.toBytes encoder
+    in
+    BD.decode (BD.loop ( Bytes.width encoded, [] ) binaryListStep) encoded
+        |> Maybe.withDefault []
+
+
+binaryListStep : ( Int, List Int ) -> BD.Decoder (BD.Step ( Int, List Int ) (List Int))
+binaryListStep ( remaining, values ) =
+    if remaining <= 0 then
+        BD.succeed (BD.Done (List.reverse values))
+
+    else
+        BD.map (\x -> BD.Loop ( remaining - 1, x :: values )) BD.unsignedInt8
+
+
+legacy : L.Legacy
+legacy =
+    { id = "a"
+    , count = Nothing
+    , limit = -5
+    , label = "none"
+    , tier = Nothing
+    , scores = []
+    , note = Nothing
+    , tiers = []
+    , plan = L.Premium
+    , extensions_ = noExtensions
+    }
+
+
+opaqueOrder : O.Order
+opaqueOrder =
+    O.newOrder "a"
+
+
+opaqueNested : O.Order
+opaqueNested =
+    opaqueOrder
+        |> O.setOrderParent (Just (O.newOrder "b"))
+        |> O.setOrderPayment (O.Order_Card "1234")
+
+
+binaryScalars : B.Scalars
+binaryScalars =
+    { int32Field = -5
+    , int64Field = -4294967297
+    , uint32Field = 4294967295
+    , uint64Field = 9007199254740991
+    , sint32Field = -123456
+    , sint64Field = -4294967296
+    , fixed32Field = 4294967295
+    , fixed64Field = 4294967296
+    , sfixed32Field = -2147483648
+    , sfixed64Field = -4294967296
+    , floatField = 1.5
+    , doubleField = -2.25e100
+    , boolField = True
+    , stringField = "héllo"
+    , bytesField = [ 0, 255, 128 ]
+    , level = B.High
+    }
+
+
+binaryComposite : B.Composite
+binaryComposite =
+    { scalars = Just binaryScalars
+    , packed = [ 1, -1, 300 ]
+    , names = [ "a", "" ]
+    , children = [ binaryScalars ]
+    , byName = Dict.fromList [ ( "x", binaryScalars ) ]
+    , timestamp = Just (Time.millisToPosix 1500)
+    , duration = Just { seconds = -1, nanos = -500000000 }
+    , wrapped = Just 0
+    , value = Just (StructValue (Dict.fromList [ ( "k", ListValue [ NumberValue 1, BoolValue True, NullValue, StringValue "s" ] ) ]))
+    , choice = B.Composite_Text "hi"
+    }
+
+
+int64Value : String -> Int64
+int64Value v =
+    int64FromString v |> Maybe.withDefault int64Zero
+
+
+uint64Value : String -> Int64
+uint64Value v =
+    uint64FromString v |> Maybe.withDefault int64Zero
+
+
+int64SnowflakeDefault : I64.Snowflake
+int64SnowflakeDefault =
+    { id = int64Zero
+    , unsigned = int64Zero
+    , zigzag = int64Zero
+    , fixed = int64Zero
+    , sfixed = int64Zero
+    , ids = []
+    , wrapped = Nothing
+    , labels = Dict.empty
+    }
+
+
+int64Snowflake : I64.Snowflake
+int64Snowflake =
+    { id = int64Value "1234567890123456789"
+    , unsigned = uint64Value "18446744073709551615"
+    , zigzag = int64Value "-9223372036854775808"
+    , fixed = int64Value "9007199254740993"
+    , sfixed = int64Value "-9007199254740993"
+    , ids = [ int64Value "1", int64Value "-1" ]
+    , wrapped = Just int64Zero
+    , labels = Dict.fromList [ ( "18446744073709551615", "max" ) ]
+    }
+
+
+int64Json : String
+int64Json =
+    String.trim """
+{
+  "id": "1234567890123456789",
+  "unsigned": "18446744073709551615",
+  "zigzag": "-9223372036854775808",
+  "fixed": "9007199254740993",
+  "sfixed": "-9007199254740993",
+  "ids": [
+    "1",
+    "-1"
+  ],
+  "wrapped": "0",
+  "labels": {
+    "18446744073709551615": "max"
+  }
+}
+"""
+
+
+strictAccountDefault : S.Account
+strictAccountDefault =
+    { name = ""
+    , status = S.StatusUnspecified
+    , limits = []
+    , profile = Nothing
+    , quota = Nothing
+    , contact = S.Account_ContactUnspecified
+    , extra = Nothing
+    }
+
+
+strictAccount : S.Account
+strictAccount =
+    { name = "a"
+    , status = S.Active
+    , limits = [ 1, 2 ]
+    , profile = Just { aliases = [ "b" ] }
+    , quota = Just 0
+    , contact = S.Account_Email "a@example.com"
+    , extra = Nothing
+    }
+
+
+strictJson : String
+strictJson =
+    String.trim """
+{
+  "name": "a",
+  "status": "ACTIVE",
+  "limits": [ 1, "2" ],
+  "profile": { "aliases": [ "b" ] },
+  "quota": 0,
+  "email": "a@example.com"
+}
+"""
+
+
 msg : T.Simple
 msg =
     { int32Field = 123
@@ -139,7 +526,7 @@ fooDefault =
     , colours = []
     , singleIntField = 0
     , repeatedIntField = []
-    , oo = T.OoUnspecified
+    , oo = T.Foo_OoUnspecified
     , bytesField = []
     , stringValueField = Nothing
     , otherField = Nothing
@@ -151,8 +538,10 @@ fooDefault =
 recDefault : R.Rec
 recDefault =
     { int32Field = 0
-    , r = R.RUnspecified
+    , r = R.Rec_RUnspecified
+    , child = R.Rec_Child Nothing
     , stringField = ""
+    , children = R.Rec_Children []
     }
 
 
@@ -204,7 +593,7 @@ foo =
         , 222
         , 333
         ]
-    , oo = T.Oo1 1
+    , oo = T.Foo_Oo1 1
     , bytesField = []
     , stringValueField = Nothing
     , otherField =
@@ -277,7 +666,7 @@ wrongTypeJson =
 oo1Set : T.Foo
 oo1Set =
     { fooDefault
-        | oo = T.Oo1 123
+        | oo = T.Foo_Oo1 123
     }
 
 
@@ -293,7 +682,7 @@ oo1SetJson =
 oo2Set : T.Foo
 oo2Set =
     { fooDefault
-        | oo = T.Oo2 True
+        | oo = T.Foo_Oo2 True
     }
 
 
@@ -317,15 +706,7 @@ recJson1 =
 
 rec1 : R.Rec
 rec1 =
-    { int32Field = 0
-    , r =
-        R.RecField
-            { int32Field = 0
-            , r = R.RUnspecified
-            , stringField = ""
-            }
-    , stringField = ""
-    }
+    { recDefault | r = R.Rec_RecField recDefault }
 
 
 recJson2 : String
@@ -341,22 +722,62 @@ recJson2 =
 
 rec2 : R.Rec
 rec2 =
-    { int32Field = 0
-    , r =
-        R.RecField
-            { int32Field = 0
-            , r =
-                R.RecField
-                    { int32Field = 0
-                    , r = R.RUnspecified
-                    , stringField = ""
+    { recDefault | r = R.Rec_RecField rec1 }
+
+
+recJson3 : String
+recJson3 =
+    String.trim """
+{
+  "child": {
+    "children": [
+      {
+        "int32Field": 1
+      },
+      {
+        "child": {}
+      }
+    ]
+  }
+}
+"""
+
+
+rec3 : R.Rec
+rec3 =
+    { recDefault
+        | child =
+            R.Rec_Child
+                (Just
+                    { recDefault
+                        | children =
+                            R.Rec_Children
+                                [ { recDefault | int32Field = 1 }
+                                , { recDefault | child = R.Rec_Child (Just recDefault) }
+                                ]
                     }
-            , stringField = ""
-            }
-    , stringField = ""
+                )
     }
 
 
+outerJson : String
+outerJson =
+    String.trim """
+{
+  "inner": {
+    "outers": [
+      {}
+    ]
+  }
+}
+"""
+
+
+outer : R.Outer
+outer =
+    { inner = R.Outer_Inner (Just { outers = R.Inner_Outers [ { inner = R.Outer_Inner Nothing } ] }) }
+
+
 timestampJson : String
 timestampJson =
     String.trim """
@@ -484,6 +905,17 @@ map =
         [
             ("k1", "v1"),
             ("k2", "v2")
+        ],
+        int32ToStrings = Dict.fromList
+        [
+            (-1, "minus one"),
+            (2, "two"),
+            (10, "ten")
+        ],
+        boolToMessages =
+        [
+            (False, { field = False }),
+            (True, { field = True })
         ]
     }
 
@@ -501,6 +933,17 @@ mapJson =
   "stringToStrings": {
     "k1": "v1",
     "k2": "v2"
+  },
+  "int32ToStrings": {
+    "-1": "minus one",
+    "2": "two",
+    "10": "ten"
+  },
+  "boolToMessages": {
+    "false": {},
+    "true": {
+      "field": true
+    }
   }
 }
 """
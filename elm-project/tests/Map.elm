@@ -1,18 +1,14 @@
-module Map exposing (..)
+module Map exposing (MapValue, MessageWithMaps, MessageWithMaps_BoolToMessagesEntry, MessageWithMaps_Int32ToStringsEntry, MessageWithMaps_StringToMessagesEntry, MessageWithMaps_StringToStringsEntry, mapValueDecoder, mapValueEncoder, messageWithMapsDecoder, messageWithMapsEncoder, messageWithMaps_BoolToMessagesEntryDecoder, messageWithMaps_BoolToMessagesEntryEncoder, messageWithMaps_Int32ToStringsEntryDecoder, messageWithMaps_Int32ToStringsEntryEncoder, messageWithMaps_StringToMessagesEntryDecoder, messageWithMaps_StringToMessagesEntryEncoder, messageWithMaps_StringToStringsEntryDecoder, messageWithMaps_StringToStringsEntryEncoder)
 
 -- DO NOT EDIT
 -- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
 -- https://github.com/tiziano88/elm-protobuf
 -- source file: map.proto
 
-import Protobuf exposing (..)
-
+import Dict
 import Json.Decode as JD
 import Json.Encode as JE
-import Dict
-
-
-uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+import Protobuf exposing (..)
 
 
 type alias MapValue =
@@ -22,36 +18,48 @@ type alias MapValue =
 
 mapValueDecoder : JD.Decoder MapValue
 mapValueDecoder =
-    JD.lazy <| \_ -> decode MapValue
-        |> required "field" JD.bool False
+    JD.lazy <|
+        \_ ->
+            decode MapValue
+                |> required "field" JD.bool False
 
 
 mapValueEncoder : MapValue -> JE.Value
 mapValueEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "field" JE.bool False v.field)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "field" JE.bool False v.field
+            ]
 
 
 type alias MessageWithMaps =
     { stringToMessages : Dict.Dict String MapValue -- 8
     , stringToStrings : Dict.Dict String String -- 7
+    , int32ToStrings : Dict.Dict Int String -- 1
+    , boolToMessages : List ( Bool, MapValue ) -- 2
     }
 
 
 messageWithMapsDecoder : JD.Decoder MessageWithMaps
 messageWithMapsDecoder =
-    JD.lazy <| \_ -> decode MessageWithMaps
-        |> mapEntries "stringToMessages" mapValueDecoder
-        |> mapEntries "stringToStrings" JD.string
+    JD.lazy <|
+        \_ ->
+            decode MessageWithMaps
+                |> mapEntries "stringToMessages" mapValueDecoder
+                |> mapEntries "stringToStrings" JD.string
+                |> keyedMapEntries "int32ToStrings" intKeyFromString JD.string
+                |> boolMapEntries "boolToMessages" mapValueDecoder
 
 
 messageWithMapsEncoder : MessageWithMaps -> JE.Value
 messageWithMapsEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (mapEntriesFieldEncoder "stringToMessages" mapValueEncoder v.stringToMessages)
-        , (mapEntriesFieldEncoder "stringToStrings" JE.string v.stringToStrings)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ mapEntriesFieldEncoder "stringToMessages" mapValueEncoder v.stringToMessages
+            , mapEntriesFieldEncoder "stringToStrings" JE.string v.stringToStrings
+            , keyedMapEntriesFieldEncoder "int32ToStrings" String.fromInt JE.string v.int32ToStrings
+            , boolMapEntriesFieldEncoder "boolToMessages" mapValueEncoder v.boolToMessages
+            ]
 
 
 type alias MessageWithMaps_StringToMessagesEntry =
@@ -62,17 +70,20 @@ type alias MessageWithMaps_StringToMessagesEntry =
 
 messageWithMaps_StringToMessagesEntryDecoder : JD.Decoder MessageWithMaps_StringToMessagesEntry
 messageWithMaps_StringToMessagesEntryDecoder =
-    JD.lazy <| \_ -> decode MessageWithMaps_StringToMessagesEntry
-        |> required "key" JD.string ""
-        |> optional "value" mapValueDecoder
+    JD.lazy <|
+        \_ ->
+            decode MessageWithMaps_StringToMessagesEntry
+                |> required "key" JD.string ""
+                |> optional "value" mapValueDecoder
 
 
 messageWithMaps_StringToMessagesEntryEncoder : MessageWithMaps_StringToMessagesEntry -> JE.Value
 messageWithMaps_StringToMessagesEntryEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "key" JE.string "" v.key)
-        , (optionalEncoder "value" mapValueEncoder v.value)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.string "" v.key
+            , optionalEncoder "value" mapValueEncoder v.value
+            ]
 
 
 type alias MessageWithMaps_StringToStringsEntry =
@@ -83,14 +94,65 @@ type alias MessageWithMaps_StringToStringsEntry =
 
 messageWithMaps_StringToStringsEntryDecoder : JD.Decoder MessageWithMaps_StringToStringsEntry
 messageWithMaps_StringToStringsEntryDecoder =
-    JD.lazy <| \_ -> decode MessageWithMaps_StringToStringsEntry
-        |> required "key" JD.string ""
-        |> required "value" JD.string ""
+    JD.lazy <|
+        \_ ->
+            decode MessageWithMaps_StringToStringsEntry
+                |> required "key" JD.string ""
+                |> required "value" JD.string ""
 
 
 messageWithMaps_StringToStringsEntryEncoder : MessageWithMaps_StringToStringsEntry -> JE.Value
 messageWithMaps_StringToStringsEntryEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "key" JE.string "" v.key)
-        , (requiredFieldEncoder "value" JE.string "" v.value)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.string "" v.key
+            , requiredFieldEncoder "value" JE.string "" v.value
+            ]
+
+
+type alias MessageWithMaps_Int32ToStringsEntry =
+    { key : Int -- 1
+    , value : String -- 2
+    }
+
+
+messageWithMaps_Int32ToStringsEntryDecoder : JD.Decoder MessageWithMaps_Int32ToStringsEntry
+messageWithMaps_Int32ToStringsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode MessageWithMaps_Int32ToStringsEntry
+                |> required "key" intDecoder 0
+                |> required "value" JD.string ""
+
+
+messageWithMaps_Int32ToStringsEntryEncoder : MessageWithMaps_Int32ToStringsEntry -> JE.Value
+messageWithMaps_Int32ToStringsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.int 0 v.key
+            , requiredFieldEncoder "value" JE.string "" v.value
+            ]
+
+
+type alias MessageWithMaps_BoolToMessagesEntry =
+    { key : Bool -- 1
+    , value : Maybe MapValue -- 2
+    }
+
+
+messageWithMaps_BoolToMessagesEntryDecoder : JD.Decoder MessageWithMaps_BoolToMessagesEntry
+messageWithMaps_BoolToMessagesEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode MessageWithMaps_BoolToMessagesEntry
+                |> required "key" JD.bool False
+                |> optional "value" mapValueDecoder
+
+
+messageWithMaps_BoolToMessagesEntryEncoder : MessageWithMaps_BoolToMessagesEntry -> JE.Value
+messageWithMaps_BoolToMessagesEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.bool False v.key
+            , optionalEncoder "value" mapValueEncoder v.value
+            ]
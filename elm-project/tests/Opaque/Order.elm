@@ -0,0 +1,212 @@
+module Opaque.Order exposing (Order, Order_Payment(..), getGift, getOrderExtensions, getOrderId, getOrderParent, getOrderPayment, getOrderQuantity, getOrderTags, newOrder, orderBinaryDecoder, orderBinaryEncoder, orderDecoder, orderEncoder, order_PaymentBinaryDecoder, order_PaymentBinaryEncoder, order_PaymentDecoder, order_PaymentEncoder, setGift, setOrderExtensions, setOrderId, setOrderParent, setOrderPayment, setOrderQuantity, setOrderTags)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: opaque/order.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+import Protobuf.Binary as PB
+
+
+type Order
+    = Order Order_
+
+
+type alias Order_ =
+    { id : String -- 1
+    , quantity : Int -- 2
+    , tags : List String -- 3
+    , parent : Maybe Order -- 4
+    , payment : Order_Payment
+    , extensions_ : Extensions
+    }
+
+
+orderDecoder : JD.Decoder Order
+orderDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Order_
+                |> mandatory "id" JD.string
+                |> required "quantity" intDecoder 1
+                |> repeated "tags" JD.string
+                |> optional "parent" orderDecoder
+                |> field order_PaymentDecoder
+                |> extensions
+                |> JD.map Order
+
+
+orderEncoder : Order -> JE.Value
+orderEncoder (Order v) =
+    JE.object <|
+        List.filterMap identity <|
+            [ mandatoryFieldEncoder "id" JE.string v.id
+            , requiredFieldEncoder "quantity" JE.int 1 v.quantity
+            , repeatedFieldEncoder "tags" JE.string v.tags
+            , optionalEncoder "parent" orderEncoder v.parent
+            , order_PaymentEncoder v.payment
+            ]
+                ++ extensionFields v.extensions_
+
+
+orderBinaryDecoder : PB.Decoder Order
+orderBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Order_
+                |> PB.mandatory 1 PB.string
+                |> PB.defaulted 2 PB.int32 1
+                |> PB.repeated 3 PB.string
+                |> PB.optional 4 (PB.embedded orderBinaryDecoder orderBinaryEncoder)
+                |> PB.field order_PaymentBinaryDecoder
+                |> PB.field (PB.decode noExtensions)
+                |> PB.map Order
+
+
+orderBinaryEncoder : Order -> PB.Encoder
+orderBinaryEncoder (Order v) =
+    PB.encode
+        [ PB.mandatoryFieldEncoder 1 PB.string v.id
+        , PB.defaultedFieldEncoder 2 PB.int32 1 v.quantity
+        , PB.expandedFieldEncoder 3 PB.string v.tags
+        , PB.optionalEncoder 4 (PB.embedded orderBinaryDecoder orderBinaryEncoder) v.parent
+        , order_PaymentBinaryEncoder v.payment
+        ]
+
+
+newOrder : String -> Order
+newOrder id_ =
+    Order
+        { id = id_
+        , quantity = 1
+        , tags = []
+        , parent = Nothing
+        , payment = Order_PaymentUnspecified
+        , extensions_ = noExtensions
+        }
+
+
+getOrderId : Order -> String
+getOrderId (Order v) =
+    v.id
+
+
+setOrderId : String -> Order -> Order
+setOrderId x (Order v) =
+    Order { v | id = x }
+
+
+getOrderQuantity : Order -> Int
+getOrderQuantity (Order v) =
+    v.quantity
+
+
+setOrderQuantity : Int -> Order -> Order
+setOrderQuantity x (Order v) =
+    Order { v | quantity = x }
+
+
+getOrderTags : Order -> List String
+getOrderTags (Order v) =
+    v.tags
+
+
+setOrderTags : List String -> Order -> Order
+setOrderTags x (Order v) =
+    Order { v | tags = x }
+
+
+getOrderParent : Order -> Maybe Order
+getOrderParent (Order v) =
+    v.parent
+
+
+setOrderParent : Maybe Order -> Order -> Order
+setOrderParent x (Order v) =
+    Order { v | parent = x }
+
+
+getOrderPayment : Order -> Order_Payment
+getOrderPayment (Order v) =
+    v.payment
+
+
+setOrderPayment : Order_Payment -> Order -> Order
+setOrderPayment x (Order v) =
+    Order { v | payment = x }
+
+
+getOrderExtensions : Order -> Extensions
+getOrderExtensions (Order v) =
+    v.extensions_
+
+
+setOrderExtensions : Extensions -> Order -> Order
+setOrderExtensions x (Order v) =
+    Order { v | extensions_ = x }
+
+
+type Order_Payment
+    = Order_PaymentUnspecified
+    | Order_Card String
+    | Order_Voucher String
+
+
+order_PaymentDecoder : JD.Decoder Order_Payment
+order_PaymentDecoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map Order_Card (JD.field "card" JD.string)
+                , JD.map Order_Voucher (JD.field "voucher" JD.string)
+                , JD.succeed Order_PaymentUnspecified
+                ]
+
+
+order_PaymentEncoder : Order_Payment -> Maybe ( String, JE.Value )
+order_PaymentEncoder v =
+    case v of
+        Order_PaymentUnspecified ->
+            Nothing
+
+        Order_Card x ->
+            Just ( "card", JE.string x )
+
+        Order_Voucher x ->
+            Just ( "voucher", JE.string x )
+
+
+order_PaymentBinaryDecoder : PB.Decoder Order_Payment
+order_PaymentBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.oneOf Order_PaymentUnspecified
+                [ PB.variant 5 Order_Card PB.string
+                , PB.variant 6 Order_Voucher PB.string
+                ]
+
+
+order_PaymentBinaryEncoder : Order_Payment -> PB.FieldEncoder
+order_PaymentBinaryEncoder v =
+    case v of
+        Order_PaymentUnspecified ->
+            PB.noFieldEncoder
+
+        Order_Card x ->
+            PB.fieldEncoder 5 PB.string x
+
+        Order_Voucher x ->
+            PB.fieldEncoder 6 PB.string x
+
+
+getGift : Order -> Maybe String
+getGift v =
+    getExtension "[opaque.gift]" JD.string (getOrderExtensions v)
+
+
+setGift : Maybe String -> Order -> Order
+setGift x v =
+    setOrderExtensions (setExtension "[opaque.gift]" JE.string x (getOrderExtensions v)) v
@@ -1,17 +1,13 @@
-module Other exposing (..)
+module Other exposing (Other, otherDecoder, otherEncoder)
 
 -- DO NOT EDIT
 -- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
 -- https://github.com/tiziano88/elm-protobuf
 -- source file: other.proto
 
-import Protobuf exposing (..)
-
 import Json.Decode as JD
 import Json.Encode as JE
-
-
-uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+import Protobuf exposing (..)
 
 
 type alias Other =
@@ -21,12 +17,15 @@ type alias Other =
 
 otherDecoder : JD.Decoder Other
 otherDecoder =
-    JD.lazy <| \_ -> decode Other
-        |> required "stringField" JD.string ""
+    JD.lazy <|
+        \_ ->
+            decode Other
+                |> required "stringField" JD.string ""
 
 
 otherEncoder : Other -> JE.Value
 otherEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "stringField" JE.string "" v.stringField)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "stringField" JE.string "" v.stringField
+            ]
@@ -0,0 +1,31 @@
+module Packed exposing (Packed, packedDecoder, packedEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: packed.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+
+
+type alias Packed =
+    { payload : Maybe Protobuf.Any -- 1
+    }
+
+
+packedDecoder : JD.Decoder Packed
+packedDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Packed
+                |> optional "payload" Protobuf.anyDecoder
+
+
+packedEncoder : Packed -> JE.Value
+packedEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "payload" Protobuf.anyEncoder v.payload
+            ]
@@ -1,61 +1,146 @@
-module Recursive exposing (..)
+module Recursive exposing (Inner, Inner_Outers(..), Outer, Outer_Inner(..), Rec, Rec_Child(..), Rec_Children(..), Rec_R(..), innerDecoder, innerEncoder, outerDecoder, outerEncoder, recDecoder, recEncoder, rec_RDecoder, rec_REncoder, unwrapInner_Outers, unwrapOuter_Inner, unwrapRec_Child, unwrapRec_Children)
 
 -- DO NOT EDIT
 -- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
 -- https://github.com/tiziano88/elm-protobuf
 -- source file: recursive.proto
 
-import Protobuf exposing (..)
-
 import Json.Decode as JD
 import Json.Encode as JE
-
-
-uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+import Protobuf exposing (..)
 
 
 type alias Rec =
     { int32Field : Int -- 1
+    , child : Rec_Child -- 3
     , stringField : String -- 4
-    , r : R
+    , children : Rec_Children -- 5
+    , r : Rec_R
     }
 
 
 recDecoder : JD.Decoder Rec
 recDecoder =
-    JD.lazy <| \_ -> decode Rec
-        |> required "int32Field" intDecoder 0
-        |> required "stringField" JD.string ""
-        |> field rDecoder
+    JD.lazy <|
+        \_ ->
+            decode Rec
+                |> required "int32Field" intDecoder 0
+                |> wrapped Rec_Child (optional "child" recDecoder)
+                |> required "stringField" JD.string ""
+                |> wrapped Rec_Children (repeated "children" recDecoder)
+                |> field rec_RDecoder
 
 
 recEncoder : Rec -> JE.Value
 recEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "int32Field" JE.int 0 v.int32Field)
-        , (requiredFieldEncoder "stringField" JE.string "" v.stringField)
-        , (rEncoder v.r)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "int32Field" JE.int 0 v.int32Field
+            , optionalEncoder "child" recEncoder (unwrapRec_Child v.child)
+            , requiredFieldEncoder "stringField" JE.string "" v.stringField
+            , repeatedFieldEncoder "children" recEncoder (unwrapRec_Children v.children)
+            , rec_REncoder v.r
+            ]
+
+
+type Rec_Child
+    = Rec_Child (Maybe Rec)
+
+
+unwrapRec_Child : Rec_Child -> Maybe Rec
+unwrapRec_Child (Rec_Child v) =
+    v
 
 
-type R
-    = RUnspecified
-    | RecField Rec
+type Rec_Children
+    = Rec_Children (List Rec)
 
 
-rDecoder : JD.Decoder R
-rDecoder =
-    JD.lazy <| \_ -> JD.oneOf
-        [ JD.map RecField (JD.field "recField" recDecoder)
-        , JD.succeed RUnspecified
-        ]
+unwrapRec_Children : Rec_Children -> List Rec
+unwrapRec_Children (Rec_Children v) =
+    v
 
 
-rEncoder : R -> Maybe ( String, JE.Value )
-rEncoder v =
+type Rec_R
+    = Rec_RUnspecified
+    | Rec_RecField Rec
+
+
+rec_RDecoder : JD.Decoder Rec_R
+rec_RDecoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map Rec_RecField (JD.field "recField" recDecoder)
+                , JD.succeed Rec_RUnspecified
+                ]
+
+
+rec_REncoder : Rec_R -> Maybe ( String, JE.Value )
+rec_REncoder v =
     case v of
-        RUnspecified ->
+        Rec_RUnspecified ->
             Nothing
 
-        RecField x ->
+        Rec_RecField x ->
             Just ( "recField", recEncoder x )
+
+
+type alias Outer =
+    { inner : Outer_Inner -- 1
+    }
+
+
+outerDecoder : JD.Decoder Outer
+outerDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Outer
+                |> wrapped Outer_Inner (optional "inner" innerDecoder)
+
+
+outerEncoder : Outer -> JE.Value
+outerEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "inner" innerEncoder (unwrapOuter_Inner v.inner)
+            ]
+
+
+type Outer_Inner
+    = Outer_Inner (Maybe Inner)
+
+
+unwrapOuter_Inner : Outer_Inner -> Maybe Inner
+unwrapOuter_Inner (Outer_Inner v) =
+    v
+
+
+type alias Inner =
+    { outers : Inner_Outers -- 1
+    }
+
+
+innerDecoder : JD.Decoder Inner
+innerDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Inner
+                |> wrapped Inner_Outers (repeated "outers" outerDecoder)
+
+
+innerEncoder : Inner -> JE.Value
+innerEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ repeatedFieldEncoder "outers" outerEncoder (unwrapInner_Outers v.outers)
+            ]
+
+
+type Inner_Outers
+    = Inner_Outers (List Outer)
+
+
+unwrapInner_Outers : Inner_Outers -> List Outer
+unwrapInner_Outers (Inner_Outers v) =
+    v
@@ -1,21 +1,15 @@
-module Simple exposing (..)
+module Simple exposing (Colour(..), Empty, Foo, Foo_Oo(..), Shape(..), Simple, colourDecoder, colourDefault, colourEncoder, emptyDecoder, emptyEncoder, fooDecoder, fooEncoder, foo_OoDecoder, foo_OoEncoder, shapeDecoder, shapeDefault, shapeEncoder, simpleDecoder, simpleEncoder)
 
 -- DO NOT EDIT
 -- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
 -- https://github.com/tiziano88/elm-protobuf
 -- source file: simple.proto
 
-import Protobuf exposing (..)
-
+import Dir.Other_dir as DirOther_dir
 import Json.Decode as JD
 import Json.Encode as JE
-import Dir.Other_dir exposing (..)
-
-import Other exposing (..)
-
-
-
-uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+import Other
+import Protobuf exposing (..)
 
 
 type Colour
@@ -23,6 +17,7 @@ type Colour
     | Red -- 1
     | Green -- 2
     | Blue -- 3
+    | ColourUnrecognized_ Int
 
 
 colourDecoder : JD.Decoder Colour
@@ -44,12 +39,30 @@ colourDecoder =
 
                 _ ->
                     ColourUnspecified
+
+        fromNumber n =
+            case n of
+                0 ->
+                    ColourUnspecified
+
+                1 ->
+                    Red
+
+                2 ->
+                    Green
+
+                3 ->
+                    Blue
+
+                _ ->
+                    ColourUnrecognized_ n
     in
-        JD.map lookup JD.string
+    JD.oneOf [ JD.map lookup JD.string, JD.map fromNumber JD.int ]
 
 
 colourDefault : Colour
-colourDefault = ColourUnspecified
+colourDefault =
+    ColourUnspecified
 
 
 colourEncoder : Colour -> JE.Value
@@ -58,23 +71,84 @@ colourEncoder v =
         lookup s =
             case s of
                 ColourUnspecified ->
-                    "COLOUR_UNSPECIFIED"
+                    JE.string "COLOUR_UNSPECIFIED"
 
                 Red ->
-                    "RED"
+                    JE.string "RED"
 
                 Green ->
-                    "GREEN"
+                    JE.string "GREEN"
 
                 Blue ->
-                    "BLUE"
+                    JE.string "BLUE"
+
+                ColourUnrecognized_ n ->
+                    JE.int n
+    in
+    lookup v
+
+
+type Shape
+    = ShapeUnspecified -- 0
+    | Circle -- 1
+    | ShapeUnrecognized_ Int
+
+
+shapeDecoder : JD.Decoder Shape
+shapeDecoder =
+    let
+        lookup s =
+            case s of
+                "SHAPE_UNSPECIFIED" ->
+                    ShapeUnspecified
+
+                "CIRCLE" ->
+                    Circle
+
+                "ROUND" ->
+                    Circle
+
+                _ ->
+                    ShapeUnspecified
+
+        fromNumber n =
+            case n of
+                0 ->
+                    ShapeUnspecified
+
+                1 ->
+                    Circle
+
+                _ ->
+                    ShapeUnrecognized_ n
+    in
+    JD.oneOf [ JD.map lookup JD.string, JD.map fromNumber JD.int ]
+
+
+shapeDefault : Shape
+shapeDefault =
+    ShapeUnspecified
+
+
+shapeEncoder : Shape -> JE.Value
+shapeEncoder v =
+    let
+        lookup s =
+            case s of
+                ShapeUnspecified ->
+                    JE.string "SHAPE_UNSPECIFIED"
+
+                Circle ->
+                    JE.string "CIRCLE"
 
+                ShapeUnrecognized_ n ->
+                    JE.int n
     in
-        JE.string <| lookup v
+    lookup v
 
 
 type alias Empty =
-    { }
+    {}
 
 
 emptyDecoder : JD.Decoder Empty
@@ -84,8 +158,7 @@ emptyDecoder =
 
 emptyEncoder : Empty -> JE.Value
 emptyEncoder v =
-    JE.object <| List.filterMap identity <|
-        []
+    JE.object <| List.filterMap identity <| []
 
 
 type alias Simple =
@@ -95,15 +168,18 @@ type alias Simple =
 
 simpleDecoder : JD.Decoder Simple
 simpleDecoder =
-    JD.lazy <| \_ -> decode Simple
-        |> required "int32Field" intDecoder 0
+    JD.lazy <|
+        \_ ->
+            decode Simple
+                |> required "int32Field" intDecoder 0
 
 
 simpleEncoder : Simple -> JE.Value
 simpleEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "int32Field" JE.int 0 v.int32Field)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "int32Field" JE.int 0 v.int32Field
+            ]
 
 
 type alias Foo =
@@ -115,71 +191,76 @@ type alias Foo =
     , repeatedIntField : List Int -- 6
     , bytesField : Bytes -- 9
     , stringValueField : Maybe String -- 10
-    , otherField : Maybe Other -- 11
-    , otherDirField : Maybe OtherDir -- 12
+    , otherField : Maybe Other.Other -- 11
+    , otherDirField : Maybe DirOther_dir.OtherDir -- 12
     , timestampField : Maybe Timestamp -- 13
-    , oo : Oo
+    , oo : Foo_Oo
     }
 
 
 fooDecoder : JD.Decoder Foo
 fooDecoder =
-    JD.lazy <| \_ -> decode Foo
-        |> optional "s" simpleDecoder
-        |> repeated "ss" simpleDecoder
-        |> required "colour" colourDecoder colourDefault
-        |> repeated "colours" colourDecoder
-        |> required "singleIntField" intDecoder 0
-        |> repeated "repeatedIntField" intDecoder
-        |> required "bytesField" bytesFieldDecoder []
-        |> optional "stringValueField" stringValueDecoder
-        |> optional "otherField" otherDecoder
-        |> optional "otherDirField" otherDirDecoder
-        |> optional "timestampField" timestampDecoder
-        |> field ooDecoder
+    JD.lazy <|
+        \_ ->
+            decode Foo
+                |> optional "s" simpleDecoder
+                |> repeated "ss" simpleDecoder
+                |> required "colour" colourDecoder colourDefault
+                |> repeated "colours" colourDecoder
+                |> required "singleIntField" intDecoder 0
+                |> repeated "repeatedIntField" intDecoder
+                |> required "bytesField" bytesFieldDecoder []
+                |> optional "stringValueField" stringValueDecoder
+                |> optional "otherField" Other.otherDecoder
+                |> optional "otherDirField" DirOther_dir.otherDirDecoder
+                |> optional "timestampField" timestampDecoder
+                |> field foo_OoDecoder
 
 
 fooEncoder : Foo -> JE.Value
 fooEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (optionalEncoder "s" simpleEncoder v.s)
-        , (repeatedFieldEncoder "ss" simpleEncoder v.ss)
-        , (requiredFieldEncoder "colour" colourEncoder colourDefault v.colour)
-        , (repeatedFieldEncoder "colours" colourEncoder v.colours)
-        , (requiredFieldEncoder "singleIntField" JE.int 0 v.singleIntField)
-        , (repeatedFieldEncoder "repeatedIntField" JE.int v.repeatedIntField)
-        , (requiredFieldEncoder "bytesField" bytesFieldEncoder [] v.bytesField)
-        , (optionalEncoder "stringValueField" stringValueEncoder v.stringValueField)
-        , (optionalEncoder "otherField" otherEncoder v.otherField)
-        , (optionalEncoder "otherDirField" otherDirEncoder v.otherDirField)
-        , (optionalEncoder "timestampField" timestampEncoder v.timestampField)
-        , (ooEncoder v.oo)
-        ]
-
-
-type Oo
-    = OoUnspecified
-    | Oo1 Int
-    | Oo2 Bool
-
-
-ooDecoder : JD.Decoder Oo
-ooDecoder =
-    JD.lazy <| \_ -> JD.oneOf
-        [ JD.map Oo1 (JD.field "oo1" intDecoder)
-        , JD.map Oo2 (JD.field "oo2" JD.bool)
-        , JD.succeed OoUnspecified
-        ]
-
-
-ooEncoder : Oo -> Maybe ( String, JE.Value )
-ooEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "s" simpleEncoder v.s
+            , repeatedFieldEncoder "ss" simpleEncoder v.ss
+            , requiredFieldEncoder "colour" colourEncoder colourDefault v.colour
+            , repeatedFieldEncoder "colours" colourEncoder v.colours
+            , requiredFieldEncoder "singleIntField" JE.int 0 v.singleIntField
+            , repeatedFieldEncoder "repeatedIntField" JE.int v.repeatedIntField
+            , requiredFieldEncoder "bytesField" bytesFieldEncoder [] v.bytesField
+            , optionalEncoder "stringValueField" stringValueEncoder v.stringValueField
+            , optionalEncoder "otherField" Other.otherEncoder v.otherField
+            , optionalEncoder "otherDirField" DirOther_dir.otherDirEncoder v.otherDirField
+            , optionalEncoder "timestampField" timestampEncoder v.timestampField
+            , foo_OoEncoder v.oo
+            ]
+
+
+type Foo_Oo
+    = Foo_OoUnspecified
+    | Foo_Oo1 Int
+    | Foo_Oo2 Bool
+
+
+foo_OoDecoder : JD.Decoder Foo_Oo
+foo_OoDecoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map Foo_Oo1 (JD.field "oo1" intDecoder)
+                , JD.map Foo_Oo2 (JD.field "oo2" JD.bool)
+                , JD.succeed Foo_OoUnspecified
+                ]
+
+
+foo_OoEncoder : Foo_Oo -> Maybe ( String, JE.Value )
+foo_OoEncoder v =
     case v of
-        OoUnspecified ->
+        Foo_OoUnspecified ->
             Nothing
 
-        Oo1 x ->
+        Foo_Oo1 x ->
             Just ( "oo1", JE.int x )
 
-        Oo2 x ->
+        Foo_Oo2 x ->
             Just ( "oo2", JE.bool x )
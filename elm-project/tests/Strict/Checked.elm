@@ -0,0 +1,160 @@
+module Strict.Checked exposing (Account, Account_Contact(..), Profile, Status(..), accountDecoder, accountEncoder, account_ContactDecoder, account_ContactEncoder, profileDecoder, profileEncoder, statusDecoder, statusDefault, statusEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: strict/checked.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+
+
+type Status
+    = StatusUnspecified -- 0
+    | Active -- 1
+    | StatusUnrecognized_ Int
+
+
+statusDecoder : JD.Decoder Status
+statusDecoder =
+    let
+        lookup s =
+            case s of
+                "STATUS_UNSPECIFIED" ->
+                    JD.succeed StatusUnspecified
+
+                "ACTIVE" ->
+                    JD.succeed Active
+
+                _ ->
+                    JD.fail ("unknown value \"" ++ s ++ "\"")
+
+        fromNumber n =
+            case n of
+                0 ->
+                    StatusUnspecified
+
+                1 ->
+                    Active
+
+                _ ->
+                    StatusUnrecognized_ n
+    in
+    JD.oneOf [ JD.andThen lookup JD.string, JD.map fromNumber JD.int ]
+
+
+statusDefault : Status
+statusDefault =
+    StatusUnspecified
+
+
+statusEncoder : Status -> JE.Value
+statusEncoder v =
+    let
+        lookup s =
+            case s of
+                StatusUnspecified ->
+                    JE.string "STATUS_UNSPECIFIED"
+
+                Active ->
+                    JE.string "ACTIVE"
+
+                StatusUnrecognized_ n ->
+                    JE.int n
+    in
+    lookup v
+
+
+type alias Account =
+    { name : String -- 1
+    , status : Status -- 2
+    , limits : List Int -- 3
+    , profile : Maybe Profile -- 4
+    , quota : Maybe Int -- 5
+    , extra : Maybe Protobuf.Value -- 8
+    , contact : Account_Contact
+    }
+
+
+accountDecoder : JD.Decoder Account
+accountDecoder =
+    JD.lazy <|
+        \_ ->
+            strictDecodeKnown [ "name", "status", "limits", "profile", "quota", "email", "phone", "other", "extra" ] Account
+                |> strictRequired "name" JD.string ""
+                |> strictRequired "status" statusDecoder statusDefault
+                |> strictRepeated "limits" intDecoder
+                |> strictOptional "profile" profileDecoder
+                |> strictOptional "quota" intValueDecoder
+                |> strictOptional "extra" Protobuf.valueDecoder
+                |> field account_ContactDecoder
+
+
+accountEncoder : Account -> JE.Value
+accountEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "name" JE.string "" v.name
+            , requiredFieldEncoder "status" statusEncoder statusDefault v.status
+            , repeatedFieldEncoder "limits" JE.int v.limits
+            , optionalEncoder "profile" profileEncoder v.profile
+            , optionalEncoder "quota" intValueEncoder v.quota
+            , optionalEncoder "extra" Protobuf.valueEncoder v.extra
+            , account_ContactEncoder v.contact
+            ]
+
+
+type Account_Contact
+    = Account_ContactUnspecified
+    | Account_Email String
+    | Account_Phone String
+    | Account_Other Protobuf.Value
+
+
+account_ContactDecoder : JD.Decoder Account_Contact
+account_ContactDecoder =
+    JD.lazy <|
+        \_ ->
+            strictOneOf Account_ContactUnspecified
+                [ ( "email", JD.map Account_Email JD.string )
+                , ( "phone", JD.map Account_Phone JD.string )
+                , ( "other", JD.map Account_Other Protobuf.valueDecoder )
+                ]
+
+
+account_ContactEncoder : Account_Contact -> Maybe ( String, JE.Value )
+account_ContactEncoder v =
+    case v of
+        Account_ContactUnspecified ->
+            Nothing
+
+        Account_Email x ->
+            Just ( "email", JE.string x )
+
+        Account_Phone x ->
+            Just ( "phone", JE.string x )
+
+        Account_Other x ->
+            Just ( "other", Protobuf.valueEncoder x )
+
+
+type alias Profile =
+    { aliases : List String -- 1
+    }
+
+
+profileDecoder : JD.Decoder Profile
+profileDecoder =
+    JD.lazy <|
+        \_ ->
+            strictDecodeKnown [ "aliases" ] Profile
+                |> strictRepeated "aliases" JD.string
+
+
+profileEncoder : Profile -> JE.Value
+profileEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ repeatedFieldEncoder "aliases" JE.string v.aliases
+            ]
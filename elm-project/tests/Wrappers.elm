@@ -1,17 +1,13 @@
-module Wrappers exposing (..)
+module Wrappers exposing (Wrappers, wrappersDecoder, wrappersEncoder)
 
 -- DO NOT EDIT
 -- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
 -- https://github.com/tiziano88/elm-protobuf
 -- source file: wrappers.proto
 
-import Protobuf exposing (..)
-
 import Json.Decode as JD
 import Json.Encode as JE
-
-
-uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+import Protobuf exposing (..)
 
 
 type alias Wrappers =
@@ -29,28 +25,31 @@ type alias Wrappers =
 
 wrappersDecoder : JD.Decoder Wrappers
 wrappersDecoder =
-    JD.lazy <| \_ -> decode Wrappers
-        |> optional "int32ValueField" intValueDecoder
-        |> optional "int64ValueField" intValueDecoder
-        |> optional "uInt32ValueField" intValueDecoder
-        |> optional "uInt64ValueField" intValueDecoder
-        |> optional "doubleValueField" floatValueDecoder
-        |> optional "floatValueField" floatValueDecoder
-        |> optional "boolValueField" boolValueDecoder
-        |> optional "stringValueField" stringValueDecoder
-        |> optional "bytesValueField" bytesValueDecoder
+    JD.lazy <|
+        \_ ->
+            decode Wrappers
+                |> optional "int32ValueField" intValueDecoder
+                |> optional "int64ValueField" intValueDecoder
+                |> optional "uInt32ValueField" intValueDecoder
+                |> optional "uInt64ValueField" intValueDecoder
+                |> optional "doubleValueField" floatValueDecoder
+                |> optional "floatValueField" floatValueDecoder
+                |> optional "boolValueField" boolValueDecoder
+                |> optional "stringValueField" stringValueDecoder
+                |> optional "bytesValueField" bytesValueDecoder
 
 
 wrappersEncoder : Wrappers -> JE.Value
 wrappersEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (optionalEncoder "int32ValueField" intValueEncoder v.int32ValueField)
-        , (optionalEncoder "int64ValueField" numericStringEncoder v.int64ValueField)
-        , (optionalEncoder "uInt32ValueField" intValueEncoder v.uInt32ValueField)
-        , (optionalEncoder "uInt64ValueField" numericStringEncoder v.uInt64ValueField)
-        , (optionalEncoder "doubleValueField" floatValueEncoder v.doubleValueField)
-        , (optionalEncoder "floatValueField" floatValueEncoder v.floatValueField)
-        , (optionalEncoder "boolValueField" boolValueEncoder v.boolValueField)
-        , (optionalEncoder "stringValueField" stringValueEncoder v.stringValueField)
-        , (optionalEncoder "bytesValueField" bytesValueEncoder v.bytesValueField)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "int32ValueField" intValueEncoder v.int32ValueField
+            , optionalEncoder "int64ValueField" numericStringEncoder v.int64ValueField
+            , optionalEncoder "uInt32ValueField" intValueEncoder v.uInt32ValueField
+            , optionalEncoder "uInt64ValueField" numericStringEncoder v.uInt64ValueField
+            , optionalEncoder "doubleValueField" floatValueEncoder v.doubleValueField
+            , optionalEncoder "floatValueField" floatValueEncoder v.floatValueField
+            , optionalEncoder "boolValueField" boolValueEncoder v.boolValueField
+            , optionalEncoder "stringValueField" stringValueEncoder v.stringValueField
+            , optionalEncoder "bytesValueField" bytesValueEncoder v.bytesValueField
+            ]
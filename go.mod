@@ -1,9 +1,9 @@
 module github.com/jalandis/elm-protobuf
 
-go 1.13
+go 1.20
 
 require (
 	github.com/gogo/protobuf v1.3.2
 	github.com/pkg/errors v0.9.1
-	google.golang.org/protobuf v1.28.1
+	google.golang.org/protobuf v1.34.2
 )
@@ -0,0 +1,235 @@
+package elm
+
+// Module - Elm source file, see Print
+type Module struct {
+	Name     string
+	Exposing []string
+	// Comments - line comments between the module declaration and the imports
+	Comments     []string
+	Imports      []Import
+	Declarations []Declaration
+}
+
+// Declaration - top level declaration of a module
+type Declaration interface {
+	declaration()
+}
+
+// CommentDeclaration - line comments standing between top level declarations
+type CommentDeclaration struct {
+	Lines []string
+}
+
+// TypeAliasDeclaration - `type alias Name = ...`
+type TypeAliasDeclaration struct {
+	Doc  []string
+	Name Type
+	Type TypeExpr
+}
+
+// CustomTypeDeclaration - `type Name = A | B ...`
+type CustomTypeDeclaration struct {
+	Doc          []string
+	Name         Type
+	Constructors []Constructor
+}
+
+// Constructor - variant of a custom type declaration
+type Constructor struct {
+	Name VariantName
+	Args []TypeExpr
+	// Comments - line comments before the variant, EOLComment - comment ending its line
+	Comments   []string
+	EOLComment string
+}
+
+// ValueDeclaration - function or constant, without annotation inside let expressions
+type ValueDeclaration struct {
+	Doc        []string
+	Name       VariableName
+	Annotation TypeExpr
+	Args       []Pattern
+	Body       Expr
+}
+
+func (CommentDeclaration) declaration()    {}
+func (TypeAliasDeclaration) declaration()  {}
+func (CustomTypeDeclaration) declaration() {}
+func (ValueDeclaration) declaration()      {}
+
+// TypeExpr - Elm type, Type being a type without arguments
+type TypeExpr interface {
+	typeExpr()
+}
+
+// TypeRef - type applied to arguments, ex. `Maybe Int`
+type TypeRef struct {
+	Name Type
+	Args []TypeExpr
+}
+
+// FunctionType - `a -> b -> c`
+type FunctionType struct {
+	Args   []TypeExpr
+	Result TypeExpr
+}
+
+// TupleType - `( a, b )`
+type TupleType []TypeExpr
+
+// RecordType - `{ a : Int, b : String }`
+type RecordType struct {
+	Fields []RecordFieldType
+}
+
+// RecordFieldType - field of a record type
+type RecordFieldType struct {
+	Name VariableName
+	Type TypeExpr
+	// Comments - line comments before the field, EOLComment - comment ending its line
+	Comments   []string
+	EOLComment string
+}
+
+func (Type) typeExpr()         {}
+func (TypeRef) typeExpr()      {}
+func (FunctionType) typeExpr() {}
+func (TupleType) typeExpr()    {}
+func (RecordType) typeExpr()   {}
+
+// Expr - Elm expression, VariableName and VariantName being references to a value
+type Expr interface {
+	expr()
+}
+
+// Literal - number or string literal, see StringLiteral
+type Literal string
+
+// Call - function application
+type Call struct {
+	Func Expr
+	Args []Expr
+}
+
+// BinOp - binary operator application, `<|` breaking after the operator
+type BinOp struct {
+	Left  Expr
+	Op    string
+	Right Expr
+}
+
+// Pipeline - `Head |> Steps[0] |> ...`, one step per line
+type Pipeline struct {
+	Head  Expr
+	Steps []Expr
+}
+
+// Lambda - `\a b -> Body`
+type Lambda struct {
+	Params []Pattern
+	Body   Expr
+}
+
+// Case - `case Subject of ...`
+type Case struct {
+	Subject  Expr
+	Branches []Branch
+}
+
+// Branch - pattern and body of a case expression
+type Branch struct {
+	Pattern Pattern
+	Body    Expr
+}
+
+// Let - `let ... in Body`
+type Let struct {
+	Declarations []ValueDeclaration
+	Body         Expr
+}
+
+// List - `[ a, b ]`, one item per line when Multiline
+type List struct {
+	Items     []Expr
+	Multiline bool
+}
+
+// Tuple - `( a, b )`
+type Tuple []Expr
+
+// Record - `{ a = 1, b = 2 }`, one field per line when Multiline
+type Record struct {
+	Fields    []RecordField
+	Multiline bool
+}
+
+// RecordUpdate - `{ Record | a = 1 }`
+type RecordUpdate struct {
+	Record VariableName
+	Fields []RecordField
+}
+
+// RecordField - field of a record or record update expression
+type RecordField struct {
+	Name  VariableName
+	Value Expr
+}
+
+// Access - `Record.Field`
+type Access struct {
+	Record VariableName
+	Field  VariableName
+}
+
+func (VariableName) expr() {}
+func (VariantName) expr()  {}
+func (Literal) expr()      {}
+func (Call) expr()         {}
+func (BinOp) expr()        {}
+func (Pipeline) expr()     {}
+func (Lambda) expr()       {}
+func (Case) expr()         {}
+func (Let) expr()          {}
+func (List) expr()         {}
+func (Tuple) expr()        {}
+func (Record) expr()       {}
+func (RecordUpdate) expr() {}
+func (Access) expr()       {}
+
+// Pattern - Elm pattern, VariableName binding a variable and Literal matching a constant
+type Pattern interface {
+	pattern()
+}
+
+// ConstructorPattern - `Name a b`
+type ConstructorPattern struct {
+	Name VariantName
+	Args []Pattern
+}
+
+func (VariableName) pattern()       {}
+func (VariantName) pattern()        {}
+func (Literal) pattern()            {}
+func (ConstructorPattern) pattern() {}
+
+// wildcard - pattern matching anything
+const wildcard VariableName = "_"
+
+// Apply - application of a function to arguments, the function itself without arguments
+func Apply(f Expr, args ...Expr) Expr {
+	if len(args) == 0 {
+		return f
+	}
+
+	// Partial applications take the additional arguments.
+	if call, ok := f.(Call); ok {
+		return Call{Func: call.Func, Args: append(append([]Expr{}, call.Args...), args...)}
+	}
+
+	return Call{Func: f, Args: args}
+}
+
+// StringLiteral - Elm String literal
+func StringLiteral(value string) Literal {
+	return Literal(stringLiteral(value))
+}
@@ -0,0 +1,218 @@
+package elm
+
+import (
+	"fmt"
+
+	"github.com/jalandis/elm-protobuf/pkg/stringextras"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// BinaryDecoderName - binary decoder function name for Elm type
+func BinaryDecoderName(t Type) VariableName {
+	return VariableName(stringextras.FirstLower(fmt.Sprintf("%sBinaryDecoder", t)))
+}
+
+// BinaryEncoderName - binary encoder function name for Elm type
+func BinaryEncoderName(t Type) VariableName {
+	return VariableName(stringextras.FirstLower(fmt.Sprintf("%sBinaryEncoder", t)))
+}
+
+// BasicFieldBinaryType - Protobuf.Binary field type for a single value of a PB field
+func BasicFieldBinaryType(r *Registry, inField *descriptorpb.FieldDescriptorProto) (Expr, error) {
+	switch inField.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_INT32:
+		return VariableName("PB.int32"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64:
+		return int64BinaryType(r, "PB.int64", "PB.exactInt64", "PB.decimal"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32:
+		return VariableName("PB.uint32"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT64:
+		return int64BinaryType(r, "PB.uint64", "PB.exactUint64", "PB.unsignedDecimal"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_SINT32:
+		return VariableName("PB.sint32"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_SINT64:
+		return int64BinaryType(r, "PB.sint64", "PB.exactSint64", "PB.decimal"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_FIXED32:
+		return VariableName("PB.fixed32"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		return int64BinaryType(r, "PB.fixed64", "PB.exactFixed64", "PB.unsignedDecimal"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		return VariableName("PB.sfixed32"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		return int64BinaryType(r, "PB.sfixed64", "PB.exactSfixed64", "PB.decimal"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
+		return VariableName("PB.float"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+		return VariableName("PB.double"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return VariableName("PB.bool"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return VariableName("PB.string"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+		if r.options.Bytes == BytesAsElmBytes {
+			return VariableName("PB.elmBytes"), nil
+		}
+
+		return VariableName("PB.bytes"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM,
+		descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		if n, ok := r.wellKnownType(inField.GetTypeName()); ok {
+			if n.Binary == nil {
+				return nil, fmt.Errorf("no binary encoding for %s", inField.GetTypeName())
+			}
+
+			return n.Binary, nil
+		}
+
+		symbol, err := r.Lookup(inField.GetTypeName())
+		if err != nil {
+			return nil, err
+		}
+
+		helper := VariableName("PB.embedded")
+		if inField.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP {
+			helper = "PB.group"
+		} else if symbol.Enum != nil && symbol.Features.Closed() {
+			helper = "PB.closedEnum"
+		} else if symbol.Enum != nil {
+			helper = "PB.enum"
+		}
+
+		return Apply(
+			helper,
+			VariableName(r.Qualify(symbol, string(BinaryDecoderName(symbol.Type)))),
+			VariableName(r.Qualify(symbol, string(BinaryEncoderName(symbol.Type)))),
+		), nil
+	default:
+		return nil, fmt.Errorf("no binary encoding for field type %s", inField.GetType())
+	}
+}
+
+// int64BinaryType - Protobuf.Binary field type for a PB 64 bit integer, given its `Int` and exact
+// `Protobuf.Int64` variants, and the decimal string wrapper of the exact one
+func int64BinaryType(r *Registry, intType VariableName, exactType VariableName, decimal VariableName) Expr {
+	switch r.options.Int64 {
+	case Int64AsString:
+		return Apply(decimal, exactType)
+	case Int64AsInt64:
+		return exactType
+	default:
+		return intType
+	}
+}
+
+// binaryFieldCodec - binary decoder and encoder for a PB field, built with the given
+// Protobuf.Binary helpers
+func binaryFieldCodec(
+	r *Registry,
+	pb *descriptorpb.FieldDescriptorProto,
+	decoderHelper VariableName,
+	encoderHelper VariableName,
+) (Expr, Expr, error) {
+	fieldType, err := BasicFieldBinaryType(r, pb)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decoder := Apply(decoderHelper, fieldNumber(pb), fieldType)
+	encoder := Apply(encoderHelper, fieldNumber(pb), fieldType, fieldValue(pb))
+
+	return decoder, encoder, nil
+}
+
+// RequiredFieldBinaryCodec - binary decoder and encoder for a PB field with a default value
+func RequiredFieldBinaryCodec(r *Registry, pb *descriptorpb.FieldDescriptorProto) (Expr, Expr, error) {
+	if pb.DefaultValue == nil {
+		return binaryFieldCodec(r, pb, "PB.required", "PB.requiredFieldEncoder")
+	}
+
+	fieldType, err := BasicFieldBinaryType(r, pb)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defaultValue, err := FieldDefaultValue(r, pb)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decoder := Apply(VariableName("PB.defaulted"), fieldNumber(pb), fieldType, defaultValue)
+
+	// elm/bytes values can't be compared to a default value.
+	if pb.GetType() == descriptorpb.FieldDescriptorProto_TYPE_BYTES && r.options.Bytes == BytesAsElmBytes {
+		_, encoder, err := MandatoryFieldBinaryCodec(r, pb)
+		return decoder, encoder, err
+	}
+
+	encoder := Apply(VariableName("PB.defaultedFieldEncoder"), fieldNumber(pb), fieldType, defaultValue, fieldValue(pb))
+
+	return decoder, encoder, nil
+}
+
+// MandatoryFieldBinaryCodec - binary decoder and encoder for a proto2 `required` field
+func MandatoryFieldBinaryCodec(r *Registry, pb *descriptorpb.FieldDescriptorProto) (Expr, Expr, error) {
+	return binaryFieldCodec(r, pb, "PB.mandatory", "PB.mandatoryFieldEncoder")
+}
+
+// MaybeBinaryCodec - binary decoder and encoder for an optional PB field
+func MaybeBinaryCodec(r *Registry, pb *descriptorpb.FieldDescriptorProto) (Expr, Expr, error) {
+	return binaryFieldCodec(r, pb, "PB.optional", "PB.optionalEncoder")
+}
+
+// ListBinaryCodec - binary decoder and encoder for a repeated PB field, packed unless expanded
+func ListBinaryCodec(r *Registry, pb *descriptorpb.FieldDescriptorProto, expanded bool) (Expr, Expr, error) {
+	if expanded {
+		return binaryFieldCodec(r, pb, "PB.repeated", "PB.expandedFieldEncoder")
+	}
+
+	return binaryFieldCodec(r, pb, "PB.repeated", "PB.repeatedFieldEncoder")
+}
+
+// MapBinaryCodec - binary decoder and encoder for a PB map field
+func MapBinaryCodec(
+	r *Registry,
+	fieldPb *descriptorpb.FieldDescriptorProto,
+	messagePb *descriptorpb.DescriptorProto,
+) (Expr, Expr, error) {
+	keyField, valueField, err := mapEntryFields(messagePb)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	valueType, err := BasicFieldBinaryType(r, valueField)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if keyField.GetType() == descriptorpb.FieldDescriptorProto_TYPE_BOOL {
+		decoder := Apply(VariableName("PB.boolMapEntries"), fieldNumber(fieldPb), valueType)
+		encoder := Apply(VariableName("PB.boolMapEntriesFieldEncoder"), fieldNumber(fieldPb), valueType, fieldValue(fieldPb))
+
+		return decoder, encoder, nil
+	}
+
+	keyType, err := BasicFieldBinaryType(r.mapKeys(), keyField)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decoder := Apply(VariableName("PB.mapEntries"), fieldNumber(fieldPb), keyType, valueType)
+	encoder := Apply(VariableName("PB.mapEntriesFieldEncoder"), fieldNumber(fieldPb), keyType, valueType, fieldValue(fieldPb))
+
+	return decoder, encoder, nil
+}
+
+// OneOfBinaryCodec - binary decoder and encoder for a PB one-of
+func OneOfBinaryCodec(pb *descriptorpb.OneofDescriptorProto, name Type) (Expr, Expr) {
+	decoder := Apply(VariableName("PB.field"), BinaryDecoderName(name))
+	encoder := Apply(BinaryEncoderName(name), Access{Record: "v", Field: FieldName(pb.GetName())})
+
+	return decoder, encoder
+}
+
+// fieldNumber - number of a PB field, as Int literal
+func fieldNumber(pb *descriptorpb.FieldDescriptorProto) Literal {
+	return Literal(fmt.Sprint(pb.GetNumber()))
+}
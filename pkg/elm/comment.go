@@ -0,0 +1,111 @@
+package elm
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Comment - comments attached to a PB definition in its source file
+// https://github.com/protocolbuffers/protobuf/blob/main/src/google/protobuf/descriptor.proto
+type Comment struct {
+	Leading  string
+	Trailing string
+	Detached []string
+}
+
+// NewComment - comments of the source location of a PB definition, empty without location
+func NewComment(location *descriptorpb.SourceCodeInfo_Location) Comment {
+	return Comment{
+		Leading:  location.GetLeadingComments(),
+		Trailing: location.GetTrailingComments(),
+		Detached: location.GetLeadingDetachedComments(),
+	}
+}
+
+// Doc - lines of the Elm doc comment of the leading and trailing comments of a top level
+// declaration, empty without comments
+func (c Comment) Doc() []string {
+	lines := joinParagraphs(commentLines(c.Leading), commentLines(c.Trailing))
+	for i, line := range lines {
+		// Elm block comments nest, unbalanced delimiters would end the doc comment early.
+		lines[i] = strings.NewReplacer("{-", "{ -", "-}", "- }").Replace(line)
+	}
+
+	return lines
+}
+
+// Declarations - detached comments of a top level declaration, as line comments standing before it
+func (c Comment) Declarations() []Declaration {
+	var paragraphs [][]string
+	for _, detached := range c.Detached {
+		paragraphs = append(paragraphs, commentLines(detached))
+	}
+
+	lines := joinParagraphs(paragraphs...)
+	if len(lines) == 0 {
+		return nil
+	}
+
+	return []Declaration{CommentDeclaration{Lines: lines}}
+}
+
+// Lines - all comments as line comments of a record field or a custom type variant
+func (c Comment) Lines() []string {
+	var paragraphs [][]string
+	for _, detached := range c.Detached {
+		paragraphs = append(paragraphs, commentLines(detached))
+	}
+	paragraphs = append(paragraphs, commentLines(c.Leading), commentLines(c.Trailing))
+
+	return joinParagraphs(paragraphs...)
+}
+
+// commentLines - lines of a PB comment, without surrounding blank lines and common indentation
+func commentLines(comment string) []string {
+	lines := strings.Split(comment, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	indent := -1
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		if n := len(line) - len(strings.TrimLeft(line, " \t")); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = line[indent:]
+		}
+	}
+
+	return lines
+}
+
+// joinParagraphs - non empty paragraphs separated by a blank line
+func joinParagraphs(paragraphs ...[]string) []string {
+	var result []string
+	for _, paragraph := range paragraphs {
+		if len(paragraph) == 0 {
+			continue
+		}
+		if len(result) > 0 {
+			result = append(result, "")
+		}
+		result = append(result, paragraph...)
+	}
+
+	return result
+}
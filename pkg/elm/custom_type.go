@@ -3,7 +3,6 @@ package elm
 import (
 	"fmt"
 	"strings"
-	"text/template"
 
 	"github.com/jalandis/elm-protobuf/pkg/stringextras"
 
@@ -19,6 +18,17 @@ type EnumCustomType struct {
 	DefaultVariantVariable VariableName
 	DefaultVariantValue    VariantName
 	Variants               []EnumVariant
+	// AliasConstants - also generate a constant for each alias name
+	AliasConstants bool
+	// UnrecognizedVariant - holds numeric values without a variant, so they round-trip unchanged,
+	// empty for closed enums which fail decoding instead
+	UnrecognizedVariant VariantName
+	// Strict - decoding fails on unknown names instead of defaulting them
+	Strict bool
+	// BinaryDecoder, BinaryEncoder - empty unless binary codecs are generated
+	BinaryDecoder VariableName
+	BinaryEncoder VariableName
+	Comment       Comment
 }
 
 // VariantName - unique camelcase identifier used for custom type variants
@@ -31,6 +41,15 @@ type EnumVariant struct {
 	Name     VariantName
 	Number   ProtobufFieldNumber
 	JSONName VariantJSONName
+	// Aliases - other names sharing the number with allow_alias, decoded to this variant
+	Aliases []EnumAlias
+	Comment Comment
+}
+
+// EnumAlias - a name of an enum variant besides its canonical one
+type EnumAlias struct {
+	Name     VariableName
+	JSONName VariantJSONName
 }
 
 // OneOfCustomType - defines an Elm custom type (sometimes called union type) for a PB one-of
@@ -40,16 +59,25 @@ type OneOfCustomType struct {
 	Decoder  VariableName
 	Encoder  VariableName
 	Variants []OneOfVariant
+	// Strict - decoding fails when more than one field of the one-of is set
+	Strict bool
+	// BinaryDecoder, BinaryEncoder - empty unless binary codecs are generated
+	BinaryDecoder VariableName
+	BinaryEncoder VariableName
+	Comment       Comment
 }
 
 // OneOfVariant - a possible variant of a one-of CustomType
 // https://guide.elm-lang.org/types/custom_types.html
 type OneOfVariant struct {
-	Name     VariantName
-	Type     Type
-	JSONName VariantJSONName
-	Decoder  VariableName
-	Encoder  VariableName
+	Name       VariantName
+	Type       Type
+	Number     ProtobufFieldNumber
+	JSONName   VariantJSONName
+	Decoder    VariableName
+	Encoder    VariableName
+	BinaryType Expr
+	Comment    Comment
 }
 
 // NestedVariantName - Elm variant name for a possibly nested PB definition
@@ -67,6 +95,21 @@ func EnumDefaultVariantVariableName(t Type) VariableName {
 	return VariableName(stringextras.FirstLower(fmt.Sprintf("%sDefault", t)))
 }
 
+// EnumUnrecognizedVariantName - variant holding the numeric values unknown to an enum custom type
+func EnumUnrecognizedVariantName(t Type) VariantName {
+	return VariantName(fmt.Sprintf("%sUnrecognized_", t))
+}
+
+// OneOfUnspecifiedVariantName - variant of a one-of custom type without any field set
+func OneOfUnspecifiedVariantName(t Type) VariantName {
+	return VariantName(fmt.Sprintf("%sUnspecified", t))
+}
+
+// EnumAliasConstantName - Elm constant for an enum alias name
+func EnumAliasConstantName(name string, preface []string) VariableName {
+	return VariableName(appendUnderscoreToReservedKeywords(stringextras.FirstLower(string(NestedVariantName(name, preface)))))
+}
+
 // EnumVariantJSONName - JSON identifier for variant decoder/encoding
 func EnumVariantJSONName(pb *descriptorpb.EnumValueDescriptorProto) VariantJSONName {
 	return VariantJSONName(pb.GetName())
@@ -77,79 +120,288 @@ func OneOfVariantJSONName(pb *descriptorpb.FieldDescriptorProto) VariantJSONName
 	return VariantJSONName(pb.GetJsonName())
 }
 
-// EnumCustomTypeTemplate - defines template for an enum custom type
-func EnumCustomTypeTemplate(t *template.Template) (*template.Template, error) {
-	return t.Parse(`
-{{- define "enum-custom-type" -}}
-type {{ .Name }}
-{{- range $i, $v := .Variants }}
-    {{ if not $i }}={{ else }}|{{ end }} {{ $v.Name }} -- {{ $v.Number }}
-{{- end }}
-
-
-{{ .Decoder }} : JD.Decoder {{ .Name }}
-{{ .Decoder }} =
-    let
-        lookup s =
-            case s of
-{{- range .Variants }}
-                "{{ .JSONName }}" ->
-                    {{ .Name }}
-{{ end }}
-                _ ->
-                    {{ .DefaultVariantValue }}
-    in
-        JD.map lookup JD.string
-
-
-{{ .DefaultVariantVariable }} : {{ .Name }}
-{{ .DefaultVariantVariable }} = {{ .DefaultVariantValue }}
-
-
-{{ .Encoder }} : {{ .Name }} -> JE.Value
-{{ .Encoder }} v =
-    let
-        lookup s =
-            case s of
-{{- range .Variants }}
-                {{ .Name }} ->
-                    "{{ .JSONName }}"
-{{ end }}
-    in
-        JE.string <| lookup v
-{{- end -}}
-`)
-}
-
-// OneOfCustomTypeTemplate - defines template for a one-of custom type
-func OneOfCustomTypeTemplate(t *template.Template) (*template.Template, error) {
-	return t.Parse(`
-{{- define "oneof-custom-type" -}}
-type {{ .Name }}
-    = {{ .Name }}Unspecified
-{{- range .Variants }}
-    | {{ .Name }} {{ .Type }}
-{{- end }}
-
-
-{{ .Decoder }} : JD.Decoder {{ .Name }}
-{{ .Decoder }} =
-    JD.lazy <| \_ -> JD.oneOf
-        [{{ range $i, $v := .Variants }}{{ if $i }},{{ end }} JD.map {{ .Name }} (JD.field "{{ .JSONName }}" {{ .Decoder }})
-        {{ end }}, JD.succeed {{ .Name }}Unspecified
-        ]
-
-
-{{ .Encoder }} : {{ .Name }} -> Maybe ( String, JE.Value )
-{{ .Encoder }} v =
-    case v of
-        {{ .Name }}Unspecified ->
-            Nothing
-        {{- range .Variants }}
-
-        {{ .Name }} x ->
-            Just ( "{{ .JSONName }}", {{ .Encoder }} x )
-        {{- end }}
-{{- end -}}
-`)
+// Declarations - enum custom type, its codecs, default variant and alias constants
+func (t EnumCustomType) Declarations() []Declaration {
+	var constructors []Constructor
+	for _, v := range t.Variants {
+		constructors = append(constructors, Constructor{
+			Name:       v.Name,
+			Comments:   v.Comment.Lines(),
+			EOLComment: fmt.Sprint(v.Number),
+		})
+	}
+
+	if t.UnrecognizedVariant != "" {
+		constructors = append(constructors, Constructor{Name: t.UnrecognizedVariant, Args: []TypeExpr{Type("Int")}})
+	}
+
+	result := t.Comment.Declarations()
+	result = append(result, CustomTypeDeclaration{
+		Doc:          t.Comment.Doc(),
+		Name:         t.Name,
+		Constructors: constructors,
+	}, ValueDeclaration{
+		Name:       t.Decoder,
+		Annotation: TypeRef{Name: "JD.Decoder", Args: []TypeExpr{t.Name}},
+		Body:       t.decoder(),
+	}, ValueDeclaration{
+		Name:       t.DefaultVariantVariable,
+		Annotation: t.Name,
+		Body:       t.DefaultVariantValue,
+	})
+
+	if t.AliasConstants {
+		for _, v := range t.Variants {
+			for _, alias := range v.Aliases {
+				result = append(result, ValueDeclaration{
+					Name:       alias.Name,
+					Annotation: t.Name,
+					Body:       v.Name,
+				})
+			}
+		}
+	}
+
+	var encoderBranches []Branch
+	for _, v := range t.Variants {
+		encoderBranches = append(encoderBranches, Branch{
+			Pattern: v.Name,
+			Body:    Apply(VariableName("JE.string"), StringLiteral(string(v.JSONName))),
+		})
+	}
+
+	if t.UnrecognizedVariant != "" {
+		encoderBranches = append(encoderBranches, Branch{
+			Pattern: ConstructorPattern{Name: t.UnrecognizedVariant, Args: []Pattern{VariableName("n")}},
+			Body:    Apply(VariableName("JE.int"), VariableName("n")),
+		})
+	}
+
+	result = append(result, ValueDeclaration{
+		Name:       t.Encoder,
+		Annotation: FunctionType{Args: []TypeExpr{t.Name}, Result: Type("JE.Value")},
+		Args:       []Pattern{VariableName("v")},
+		Body: Let{
+			Declarations: []ValueDeclaration{{
+				Name: "lookup",
+				Args: []Pattern{VariableName("s")},
+				Body: Case{Subject: VariableName("s"), Branches: encoderBranches},
+			}},
+			Body: Apply(VariableName("lookup"), VariableName("v")),
+		},
+	})
+
+	if t.BinaryDecoder != "" {
+		result = append(result, t.binaryDeclarations()...)
+	}
+
+	return result
+}
+
+// decoder - decoder of the variants by JSON name or number
+func (t EnumCustomType) decoder() Expr {
+	// Strict lookups and closed enums decode to JD.Decoder, failing on unknown values.
+	succeed := func(strict bool, v VariantName) Expr {
+		if strict {
+			return Apply(VariableName("JD.succeed"), v)
+		}
+
+		return v
+	}
+
+	var lookupBranches []Branch
+	for _, v := range t.Variants {
+		lookupBranches = append(lookupBranches, Branch{
+			Pattern: StringLiteral(string(v.JSONName)),
+			Body:    succeed(t.Strict, v.Name),
+		})
+
+		for _, alias := range v.Aliases {
+			lookupBranches = append(lookupBranches, Branch{
+				Pattern: StringLiteral(string(alias.JSONName)),
+				Body:    succeed(t.Strict, v.Name),
+			})
+		}
+	}
+
+	var unknownName Expr = t.DefaultVariantValue
+	if t.Strict {
+		unknownName = Apply(VariableName("JD.fail"), BinOp{
+			Left:  StringLiteral("unknown value \""),
+			Op:    "++",
+			Right: BinOp{Left: VariableName("s"), Op: "++", Right: StringLiteral("\"")},
+		})
+	}
+	lookupBranches = append(lookupBranches, Branch{Pattern: wildcard, Body: unknownName})
+
+	var numberBranches []Branch
+	for _, v := range t.Variants {
+		numberBranches = append(numberBranches, Branch{
+			Pattern: Literal(fmt.Sprint(v.Number)),
+			Body:    succeed(t.UnrecognizedVariant == "", v.Name),
+		})
+	}
+
+	unknownNumber := Apply(t.UnrecognizedVariant, VariableName("n"))
+	if t.UnrecognizedVariant == "" {
+		unknownNumber = Apply(VariableName("JD.fail"), BinOp{
+			Left:  StringLiteral("unknown value "),
+			Op:    "++",
+			Right: Apply(VariableName("String.fromInt"), VariableName("n")),
+		})
+	}
+	numberBranches = append(numberBranches, Branch{Pattern: wildcard, Body: unknownNumber})
+
+	decodeName, decodeNumber := VariableName("JD.map"), VariableName("JD.map")
+	if t.Strict {
+		decodeName = "JD.andThen"
+	}
+	if t.UnrecognizedVariant == "" {
+		decodeNumber = "JD.andThen"
+	}
+
+	return Let{
+		Declarations: []ValueDeclaration{{
+			Name: "lookup",
+			Args: []Pattern{VariableName("s")},
+			Body: Case{Subject: VariableName("s"), Branches: lookupBranches},
+		}, {
+			Name: "fromNumber",
+			Args: []Pattern{VariableName("n")},
+			Body: Case{Subject: VariableName("n"), Branches: numberBranches},
+		}},
+		Body: Apply(VariableName("JD.oneOf"), List{Items: []Expr{
+			Apply(decodeName, VariableName("lookup"), VariableName("JD.string")),
+			Apply(decodeNumber, VariableName("fromNumber"), VariableName("JD.int")),
+		}}),
+	}
+}
+
+// binaryDeclarations - conversions of the variants from and to their number, unknown numbers
+// being rejected by closed enums
+func (t EnumCustomType) binaryDeclarations() []Declaration {
+	var decoderBranches, encoderBranches []Branch
+	for _, v := range t.Variants {
+		var variant Expr = v.Name
+		if t.UnrecognizedVariant == "" {
+			variant = Apply(VariantName("Just"), v.Name)
+		}
+
+		decoderBranches = append(decoderBranches, Branch{Pattern: Literal(fmt.Sprint(v.Number)), Body: variant})
+		encoderBranches = append(encoderBranches, Branch{Pattern: v.Name, Body: Literal(fmt.Sprint(v.Number))})
+	}
+
+	var decoded TypeExpr = t.Name
+	var unknown Expr = VariantName("Nothing")
+	if t.UnrecognizedVariant != "" {
+		unknown = Apply(t.UnrecognizedVariant, VariableName("v"))
+		encoderBranches = append(encoderBranches, Branch{
+			Pattern: ConstructorPattern{Name: t.UnrecognizedVariant, Args: []Pattern{VariableName("n")}},
+			Body:    VariableName("n"),
+		})
+	} else {
+		decoded = MaybeType(t.Name)
+	}
+	decoderBranches = append(decoderBranches, Branch{Pattern: wildcard, Body: unknown})
+
+	return []Declaration{ValueDeclaration{
+		Name:       t.BinaryDecoder,
+		Annotation: FunctionType{Args: []TypeExpr{Type("Int")}, Result: decoded},
+		Args:       []Pattern{VariableName("v")},
+		Body:       Case{Subject: VariableName("v"), Branches: decoderBranches},
+	}, ValueDeclaration{
+		Name:       t.BinaryEncoder,
+		Annotation: FunctionType{Args: []TypeExpr{t.Name}, Result: Type("Int")},
+		Args:       []Pattern{VariableName("v")},
+		Body:       Case{Subject: VariableName("v"), Branches: encoderBranches},
+	}}
+}
+
+// Declarations - one-of custom type and its codecs
+func (t OneOfCustomType) Declarations() []Declaration {
+	unspecified := OneOfUnspecifiedVariantName(t.Name)
+	constructors := []Constructor{{Name: unspecified}}
+	for _, v := range t.Variants {
+		constructors = append(constructors, Constructor{
+			Name:     v.Name,
+			Args:     []TypeExpr{v.Type},
+			Comments: v.Comment.Lines(),
+		})
+	}
+
+	var decoders []Expr
+	encoderBranches := []Branch{{Pattern: unspecified, Body: VariantName("Nothing")}}
+	for _, v := range t.Variants {
+		if t.Strict {
+			decoders = append(decoders, Tuple{
+				StringLiteral(string(v.JSONName)),
+				Apply(VariableName("JD.map"), v.Name, v.Decoder),
+			})
+		} else {
+			decoders = append(decoders, Apply(
+				VariableName("JD.map"),
+				v.Name,
+				Apply(VariableName("JD.field"), StringLiteral(string(v.JSONName)), v.Decoder),
+			))
+		}
+
+		encoderBranches = append(encoderBranches, Branch{
+			Pattern: ConstructorPattern{Name: v.Name, Args: []Pattern{VariableName("x")}},
+			Body: Apply(VariantName("Just"), Tuple{
+				StringLiteral(string(v.JSONName)),
+				Apply(v.Encoder, VariableName("x")),
+			}),
+		})
+	}
+
+	decoder := Apply(VariableName("strictOneOf"), unspecified, List{Items: decoders, Multiline: true})
+	if !t.Strict {
+		decoders = append(decoders, Apply(VariableName("JD.succeed"), unspecified))
+		decoder = Apply(VariableName("JD.oneOf"), List{Items: decoders, Multiline: true})
+	}
+
+	result := t.Comment.Declarations()
+	result = append(result, CustomTypeDeclaration{
+		Doc:          t.Comment.Doc(),
+		Name:         t.Name,
+		Constructors: constructors,
+	}, ValueDeclaration{
+		Name:       t.Decoder,
+		Annotation: TypeRef{Name: "JD.Decoder", Args: []TypeExpr{t.Name}},
+		Body:       lazy("JD.lazy", decoder),
+	}, ValueDeclaration{
+		Name: t.Encoder,
+		Annotation: FunctionType{
+			Args:   []TypeExpr{t.Name},
+			Result: MaybeType(TupleType{Type("String"), Type("JE.Value")}),
+		},
+		Args: []Pattern{VariableName("v")},
+		Body: Case{Subject: VariableName("v"), Branches: encoderBranches},
+	})
+
+	if t.BinaryDecoder == "" {
+		return result
+	}
+
+	var variants []Expr
+	binaryBranches := []Branch{{Pattern: unspecified, Body: VariableName("PB.noFieldEncoder")}}
+	for _, v := range t.Variants {
+		number := Literal(fmt.Sprint(v.Number))
+		variants = append(variants, Apply(VariableName("PB.variant"), number, v.Name, v.BinaryType))
+		binaryBranches = append(binaryBranches, Branch{
+			Pattern: ConstructorPattern{Name: v.Name, Args: []Pattern{VariableName("x")}},
+			Body:    Apply(VariableName("PB.fieldEncoder"), number, v.BinaryType, VariableName("x")),
+		})
+	}
+
+	return append(result, ValueDeclaration{
+		Name:       t.BinaryDecoder,
+		Annotation: TypeRef{Name: "PB.Decoder", Args: []TypeExpr{t.Name}},
+		Body:       lazy("PB.lazy", Apply(VariableName("PB.oneOf"), unspecified, List{Items: variants, Multiline: true})),
+	}, ValueDeclaration{
+		Name:       t.BinaryEncoder,
+		Annotation: FunctionType{Args: []TypeExpr{t.Name}, Result: Type("PB.FieldEncoder")},
+		Args:       []Pattern{VariableName("v")},
+		Body:       Case{Subject: VariableName("v"), Branches: binaryBranches},
+	})
 }
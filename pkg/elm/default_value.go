@@ -0,0 +1,242 @@
+package elm
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// FieldDefaultValue - default value of a non optional PB field, the declared `default` of a proto2
+// field or the zero value of its type
+func FieldDefaultValue(r *Registry, inField *descriptorpb.FieldDescriptorProto) (Expr, error) {
+	if inField.DefaultValue == nil || inField.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+		return BasicFieldDefaultValue(r, inField)
+	}
+
+	value := inField.GetDefaultValue()
+	switch inField.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_INT32,
+		descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default value %q: %v", value, err)
+		}
+
+		return intLiteral(n), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default value %q: %v", value, err)
+		}
+
+		return int64Literal(r, strconv.FormatInt(n, 10), uint64(n), "Protobuf.int64FromBits"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default value %q: %v", value, err)
+		}
+
+		return int64Literal(r, strconv.FormatUint(n, 10), n, "Protobuf.uint64FromBits"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
+		descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+		return floatLiteral(value)
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		if value == "true" {
+			return VariantName("True"), nil
+		}
+
+		return VariantName("False"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return StringLiteral(value), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+		return bytesLiteral(r, value)
+	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+		symbol, err := r.Lookup(inField.GetTypeName())
+		if err != nil {
+			return nil, err
+		}
+
+		variant, err := r.EnumValueVariant(symbol, value)
+		if err != nil {
+			return nil, err
+		}
+
+		return VariantName(r.Qualify(symbol, string(variant))), nil
+	default:
+		return nil, fmt.Errorf("no default value for field type %s", inField.GetType())
+	}
+}
+
+// intLiteral - Elm Int
+func intLiteral(n int64) Expr {
+	return Literal(strconv.FormatInt(n, 10))
+}
+
+// int64Literal - Elm value of a 64 bit integer in the chosen representation, from its decimal
+// and two's complement forms
+func int64Literal(r *Registry, decimal string, bits uint64, fromBits VariableName) Expr {
+	switch r.options.Int64 {
+	case Int64AsString:
+		return StringLiteral(decimal)
+	case Int64AsInt64:
+		return Apply(
+			fromBits,
+			Literal(strconv.FormatUint(bits&0xFFFFFFFF, 10)),
+			Literal(strconv.FormatUint(bits>>32, 10)),
+		)
+	default:
+		return Literal(decimal)
+	}
+}
+
+// floatLiteral - Elm Float for a declared default, including `inf`, `-inf` and `nan`
+func floatLiteral(value string) (Expr, error) {
+	switch value {
+	case "inf":
+		return BinOp{Left: Literal("1"), Op: "/", Right: Literal("0")}, nil
+	case "-inf":
+		return BinOp{Left: Literal("-1"), Op: "/", Right: Literal("0")}, nil
+	case "nan":
+		return BinOp{Left: Literal("0"), Op: "/", Right: Literal("0")}, nil
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("invalid default value %q", value)
+	}
+
+	literal := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(literal, ".") {
+		literal += ".0"
+	}
+
+	return Literal(literal), nil
+}
+
+// stringLiteral - Elm String literal, escaping quotes, backslashes and control characters
+func stringLiteral(value string) string {
+	var b strings.Builder
+	b.WriteString("\"")
+	for _, c := range value {
+		switch c {
+		case '"':
+			b.WriteString("\\\"")
+		case '\\':
+			b.WriteString("\\\\")
+		case '\n':
+			b.WriteString("\\n")
+		case '\r':
+			b.WriteString("\\r")
+		case '\t':
+			b.WriteString("\\t")
+		default:
+			if c < 0x20 || c == 0x7F {
+				fmt.Fprintf(&b, "\\u{%04X}", c)
+			} else {
+				b.WriteRune(c)
+			}
+		}
+	}
+	b.WriteString("\"")
+
+	return b.String()
+}
+
+// bytesLiteral - Elm value of a bytes default, which `protoc` stores C escaped
+func bytesLiteral(r *Registry, value string) (Expr, error) {
+	bytes, err := unescapeBytes(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default value %q: %v", value, err)
+	}
+
+	var list List
+	for _, b := range bytes {
+		list.Items = append(list.Items, Literal(strconv.Itoa(int(b))))
+	}
+
+	if r.options.Bytes == BytesAsElmBytes {
+		if len(list.Items) == 0 {
+			return VariableName("emptyBytes"), nil
+		}
+
+		return Apply(VariableName("listToElmBytes"), list), nil
+	}
+
+	return list, nil
+}
+
+// unescapeBytes - reverses the C escaping of `protoc`, octal and hexadecimal escapes included
+func unescapeBytes(value string) ([]byte, error) {
+	var result []byte
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			result = append(result, value[i])
+			continue
+		}
+
+		i++
+		if i == len(value) {
+			return nil, fmt.Errorf("trailing backslash")
+		}
+
+		switch c := value[i]; c {
+		case 'a':
+			result = append(result, '\a')
+		case 'b':
+			result = append(result, '\b')
+		case 'f':
+			result = append(result, '\f')
+		case 'n':
+			result = append(result, '\n')
+		case 'r':
+			result = append(result, '\r')
+		case 't':
+			result = append(result, '\t')
+		case 'v':
+			result = append(result, '\v')
+		case '\\', '\'', '"', '?':
+			result = append(result, c)
+		case 'x', 'X':
+			end := i + 1
+			for end < len(value) && end < i+3 && strings.IndexByte("0123456789abcdefABCDEF", value[end]) >= 0 {
+				end++
+			}
+
+			n, err := strconv.ParseUint(value[i+1:end], 16, 8)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, byte(n))
+			i = end - 1
+		default:
+			end := i
+			for end < len(value) && end < i+3 && value[end] >= '0' && value[end] <= '7' {
+				end++
+			}
+
+			if end == i {
+				return nil, fmt.Errorf("unknown escape \\%c", c)
+			}
+
+			n, err := strconv.ParseUint(value[i:end], 8, 8)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, byte(n))
+			i = end - 1
+		}
+	}
+
+	return result, nil
+}
@@ -2,9 +2,6 @@ package elm
 
 import (
 	"fmt"
-	"strings"
-	"unicode"
-	"unicode/utf8"
 
 	"github.com/jalandis/elm-protobuf/pkg/stringextras"
 
@@ -20,6 +17,12 @@ var (
 	stringType Type = "String"
 	bytesType  Type = "Bytes"
 	boolType   Type = "Bool"
+
+	// Exact 64 bit integer of the runtime library
+	int64Type Type = "Protobuf.Int64"
+
+	// elm/bytes, requires `import Bytes`
+	elmBytesType Type = "Bytes.Bytes"
 )
 
 // VariableName - unique camelcase identifier starting with lowercase letter.
@@ -53,156 +56,227 @@ func NestedType(name string, preface []string) Type {
 	return Type(stringextras.FirstUpper(fullName))
 }
 
-// ExternalType - handles types defined in external files
-func ExternalType(inType string) Type {
-	messageSegments := []string{}
-	for _, s := range strings.Split(inType, ".") {
-		if s == "" {
-			continue
-		}
-
-		if r, _ := utf8.DecodeRuneInString(s); !unicode.IsLower(r) {
-			messageSegments = append(messageSegments, stringextras.FirstUpper(s))
-		}
-	}
-	return Type(strings.Join(messageSegments, "_"))
-}
-
-func BasicFieldEncoder(inField *descriptorpb.FieldDescriptorProto) VariableName {
+// BasicFieldEncoder - encoder function for a single value of a PB field
+func BasicFieldEncoder(r *Registry, inField *descriptorpb.FieldDescriptorProto) (VariableName, error) {
 	switch inField.GetType() {
 	case descriptorpb.FieldDescriptorProto_TYPE_INT32,
 		descriptorpb.FieldDescriptorProto_TYPE_UINT32,
 		descriptorpb.FieldDescriptorProto_TYPE_SINT32,
 		descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
 		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
-		return "JE.int"
+		return "JE.int", nil
 	case descriptorpb.FieldDescriptorProto_TYPE_INT64,
 		descriptorpb.FieldDescriptorProto_TYPE_UINT64,
 		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
 		descriptorpb.FieldDescriptorProto_TYPE_FIXED64,
 		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
-		return "numericStringEncoder"
+		switch r.options.Int64 {
+		case Int64AsString:
+			return "JE.string", nil
+		case Int64AsInt64:
+			return "Protobuf.int64Encoder", nil
+		default:
+			return "numericStringEncoder", nil
+		}
 	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
 		descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
-		return "JE.float"
+		return "JE.float", nil
 	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
-		return "JE.bool"
+		return "JE.bool", nil
 	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
-		return "JE.string"
+		return "JE.string", nil
 	case descriptorpb.FieldDescriptorProto_TYPE_ENUM,
-		descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
-		if n, ok := WellKnownTypeMap[inField.GetTypeName()]; ok {
-			return n.Encoder
+		descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		if n, ok := r.wellKnownType(inField.GetTypeName()); ok {
+			return n.Encoder, nil
+		}
+
+		symbol, err := r.Lookup(inField.GetTypeName())
+		if err != nil {
+			return "", err
 		}
 
-		return EncoderName(ExternalType(inField.GetTypeName()))
+		return VariableName(r.Qualify(symbol, string(EncoderName(symbol.Type)))), nil
 	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
-		return "bytesFieldEncoder"
+		if r.options.Bytes == BytesAsElmBytes {
+			return "elmBytesFieldEncoder", nil
+		}
+
+		return "bytesFieldEncoder", nil
 	default:
-		panic(fmt.Errorf("Error generating decoder for field %s", inField.GetType()))
+		return "", fmt.Errorf("no encoder for field type %s", inField.GetType())
 	}
 }
 
-func BasicFieldDecoder(inField *descriptorpb.FieldDescriptorProto) VariableName {
+// BasicFieldDecoder - decoder function for a single value of a PB field
+func BasicFieldDecoder(r *Registry, inField *descriptorpb.FieldDescriptorProto) (VariableName, error) {
 	switch inField.GetType() {
 	case descriptorpb.FieldDescriptorProto_TYPE_INT32,
-		descriptorpb.FieldDescriptorProto_TYPE_INT64,
 		descriptorpb.FieldDescriptorProto_TYPE_UINT32,
-		descriptorpb.FieldDescriptorProto_TYPE_UINT64,
 		descriptorpb.FieldDescriptorProto_TYPE_SINT32,
-		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
 		descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		return "intDecoder", nil
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
 		descriptorpb.FieldDescriptorProto_TYPE_FIXED64,
-		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32,
 		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
-		return "intDecoder"
+		switch {
+		case r.options.Int64 == Int64AsString && isUnsigned64(inField):
+			return "Protobuf.uint64StringDecoder", nil
+		case r.options.Int64 == Int64AsString:
+			return "Protobuf.int64StringDecoder", nil
+		case r.options.Int64 == Int64AsInt64 && isUnsigned64(inField):
+			return "Protobuf.uint64Decoder", nil
+		case r.options.Int64 == Int64AsInt64:
+			return "Protobuf.int64Decoder", nil
+		default:
+			return "intDecoder", nil
+		}
 	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
 		descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
-		return "JD.float"
+		return "JD.float", nil
 	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
-		return "JD.bool"
+		return "JD.bool", nil
 	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
-		return "JD.string"
+		return "JD.string", nil
 	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
-		return "bytesFieldDecoder"
+		if r.options.Bytes == BytesAsElmBytes {
+			return "elmBytesFieldDecoder", nil
+		}
+
+		return "bytesFieldDecoder", nil
 	case descriptorpb.FieldDescriptorProto_TYPE_ENUM,
-		descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
-		if n, ok := WellKnownTypeMap[inField.GetTypeName()]; ok {
-			return n.Decoder
+		descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		if n, ok := r.wellKnownType(inField.GetTypeName()); ok {
+			return n.Decoder, nil
+		}
+
+		symbol, err := r.Lookup(inField.GetTypeName())
+		if err != nil {
+			return "", err
 		}
 
-		return DecoderName(ExternalType(inField.GetTypeName()))
+		return VariableName(r.Qualify(symbol, string(DecoderName(symbol.Type)))), nil
 	default:
-		panic(fmt.Errorf("error generating decoder for field %s", inField.GetType()))
+		return "", fmt.Errorf("no decoder for field type %s", inField.GetType())
 	}
 }
 
-func BasicFieldType(inField *descriptorpb.FieldDescriptorProto) Type {
+// isUnsigned64 - whether a PB field is an unsigned 64 bit integer, out of the int64 range
+func isUnsigned64(inField *descriptorpb.FieldDescriptorProto) bool {
+	return inField.GetType() == descriptorpb.FieldDescriptorProto_TYPE_UINT64 ||
+		inField.GetType() == descriptorpb.FieldDescriptorProto_TYPE_FIXED64
+}
+
+// BasicFieldType - Elm type for a single value of a PB field
+func BasicFieldType(r *Registry, inField *descriptorpb.FieldDescriptorProto) (Type, error) {
 	switch inField.GetType() {
 	case descriptorpb.FieldDescriptorProto_TYPE_INT32,
-		descriptorpb.FieldDescriptorProto_TYPE_INT64,
 		descriptorpb.FieldDescriptorProto_TYPE_UINT32,
-		descriptorpb.FieldDescriptorProto_TYPE_UINT64,
 		descriptorpb.FieldDescriptorProto_TYPE_SINT32,
-		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
 		descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		return intType, nil
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
 		descriptorpb.FieldDescriptorProto_TYPE_FIXED64,
-		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32,
 		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
-		return intType
+		switch r.options.Int64 {
+		case Int64AsString:
+			return stringType, nil
+		case Int64AsInt64:
+			return int64Type, nil
+		default:
+			return intType, nil
+		}
 	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
 		descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
-		return floatType
+		return floatType, nil
 	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
-		return boolType
+		return boolType, nil
 	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
-		return stringType
+		return stringType, nil
 	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
-		return bytesType
+		if r.options.Bytes == BytesAsElmBytes {
+			r.Require("Bytes")
+			return elmBytesType, nil
+		}
+
+		return bytesType, nil
 	case descriptorpb.FieldDescriptorProto_TYPE_ENUM,
-		descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
-		if n, ok := WellKnownTypeMap[inField.GetTypeName()]; ok {
-			return n.Type
+		descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		if n, ok := r.wellKnownType(inField.GetTypeName()); ok {
+			return n.Type, nil
+		}
+
+		symbol, err := r.Lookup(inField.GetTypeName())
+		if err != nil {
+			return "", err
 		}
-		return ExternalType(inField.GetTypeName())
+
+		return Type(r.Qualify(symbol, string(symbol.Type))), nil
 	default:
-		panic(fmt.Errorf("Error generating type for field %q %s", inField.GetName(), inField.GetType()))
+		return "", fmt.Errorf("no Elm type for field type %s", inField.GetType())
 	}
 }
 
-type DefaultValue string
-
-func BasicFieldDefaultValue(inField *descriptorpb.FieldDescriptorProto) DefaultValue {
+// BasicFieldDefaultValue - default value of a non optional PB field
+func BasicFieldDefaultValue(r *Registry, inField *descriptorpb.FieldDescriptorProto) (Expr, error) {
 	if inField.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
-		return "[]"
+		return List{}, nil
 	}
 
 	switch inField.GetType() {
 	case descriptorpb.FieldDescriptorProto_TYPE_INT32,
-		descriptorpb.FieldDescriptorProto_TYPE_INT64,
 		descriptorpb.FieldDescriptorProto_TYPE_UINT32,
-		descriptorpb.FieldDescriptorProto_TYPE_UINT64,
 		descriptorpb.FieldDescriptorProto_TYPE_SINT32,
-		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
 		descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32:
+		return Literal("0"), nil
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
 		descriptorpb.FieldDescriptorProto_TYPE_FIXED64,
-		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32,
 		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
-		return "0"
+		switch r.options.Int64 {
+		case Int64AsString:
+			return StringLiteral("0"), nil
+		case Int64AsInt64:
+			return VariableName("Protobuf.int64Zero"), nil
+		default:
+			return Literal("0"), nil
+		}
 	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
 		descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
-		return "0.0"
+		return Literal("0.0"), nil
 	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
-		return "False"
+		return VariantName("False"), nil
 	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
-		return "\"\""
+		return StringLiteral(""), nil
 	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
-		return "[]"
+		if r.options.Bytes == BytesAsElmBytes {
+			return VariableName("emptyBytes"), nil
+		}
+
+		return List{}, nil
 	case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
-		return DefaultValue(EnumDefaultVariantVariableName(ExternalType(inField.GetTypeName())))
-	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
-		fallthrough
+		if n, ok := r.wellKnownType(inField.GetTypeName()); ok {
+			return n.Default, nil
+		}
+
+		symbol, err := r.Lookup(inField.GetTypeName())
+		if err != nil {
+			return nil, err
+		}
+
+		return VariableName(r.Qualify(symbol, string(EnumDefaultVariantVariableName(symbol.Type)))), nil
 	default:
-		panic(fmt.Errorf("error - no known default value for field %s", inField.GetType()))
+		return nil, fmt.Errorf("no default value for field type %s", inField.GetType())
 	}
 }
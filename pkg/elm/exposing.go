@@ -0,0 +1,82 @@
+package elm
+
+import (
+	"fmt"
+)
+
+// Exposed - declarations of an enum custom type
+func (t EnumCustomType) Exposed() []string {
+	result := []string{
+		fmt.Sprintf("%s(..)", t.Name),
+		string(t.Decoder),
+		string(t.Encoder),
+		string(t.DefaultVariantVariable),
+	}
+
+	if t.AliasConstants {
+		for _, v := range t.Variants {
+			for _, alias := range v.Aliases {
+				result = append(result, string(alias.Name))
+			}
+		}
+	}
+
+	return append(result, binaryExposed(t.BinaryDecoder, t.BinaryEncoder)...)
+}
+
+// Exposed - declarations of a one-of custom type
+func (t OneOfCustomType) Exposed() []string {
+	result := []string{
+		fmt.Sprintf("%s(..)", t.Name),
+		string(t.Decoder),
+		string(t.Encoder),
+	}
+
+	return append(result, binaryExposed(t.BinaryDecoder, t.BinaryEncoder)...)
+}
+
+// Exposed - declarations of a type alias, its record hidden when opaque
+func (t TypeAlias) Exposed() []string {
+	result := []string{
+		string(t.Name),
+		string(t.Decoder),
+		string(t.Encoder),
+	}
+
+	if t.Opaque {
+		result = append(result, string(t.Constructor))
+		for _, field := range t.Fields {
+			result = append(result, string(field.Getter), string(field.Setter))
+		}
+
+		if t.Extendable {
+			result = append(result, string(t.ExtensionsGetter()), string(t.ExtensionsSetter()))
+		}
+	}
+
+	return append(result, binaryExposed(t.BinaryDecoder, t.BinaryEncoder)...)
+}
+
+// Exposed - declarations of a wrapper custom type
+func (t WrapperType) Exposed() []string {
+	return []string{
+		fmt.Sprintf("%s(..)", t.Name),
+		string(t.Unwrap),
+	}
+}
+
+// Exposed - accessors of an extension
+func (e Extension) Exposed() []string {
+	return []string{
+		string(e.Getter),
+		string(e.Setter),
+	}
+}
+
+func binaryExposed(decoder VariableName, encoder VariableName) []string {
+	if decoder == "" {
+		return nil
+	}
+
+	return []string{string(decoder), string(encoder)}
+}
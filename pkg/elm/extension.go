@@ -0,0 +1,102 @@
+package elm
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Extension - accessors of a PB extension, kept in the `extensions_` field of the message it
+// extends by its `[package.name]` JSON key
+type Extension struct {
+	Getter   VariableName
+	Setter   VariableName
+	JSONName string
+	Message  Type
+	Type     Type
+	Decoder  VariableName
+	Encoder  VariableName
+	Repeated bool
+	// ExtensionsGetter, ExtensionsSetter - accessors of the extensions of an opaque message, empty
+	// when its record is exposed
+	ExtensionsGetter VariableName
+	ExtensionsSetter VariableName
+}
+
+// NewExtension - accessors of a possibly nested PB extension, scope is the fully qualified name
+// of the package or message declaring it
+func NewExtension(r *Registry, scope string, preface []string, inField *descriptorpb.FieldDescriptorProto) (Extension, error) {
+	symbol, err := r.Lookup(inField.GetExtendee())
+	if err != nil {
+		return Extension{}, err
+	}
+
+	fieldType, err := BasicFieldType(r, inField)
+	if err != nil {
+		return Extension{}, err
+	}
+
+	decoder, err := BasicFieldDecoder(r, inField)
+	if err != nil {
+		return Extension{}, err
+	}
+
+	encoder, err := BasicFieldEncoder(r, inField)
+	if err != nil {
+		return Extension{}, err
+	}
+
+	name := NestedType(inField.GetName(), preface)
+	result := Extension{
+		Getter:   VariableName(fmt.Sprintf("get%s", name)),
+		Setter:   VariableName(fmt.Sprintf("set%s", name)),
+		JSONName: fmt.Sprintf("[%s]", strings.TrimPrefix(scope+"."+inField.GetName(), ".")),
+		Message:  Type(r.Qualify(symbol, string(symbol.Type))),
+		Type:     fieldType,
+		Decoder:  decoder,
+		Encoder:  encoder,
+		Repeated: inField.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED,
+	}
+
+	if r.options.Opaque {
+		result.ExtensionsGetter = VariableName(r.Qualify(symbol, string(GetterName(symbol.Type, extensionsField))))
+		result.ExtensionsSetter = VariableName(r.Qualify(symbol, string(SetterName(symbol.Type, extensionsField))))
+	}
+
+	return result, nil
+}
+
+// Declarations - getter and setter of an extension
+func (e Extension) Declarations() []Declaration {
+	valueType := MaybeType(e.Type)
+	get, set := VariableName("getExtension"), VariableName("setExtension")
+	if e.Repeated {
+		valueType = ListType(e.Type)
+		get, set = "getRepeatedExtension", "setRepeatedExtension"
+	}
+
+	jsonName := StringLiteral(e.JSONName)
+	var extensions Expr = Access{Record: "v", Field: extensionsField}
+	if e.ExtensionsGetter != "" {
+		extensions = Apply(e.ExtensionsGetter, VariableName("v"))
+	}
+
+	updated := Apply(set, jsonName, e.Encoder, VariableName("x"), extensions)
+	var setter Expr = RecordUpdate{Record: "v", Fields: []RecordField{{Name: extensionsField, Value: updated}}}
+	if e.ExtensionsSetter != "" {
+		setter = Apply(e.ExtensionsSetter, updated, VariableName("v"))
+	}
+
+	return []Declaration{ValueDeclaration{
+		Name:       e.Getter,
+		Annotation: FunctionType{Args: []TypeExpr{e.Message}, Result: valueType},
+		Args:       []Pattern{VariableName("v")},
+		Body:       Apply(get, jsonName, e.Decoder, extensions),
+	}, ValueDeclaration{
+		Name:       e.Setter,
+		Annotation: FunctionType{Args: []TypeExpr{valueType, e.Message}, Result: e.Message},
+		Args:       []Pattern{VariableName("x"), VariableName("v")},
+		Body:       setter,
+	}}
+}
@@ -0,0 +1,107 @@
+package elm
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// MinimumEdition, MaximumEdition - range of PB editions the generated code supports
+const (
+	MinimumEdition = descriptorpb.Edition_EDITION_PROTO2
+	MaximumEdition = descriptorpb.Edition_EDITION_2023
+)
+
+// Features - resolved PB edition features driving the shape of the generated code
+// https://protobuf.dev/editions/features/
+type Features struct {
+	FieldPresence         descriptorpb.FeatureSet_FieldPresence
+	EnumType              descriptorpb.FeatureSet_EnumType
+	RepeatedFieldEncoding descriptorpb.FeatureSet_RepeatedFieldEncoding
+	MessageEncoding       descriptorpb.FeatureSet_MessageEncoding
+}
+
+// FileEdition - edition of a PB file, files without a syntax statement are proto2
+func FileEdition(inFile *descriptorpb.FileDescriptorProto) descriptorpb.Edition {
+	switch inFile.GetSyntax() {
+	case "", "proto2":
+		return descriptorpb.Edition_EDITION_PROTO2
+	case "proto3":
+		return descriptorpb.Edition_EDITION_PROTO3
+	default:
+		return inFile.GetEdition()
+	}
+}
+
+// FileFeatures - defaults of the edition of a PB file, overridden by its options
+func FileFeatures(inFile *descriptorpb.FileDescriptorProto) (Features, error) {
+	edition := FileEdition(inFile)
+	if edition < MinimumEdition || edition > MaximumEdition {
+		return Features{}, fmt.Errorf("unsupported edition %s", edition)
+	}
+
+	result := Features{
+		FieldPresence:         descriptorpb.FeatureSet_EXPLICIT,
+		EnumType:              descriptorpb.FeatureSet_OPEN,
+		RepeatedFieldEncoding: descriptorpb.FeatureSet_PACKED,
+		MessageEncoding:       descriptorpb.FeatureSet_LENGTH_PREFIXED,
+	}
+
+	switch edition {
+	case descriptorpb.Edition_EDITION_PROTO2:
+		result.EnumType = descriptorpb.FeatureSet_CLOSED
+		result.RepeatedFieldEncoding = descriptorpb.FeatureSet_EXPANDED
+	case descriptorpb.Edition_EDITION_PROTO3:
+		result.FieldPresence = descriptorpb.FeatureSet_IMPLICIT
+	}
+
+	return result.Merge(inFile.GetOptions().GetFeatures()), nil
+}
+
+// Merge - features overridden by those explicitly set on a nested PB definition
+func (f Features) Merge(set *descriptorpb.FeatureSet) Features {
+	if set == nil {
+		return f
+	}
+
+	if set.FieldPresence != nil {
+		f.FieldPresence = set.GetFieldPresence()
+	}
+	if set.EnumType != nil {
+		f.EnumType = set.GetEnumType()
+	}
+	if set.RepeatedFieldEncoding != nil {
+		f.RepeatedFieldEncoding = set.GetRepeatedFieldEncoding()
+	}
+	if set.MessageEncoding != nil {
+		f.MessageEncoding = set.GetMessageEncoding()
+	}
+
+	return f
+}
+
+// FieldFeatures - features of a PB field, including those expressed by proto2 and proto3 syntax
+func (f Features) FieldFeatures(inField *descriptorpb.FieldDescriptorProto) Features {
+	if inField.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REQUIRED {
+		f.FieldPresence = descriptorpb.FeatureSet_LEGACY_REQUIRED
+	}
+
+	if options := inField.GetOptions(); options != nil && options.Packed != nil {
+		f.RepeatedFieldEncoding = descriptorpb.FeatureSet_EXPANDED
+		if options.GetPacked() {
+			f.RepeatedFieldEncoding = descriptorpb.FeatureSet_PACKED
+		}
+	}
+
+	return f.Merge(inField.GetOptions().GetFeatures())
+}
+
+// Closed - closed enums reject numeric values without a variant
+func (f Features) Closed() bool {
+	return f.EnumType == descriptorpb.FeatureSet_CLOSED
+}
+
+// Expanded - repeated scalar fields are encoded one value per tag instead of packed
+func (f Features) Expanded() bool {
+	return f.RepeatedFieldEncoding == descriptorpb.FeatureSet_EXPANDED
+}
@@ -0,0 +1,173 @@
+package elm
+
+import "github.com/jalandis/elm-protobuf/pkg/stringextras"
+
+// Options - generator settings affecting the Elm representation of PB types
+type Options struct {
+	Bytes  BytesRepresentation
+	Struct StructRepresentation
+	Int64  Int64Representation
+	Strict StrictMode
+	// Binary - also generate binary wire format codecs, see Protobuf.Binary
+	Binary bool
+	// Opaque - hide the records of messages behind opaque types, see OpaqueTypeAlias
+	Opaque bool
+}
+
+// BytesRepresentation - Elm type used for PB bytes
+type BytesRepresentation int
+
+const (
+	// BytesAsList - `List Int` of byte values, aliased as `Protobuf.Bytes`
+	BytesAsList BytesRepresentation = iota
+	// BytesAsElmBytes - `Bytes.Bytes` from elm/bytes
+	BytesAsElmBytes
+)
+
+// StructRepresentation - Elm type used for google.protobuf.Struct, Value and ListValue
+type StructRepresentation int
+
+const (
+	// StructAsValue - recursive `Protobuf.Value` type
+	StructAsValue StructRepresentation = iota
+	// StructAsJSON - raw `Json.Encode.Value`
+	StructAsJSON
+)
+
+// Int64Representation - Elm type used for PB 64 bit integers and their wrappers
+type Int64Representation int
+
+const (
+	// Int64AsInt - Elm `Int`, values beyond 2^53 lose precision
+	Int64AsInt Int64Representation = iota
+	// Int64AsString - decimal `String`, as in the JSON mapping
+	Int64AsString
+	// Int64AsInt64 - exact `Protobuf.Int64`
+	Int64AsInt64
+)
+
+// StrictMode - how generated JSON decoders handle malformed input
+type StrictMode int
+
+const (
+	// Lenient - malformed fields are decoded as their default value
+	Lenient StrictMode = iota
+	// StrictFields - malformed fields fail decoding, absent and null fields are still defaulted
+	StrictFields
+	// StrictUnknownFields - as StrictFields, unknown keys also fail decoding
+	StrictUnknownFields
+)
+
+// decoderHelper - Protobuf runtime helper decoding a message or a field, `strict` variant in
+// strict mode
+func (r *Registry) decoderHelper(name string) VariableName {
+	if r.options.Strict == Lenient {
+		return VariableName(name)
+	}
+
+	return VariableName("strict" + stringextras.FirstUpper(name))
+}
+
+var jsonStructWellKnownTypes = map[string]WellKnownType{
+	".google.protobuf.Struct": {
+		Type:    "JE.Value",
+		Decoder: "jsonStructDecoder",
+		Encoder: "jsonValueEncoder",
+		Binary:  VariableName("PB.jsonStruct"),
+	},
+	".google.protobuf.Value": {
+		Type:    "JE.Value",
+		Decoder: "jsonValueDecoder",
+		Encoder: "jsonValueEncoder",
+		Binary:  VariableName("PB.jsonValue"),
+	},
+	".google.protobuf.ListValue": {
+		Type:    "JE.Value",
+		Decoder: "jsonListValueDecoder",
+		Encoder: "jsonValueEncoder",
+		Binary:  VariableName("PB.jsonListValue"),
+	},
+}
+
+var elmBytesWellKnownTypes = map[string]WellKnownType{
+	".google.protobuf.BytesValue": {
+		Type:    elmBytesType,
+		Decoder: "elmBytesValueDecoder",
+		Encoder: "elmBytesValueEncoder",
+		Binary:  Apply(VariableName("PB.wrapper"), VariableName("PB.elmBytes")),
+	},
+}
+
+var int64StringWellKnownTypes = map[string]WellKnownType{
+	".google.protobuf.Int64Value": {
+		Type:    stringType,
+		Decoder: "Protobuf.int64StringDecoder",
+		Encoder: "JE.string",
+		Binary:  Apply(VariableName("PB.wrapper"), Apply(VariableName("PB.decimal"), VariableName("PB.exactInt64"))),
+	},
+	".google.protobuf.UInt64Value": {
+		Type:    stringType,
+		Decoder: "Protobuf.uint64StringDecoder",
+		Encoder: "JE.string",
+		Binary:  Apply(VariableName("PB.wrapper"), Apply(VariableName("PB.unsignedDecimal"), VariableName("PB.exactUint64"))),
+	},
+}
+
+var int64WellKnownTypes = map[string]WellKnownType{
+	".google.protobuf.Int64Value": {
+		Type:    int64Type,
+		Decoder: "Protobuf.int64Decoder",
+		Encoder: "Protobuf.int64Encoder",
+		Binary:  Apply(VariableName("PB.wrapper"), VariableName("PB.exactInt64")),
+	},
+	".google.protobuf.UInt64Value": {
+		Type:    int64Type,
+		Decoder: "Protobuf.uint64Decoder",
+		Encoder: "Protobuf.int64Encoder",
+		Binary:  Apply(VariableName("PB.wrapper"), VariableName("PB.exactUint64")),
+	},
+}
+
+// wellKnownType - Elm representation of a Google well known type, if typeName is one
+// and as seen from the module the registry is scoped to
+func (r *Registry) wellKnownType(typeName string) (WellKnownType, bool) {
+	n, ok := r.optionWellKnownType(typeName)
+	if !ok || !r.qualifiedRuntime {
+		return n, ok
+	}
+
+	n.Type = Type(r.qualifyRuntime(string(n.Type)))
+	n.Decoder = VariableName(r.qualifyRuntime(string(n.Decoder)))
+	n.Encoder = VariableName(r.qualifyRuntime(string(n.Encoder)))
+	return n, true
+}
+
+// optionWellKnownType - representation of a well known type chosen by the options
+func (r *Registry) optionWellKnownType(typeName string) (WellKnownType, bool) {
+	if r.options.Bytes == BytesAsElmBytes {
+		if n, ok := elmBytesWellKnownTypes[typeName]; ok {
+			r.Require("Bytes")
+			return n, true
+		}
+	}
+
+	if r.options.Struct == StructAsJSON {
+		if n, ok := jsonStructWellKnownTypes[typeName]; ok {
+			return n, true
+		}
+	}
+
+	switch r.options.Int64 {
+	case Int64AsString:
+		if n, ok := int64StringWellKnownTypes[typeName]; ok {
+			return n, true
+		}
+	case Int64AsInt64:
+		if n, ok := int64WellKnownTypes[typeName]; ok {
+			return n, true
+		}
+	}
+
+	n, ok := WellKnownTypeMap[typeName]
+	return n, ok
+}
@@ -0,0 +1,564 @@
+package elm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Print - source of an Elm module, laid out as `elm-format` lays it out
+// https://github.com/avh4/elm-format
+func Print(m Module) (string, error) {
+	p := &printer{}
+
+	var lines []string
+	lines = append(lines, fmt.Sprintf("module %s exposing %s", m.Name, Exposing(m.Exposing)), "")
+	if len(m.Comments) > 0 {
+		lines = append(lines, lineComments(m.Comments)...)
+		lines = append(lines, "")
+	}
+
+	imports := append([]Import{}, m.Imports...)
+	sort.SliceStable(imports, func(i, j int) bool {
+		return imports[i].Module < imports[j].Module
+	})
+	for _, i := range imports {
+		lines = append(lines, printImport(i))
+	}
+
+	for _, d := range m.Declarations {
+		// Standalone comments are sections, set apart from the declarations above them.
+		lines = append(lines, "", "")
+		if _, ok := d.(CommentDeclaration); ok {
+			lines = append(lines, "")
+		}
+
+		lines = append(lines, p.printDeclaration(d)...)
+	}
+
+	if p.err != nil {
+		return "", errors.Wrapf(p.err, "failed to print module %s", m.Name)
+	}
+
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// printer - state of the printing of a module, the first node it can't print failing it
+type printer struct {
+	err error
+}
+
+// fail - records a node of an unknown kind, printed as a placeholder until Print returns the error
+func (p *printer) fail(kind string, node interface{}) string {
+	if p.err == nil {
+		p.err = fmt.Errorf("unknown %s %T", kind, node)
+	}
+
+	return "_"
+}
+
+// Exposing - sorted exposing list of a module, `Type(..)` entries exposing variants
+func Exposing(names []string) string {
+	sorted := append([]string{}, names...)
+	sort.Strings(sorted)
+
+	return fmt.Sprintf("(%s)", strings.Join(sorted, ", "))
+}
+
+func printImport(i Import) string {
+	result := "import " + i.Module
+	if i.Alias != "" && i.Alias != i.Module {
+		result += " as " + i.Alias
+	}
+	if len(i.Exposing) > 0 {
+		result += " exposing " + Exposing(i.Exposing)
+	}
+
+	return result
+}
+
+func (p *printer) printDeclaration(d Declaration) []string {
+	switch d := d.(type) {
+	case CommentDeclaration:
+		return lineComments(d.Lines)
+	case TypeAliasDeclaration:
+		lines := docComment(d.Doc)
+		lines = append(lines, fmt.Sprintf("type alias %s =", d.Name))
+		return append(lines, indent(p.printTypeBlock(d.Type), 4)...)
+	case CustomTypeDeclaration:
+		lines := docComment(d.Doc)
+		lines = append(lines, fmt.Sprintf("type %s", d.Name))
+		return append(lines, p.printConstructors(d.Constructors)...)
+	case ValueDeclaration:
+		return p.printValueDeclaration(d)
+	default:
+		return []string{p.fail("declaration", d)}
+	}
+}
+
+func (p *printer) printValueDeclaration(d ValueDeclaration) []string {
+	lines := docComment(d.Doc)
+	if d.Annotation != nil {
+		lines = append(lines, fmt.Sprintf("%s : %s", d.Name, p.printType(d.Annotation)))
+	}
+
+	head := string(d.Name)
+	for _, arg := range d.Args {
+		head += " " + p.printPattern(arg, true)
+	}
+
+	lines = append(lines, head+" =")
+	return append(lines, indent(p.printExpr(d.Body), 4)...)
+}
+
+// docComment - `{-| ... -}` lines, empty without documentation
+func docComment(doc []string) []string {
+	if len(doc) == 0 {
+		return nil
+	}
+
+	lines := append([]string{"{-| " + doc[0]}, doc[1:]...)
+	return append(lines, "-}")
+}
+
+// lineComments - `--` comment lines, blank lines without trailing space
+func lineComments(comments []string) []string {
+	var lines []string
+	for _, c := range comments {
+		lines = append(lines, strings.TrimRight("-- "+c, " "))
+	}
+
+	return lines
+}
+
+func eolComment(line string, comment string) string {
+	if comment == "" {
+		return line
+	}
+
+	return line + " -- " + comment
+}
+
+func (p *printer) printConstructors(constructors []Constructor) []string {
+	var lines []string
+	for i, c := range constructors {
+		variant := string(c.Name)
+		for _, arg := range c.Args {
+			variant += " " + p.printTypeArg(arg)
+		}
+		variant = eolComment(variant, c.EOLComment)
+
+		if i > 0 {
+			lines = append(lines, indent(lineComments(c.Comments), 6)...)
+			lines = append(lines, "    | "+variant)
+			continue
+		}
+
+		comments := lineComments(c.Comments)
+		if len(comments) == 0 {
+			lines = append(lines, "    = "+variant)
+			continue
+		}
+
+		lines = append(lines, "    = "+comments[0])
+		lines = append(lines, indent(comments[1:], 6)...)
+		lines = append(lines, "      "+variant)
+	}
+
+	return lines
+}
+
+// printTypeBlock - type of a type alias, records with one field per line
+func (p *printer) printTypeBlock(t TypeExpr) []string {
+	record, ok := t.(RecordType)
+	if !ok {
+		return []string{p.printType(t)}
+	}
+
+	if len(record.Fields) == 0 {
+		return []string{"{}"}
+	}
+
+	var lines []string
+	for i, f := range record.Fields {
+		field := eolComment(fmt.Sprintf("%s : %s", f.Name, p.printType(f.Type)), f.EOLComment)
+		lines = append(lines, commentedItem(i, f.Comments, field)...)
+	}
+
+	return append(lines, "}")
+}
+
+// commentedItem - lines of an item of a multiline record, preceded by its comments
+func commentedItem(i int, comments []string, item string) []string {
+	comments = lineComments(comments)
+	if i > 0 {
+		var lines []string
+		if len(comments) > 0 {
+			lines = append([]string{""}, comments...)
+		}
+
+		return append(lines, ", "+item)
+	}
+
+	if len(comments) == 0 {
+		return []string{"{ " + item}
+	}
+
+	lines := []string{"{ " + comments[0]}
+	lines = append(lines, indent(comments[1:], 2)...)
+	return append(lines, "  "+item)
+}
+
+func (p *printer) printType(t TypeExpr) string {
+	switch t := t.(type) {
+	case Type:
+		return string(t)
+	case TypeRef:
+		result := string(t.Name)
+		for _, arg := range t.Args {
+			result += " " + p.printTypeArg(arg)
+		}
+		return result
+	case FunctionType:
+		var parts []string
+		for _, arg := range t.Args {
+			if _, ok := arg.(FunctionType); ok {
+				parts = append(parts, "("+p.printType(arg)+")")
+			} else {
+				parts = append(parts, p.printType(arg))
+			}
+		}
+		return strings.Join(append(parts, p.printType(t.Result)), " -> ")
+	case TupleType:
+		var parts []string
+		for _, item := range t {
+			parts = append(parts, p.printType(item))
+		}
+		return "( " + strings.Join(parts, ", ") + " )"
+	case RecordType:
+		if len(t.Fields) == 0 {
+			return "{}"
+		}
+		var parts []string
+		for _, f := range t.Fields {
+			parts = append(parts, fmt.Sprintf("%s : %s", f.Name, p.printType(f.Type)))
+		}
+		return "{ " + strings.Join(parts, ", ") + " }"
+	default:
+		return p.fail("type", t)
+	}
+}
+
+// printTypeArg - type as argument of another, in parentheses unless atomic
+func (p *printer) printTypeArg(t TypeExpr) string {
+	switch t := t.(type) {
+	case TypeRef:
+		if len(t.Args) > 0 {
+			return "(" + p.printType(t) + ")"
+		}
+	case FunctionType:
+		return "(" + p.printType(t) + ")"
+	}
+
+	return p.printType(t)
+}
+
+func (p *printer) printPattern(pattern Pattern, argument bool) string {
+	switch pattern := pattern.(type) {
+	case VariableName:
+		return string(pattern)
+	case VariantName:
+		return string(pattern)
+	case Literal:
+		return string(pattern)
+	case ConstructorPattern:
+		result := string(pattern.Name)
+		for _, arg := range pattern.Args {
+			result += " " + p.printPattern(arg, true)
+		}
+		if argument && len(pattern.Args) > 0 {
+			return "(" + result + ")"
+		}
+		return result
+	default:
+		return p.fail("pattern", pattern)
+	}
+}
+
+// printExpr - lines of an expression, lines after the first indented relative to the column the
+// expression starts at
+func (p *printer) printExpr(e Expr) []string {
+	switch e := e.(type) {
+	case VariableName:
+		return []string{string(e)}
+	case VariantName:
+		return []string{string(e)}
+	case Literal:
+		return []string{string(e)}
+	case Access:
+		return []string{fmt.Sprintf("%s.%s", e.Record, e.Field)}
+	case Call:
+		return p.printCall(e)
+	case BinOp:
+		return p.printBinOp(e)
+	case Pipeline:
+		lines := p.printExpr(e.Head)
+		for _, step := range e.Steps {
+			lines = append(lines, indent(prefix("|> ", p.printExpr(step)), 4)...)
+		}
+		return lines
+	case Lambda:
+		head := "\\"
+		for i, param := range e.Params {
+			if i > 0 {
+				head += " "
+			}
+			head += p.printPattern(param, true)
+		}
+		head += " ->"
+		body := p.printExpr(e.Body)
+		if len(body) == 1 {
+			return []string{head + " " + body[0]}
+		}
+		return append([]string{head}, indent(body, 4)...)
+	case Case:
+		// Subjects spanning lines stand alone between the keywords.
+		var lines []string
+		if subject := p.printExpr(e.Subject); len(subject) == 1 {
+			lines = []string{"case " + subject[0] + " of"}
+		} else {
+			lines = append(append([]string{"case"}, indent(subject, 4)...), "of")
+		}
+		for i, b := range e.Branches {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, "    "+p.printPattern(b.Pattern, false)+" ->")
+			lines = append(lines, indent(p.printExpr(b.Body), 8)...)
+		}
+		return lines
+	case Let:
+		lines := []string{"let"}
+		for i, d := range e.Declarations {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, indent(p.printValueDeclaration(d), 4)...)
+		}
+		lines = append(lines, "in")
+		return append(lines, p.printExpr(e.Body)...)
+	case List:
+		return p.printSequence("[", "]", "[]", p.printExprs(e.Items), e.Multiline)
+	case Tuple:
+		return p.printSequence("(", ")", "()", p.printExprs(e), false)
+	case Record:
+		return p.printSequence("{", "}", "{}", p.printRecordFields(e.Fields), e.Multiline)
+	case RecordUpdate:
+		fields := p.printRecordFields(e.Fields)
+		multiline := false
+		var parts []string
+		for _, block := range fields {
+			multiline = multiline || len(block) > 1
+			parts = append(parts, block[0])
+		}
+		if !multiline {
+			return []string{fmt.Sprintf("{ %s | %s }", e.Record, strings.Join(parts, ", "))}
+		}
+
+		// Updates spanning lines have one field per line, below the record.
+		lines := []string{"{ " + string(e.Record)}
+		for i, block := range fields {
+			separator := ", "
+			if i == 0 {
+				separator = "| "
+			}
+			lines = append(lines, indent(prefix(separator, block), 4)...)
+		}
+		return append(lines, "}")
+	default:
+		return []string{p.fail("expression", e)}
+	}
+}
+
+func (p *printer) printExprs(items []Expr) [][]string {
+	var blocks [][]string
+	for _, item := range items {
+		blocks = append(blocks, p.printExpr(item))
+	}
+
+	return blocks
+}
+
+// printRecordFields - `name = value` blocks, values spanning lines starting on the line below
+func (p *printer) printRecordFields(fields []RecordField) [][]string {
+	var blocks [][]string
+	for _, f := range fields {
+		value := p.printExpr(f.Value)
+		if len(value) == 1 {
+			blocks = append(blocks, []string{fmt.Sprintf("%s = %s", f.Name, value[0])})
+			continue
+		}
+
+		blocks = append(blocks, append([]string{fmt.Sprintf("%s =", f.Name)}, indent(value, 2)...))
+	}
+
+	return blocks
+}
+
+func (p *printer) printCall(c Call) []string {
+	lines := p.printExpr(c.Func)
+	multiline := false
+	for _, arg := range c.Args {
+		argLines := p.printArg(arg)
+		if !multiline && len(argLines) == 1 {
+			lines[len(lines)-1] += " " + argLines[0]
+			continue
+		}
+
+		// Once an argument spans lines, the following ones are stacked below the function.
+		multiline = true
+		lines = append(lines, indent(argLines, 4)...)
+	}
+
+	return lines
+}
+
+// printArg - expression as function argument, in parentheses unless atomic
+func (p *printer) printArg(e Expr) []string {
+	lines := p.printExpr(e)
+	if !needsParens(e) {
+		return lines
+	}
+
+	lines = prefix("(", lines)
+	lines[len(lines)-1] += ")"
+	return lines
+}
+
+func needsParens(e Expr) bool {
+	switch e := e.(type) {
+	case Literal:
+		return strings.HasPrefix(string(e), "-")
+	case Call, BinOp, Lambda, Case, Let:
+		return true
+	case Pipeline:
+		return len(e.Steps) > 0 || needsParens(e.Head)
+	default:
+		return false
+	}
+}
+
+func (p *printer) printBinOp(b BinOp) []string {
+	if b.Op == "<|" {
+		left := p.printOperand(b.Op, b.Left)
+		right := p.printExpr(b.Right)
+		if len(left) == 1 && len(right) == 1 {
+			return []string{left[0] + " <| " + right[0]}
+		}
+
+		left[len(left)-1] += " <|"
+		return append(left, indent(right, 4)...)
+	}
+
+	// Chains of a right associative operator are laid out as a sequence of operands.
+	operands := []Expr{b.Left}
+	for {
+		next, ok := b.Right.(BinOp)
+		if !ok || next.Op != b.Op {
+			operands = append(operands, b.Right)
+			break
+		}
+		operands = append(operands, next.Left)
+		b = next
+	}
+
+	var blocks [][]string
+	multiline := false
+	for _, operand := range operands {
+		block := p.printOperand(b.Op, operand)
+		multiline = multiline || len(block) > 1
+		blocks = append(blocks, block)
+	}
+
+	if !multiline {
+		var parts []string
+		for _, block := range blocks {
+			parts = append(parts, block[0])
+		}
+		return []string{strings.Join(parts, " "+b.Op+" ")}
+	}
+
+	lines := blocks[0]
+	for _, block := range blocks[1:] {
+		lines = append(lines, indent(prefix(b.Op+" ", block), 4)...)
+	}
+
+	return lines
+}
+
+// printOperand - operand of a binary operator, in parentheses when it is another operation
+func (p *printer) printOperand(op string, e Expr) []string {
+	switch e := e.(type) {
+	case BinOp:
+		if e.Op == op {
+			break
+		}
+		return p.printArg(e)
+	case Lambda, Case, Let:
+		return p.printArg(e)
+	}
+
+	return p.printExpr(e)
+}
+
+// printSequence - printed items of a list, tuple or record, one per line when multiline or when
+// an item spans lines
+func (p *printer) printSequence(open string, close string, empty string, blocks [][]string, multiline bool) []string {
+	if len(blocks) == 0 {
+		return []string{empty}
+	}
+
+	for _, block := range blocks {
+		multiline = multiline || len(block) > 1
+	}
+
+	if !multiline {
+		var parts []string
+		for _, block := range blocks {
+			parts = append(parts, block[0])
+		}
+		return []string{open + " " + strings.Join(parts, ", ") + " " + close}
+	}
+
+	var lines []string
+	for i, block := range blocks {
+		if i == 0 {
+			lines = append(lines, prefix(open+" ", block)...)
+		} else {
+			lines = append(lines, prefix(", ", block)...)
+		}
+	}
+
+	return append(lines, close)
+}
+
+// prefix - lines of a block following pre on its first line, the other lines aligned with it
+func prefix(pre string, lines []string) []string {
+	result := []string{pre + lines[0]}
+	return append(result, indent(lines[1:], len(pre))...)
+}
+
+// indent - lines indented by n spaces, blank lines kept empty
+func indent(lines []string, n int) []string {
+	pad := strings.Repeat(" ", n)
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		if line != "" {
+			result[i] = pad + line
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,148 @@
+package elm
+
+import (
+	"strings"
+	"testing"
+)
+
+func printDeclarations(t *testing.T, declarations ...Declaration) string {
+	t.Helper()
+
+	source, err := Print(Module{Name: "Test", Exposing: []string{"test"}, Declarations: declarations})
+	if err != nil {
+		t.Fatalf("Print failed: %v", err)
+	}
+
+	// Drop the module declaration and the blank lines after it.
+	return strings.TrimPrefix(source, "module Test exposing (test)\n\n\n\n")
+}
+
+func TestPrintModule(t *testing.T) {
+	source, err := Print(Module{
+		Name:     "Test",
+		Exposing: []string{"test", "Test(..)"},
+		Comments: []string{"DO NOT EDIT", ""},
+		Imports:  []Import{{Module: "Json.Decode", Alias: "JD"}, {Module: "Dict"}},
+		Declarations: []Declaration{
+			CustomTypeDeclaration{Name: "Test", Constructors: []Constructor{{Name: "A"}, {Name: "B", Args: []TypeExpr{Type("Int")}}}},
+			CommentDeclaration{Lines: []string{"SECTION"}},
+			ValueDeclaration{
+				Name:       "test",
+				Annotation: TypeRef{Name: "List", Args: []TypeExpr{Type("Test")}},
+				Body:       List{Items: []Expr{VariantName("A"), Apply(VariantName("B"), Literal("1"))}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Print failed: %v", err)
+	}
+
+	expected := `module Test exposing (Test(..), test)
+
+-- DO NOT EDIT
+--
+
+import Dict
+import Json.Decode as JD
+
+
+type Test
+    = A
+    | B Int
+
+
+
+-- SECTION
+
+
+test : List Test
+test =
+    [ A, B 1 ]
+`
+	if source != expected {
+		t.Errorf("Print =\n%s\nwant\n%s", source, expected)
+	}
+}
+
+func TestPrintMultilineCaseSubject(t *testing.T) {
+	source := printDeclarations(t, ValueDeclaration{
+		Name: "test",
+		Body: Case{
+			Subject: Pipeline{Head: VariableName("x"), Steps: []Expr{VariableName("f")}},
+			Branches: []Branch{
+				{Pattern: ConstructorPattern{Name: "Just", Args: []Pattern{VariableName("y")}}, Body: VariableName("y")},
+				{Pattern: wildcard, Body: Literal("0")},
+			},
+		},
+	})
+
+	expected := `test =
+    case
+        x
+            |> f
+    of
+        Just y ->
+            y
+
+        _ ->
+            0
+`
+	if source != expected {
+		t.Errorf("Print =\n%s\nwant\n%s", source, expected)
+	}
+}
+
+func TestPrintRecordUpdate(t *testing.T) {
+	short := RecordUpdate{Record: "r", Fields: []RecordField{{Name: "a", Value: Literal("1")}, {Name: "b", Value: VariableName("c")}}}
+	long := RecordUpdate{Record: "r", Fields: []RecordField{
+		{Name: "a", Value: Literal("1")},
+		{Name: "b", Value: List{Items: []Expr{Literal("1"), Literal("2")}, Multiline: true}},
+	}}
+	source := printDeclarations(t,
+		ValueDeclaration{Name: "short", Body: short},
+		ValueDeclaration{Name: "long", Body: long},
+	)
+
+	expected := `short =
+    { r | a = 1, b = c }
+
+
+long =
+    { r
+        | a = 1
+        , b =
+            [ 1
+            , 2
+            ]
+    }
+`
+	if source != expected {
+		t.Errorf("Print =\n%s\nwant\n%s", source, expected)
+	}
+}
+
+func TestPrintUnknownNodes(t *testing.T) {
+	tests := []struct {
+		name        string
+		declaration Declaration
+		err         string
+	}{
+		{"declaration", nil, "unknown declaration <nil>"},
+		{"type", TypeAliasDeclaration{Name: "Test"}, "unknown type <nil>"},
+		{"pattern", ValueDeclaration{Name: "test", Args: []Pattern{nil}, Body: Literal("1")}, "unknown pattern <nil>"},
+		{"expression", ValueDeclaration{Name: "test", Body: Apply(VariableName("f"), nil)}, "unknown expression <nil>"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Print(Module{Name: "Test", Declarations: []Declaration{test.declaration}})
+			if err == nil {
+				t.Fatal("Print succeeded")
+			}
+
+			if expected := "failed to print module Test: " + test.err; err.Error() != expected {
+				t.Errorf("Print error = %q, want %q", err.Error(), expected)
+			}
+		})
+	}
+}
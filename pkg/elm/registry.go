@@ -0,0 +1,498 @@
+package elm
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Symbol - Elm identity of a PB message or enum definition
+type Symbol struct {
+	// Module - Elm module the definition is generated in
+	Module string
+	// Type - Elm type generated for the definition
+	Type Type
+	// Message - PB definition of a message, nil for enums
+	Message *descriptorpb.DescriptorProto
+	// Enum - PB definition of an enum, nil for messages
+	Enum *descriptorpb.EnumDescriptorProto
+	// Preface - names of the PB messages the definition is nested in, innermost first
+	Preface []string
+	// Features - resolved edition features of the definition
+	Features Features
+}
+
+// Import - import of an Elm module, qualified by its alias unless it exposes its declarations
+type Import struct {
+	Module   string
+	Alias    string
+	Exposing []string
+}
+
+// Registry - symbol table of every PB message and enum in a generation request,
+// indexed by fully qualified PB name (ex. `.foo.bar.Baz`)
+type Registry struct {
+	options Options
+	symbols map[string]Symbol
+	modules map[string]bool
+	// Type and variant names generated for the messages and enums of each module
+	names map[string]map[string]bool
+
+	// Set when scoped to the Elm module being generated, see InModule
+	module  string
+	aliases map[string]string
+	imports map[string]bool
+	// Component of each message in a cycle of type aliases, see RecursiveField
+	cycles map[*descriptorpb.DescriptorProto]int
+	// Set when the module imports the runtime library without exposing it, see QualifiedRuntime
+	qualifiedRuntime bool
+}
+
+// Modules of the runtime library and its dependencies by the alias they are imported as
+var runtimeModules = map[string]string{
+	"Protobuf": "Protobuf",
+	"JD":       "Json.Decode",
+	"JE":       "Json.Encode",
+	"PB":       "Protobuf.Binary",
+	"Bytes":    "Bytes",
+}
+
+// Types declared by default in every Elm module
+var coreTypes = map[Type]bool{
+	intType:    true,
+	floatType:  true,
+	stringType: true,
+	boolType:   true,
+	"()":       true,
+}
+
+var reservedAliases = map[string]bool{
+	// Imported by every generated module
+	"Protobuf": true,
+	"JD":       true,
+	"JE":       true,
+	"Dict":     true,
+	"Bytes":    true,
+	"PB":       true,
+	// Imported by service clients
+	"Http": true,
+	"PH":   true,
+	// Imported by default in every Elm module
+	"Basics":   true,
+	"List":     true,
+	"Maybe":    true,
+	"Result":   true,
+	"String":   true,
+	"Char":     true,
+	"Tuple":    true,
+	"Debug":    true,
+	"Platform": true,
+	"Cmd":      true,
+	"Sub":      true,
+}
+
+// NewRegistry - creates an empty registry generating code with the given options
+func NewRegistry(options Options) *Registry {
+	return &Registry{
+		options: options,
+		symbols: map[string]Symbol{},
+		modules: map[string]bool{},
+		names:   map[string]map[string]bool{},
+	}
+}
+
+// InModule - registry scoped to the Elm module being generated, references to
+// symbols of other modules are qualified and tracked as imports
+func (r *Registry) InModule(module string) *Registry {
+	var modules []string
+	for m := range r.modules {
+		modules = append(modules, m)
+	}
+	sort.Strings(modules)
+
+	// Aliases are assigned in module order so that a module keeps the same alias
+	// in every file of a run.
+	aliases := map[string]string{}
+	taken := map[string]bool{}
+	for _, m := range modules {
+		base := strings.Replace(m, ".", "", -1)
+		alias := base
+		for i := 2; taken[alias] || reservedAliases[alias]; i++ {
+			alias = base + strconv.Itoa(i)
+		}
+
+		taken[alias] = true
+		aliases[m] = alias
+	}
+
+	result := &Registry{
+		options: r.options,
+		symbols: r.symbols,
+		modules: r.modules,
+		names:   r.names,
+		module:  module,
+		aliases: aliases,
+		imports: map[string]bool{},
+	}
+	result.cycles = result.messageCycles()
+
+	return result
+}
+
+// mapKeys - registry for the keys of map fields, exact 64 bit keys are decimal strings since
+// `Protobuf.Int64` values can't be `Dict` keys
+func (r *Registry) mapKeys() *Registry {
+	if r.options.Int64 != Int64AsInt64 {
+		return r
+	}
+
+	keys := *r
+	keys.options.Int64 = Int64AsString
+	return &keys
+}
+
+// QualifiedRuntime - registry for a module importing the runtime library without exposing its
+// declarations, names of well known types being qualified and their modules tracked as imports
+func (r *Registry) QualifiedRuntime() *Registry {
+	qualified := *r
+	qualified.qualifiedRuntime = true
+	return &qualified
+}
+
+// qualifyRuntime - name of the runtime library as seen from a module not exposing it
+func (r *Registry) qualifyRuntime(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		if module, ok := runtimeModules[name[:i]]; ok {
+			r.Require(module)
+		}
+		return name
+	}
+
+	if coreTypes[Type(name)] {
+		return name
+	}
+
+	r.Require("Protobuf")
+	return "Protobuf." + name
+}
+
+// Qualify - reference to a name declared in the module of symbol, as seen from the
+// module the registry is scoped to
+func (r *Registry) Qualify(symbol Symbol, name string) string {
+	if symbol.Module == r.module {
+		return name
+	}
+
+	if r.imports != nil {
+		r.imports[symbol.Module] = true
+	}
+
+	return fmt.Sprintf("%s.%s", r.aliases[symbol.Module], name)
+}
+
+// Require - tracks a non generated module as imported, under its own name
+func (r *Registry) Require(module string) {
+	if r.imports != nil {
+		r.imports[module] = true
+	}
+}
+
+// Imports - modules referenced through Qualify or Require, sorted by module name
+func (r *Registry) Imports() []Import {
+	var result []Import
+	for m := range r.imports {
+		alias, ok := r.aliases[m]
+		if !ok {
+			alias = m
+			for a, module := range runtimeModules {
+				if module == m {
+					alias = a
+				}
+			}
+		}
+
+		result = append(result, Import{
+			Module: m,
+			Alias:  alias,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Module < result[j].Module
+	})
+
+	return result
+}
+
+// AddFile - registers all definitions of a PB file as generated in the given Elm module
+func (r *Registry) AddFile(inFile *descriptorpb.FileDescriptorProto, module string) {
+	r.modules[module] = true
+	if r.names[module] == nil {
+		r.names[module] = map[string]bool{}
+	}
+
+	scope := ""
+	if inFile.GetPackage() != "" {
+		scope = "." + inFile.GetPackage()
+	}
+
+	// Unsupported editions are reported when the file is generated.
+	features, _ := FileFeatures(inFile)
+
+	r.addEnums(scope, []string{}, inFile.GetEnumType(), module, features)
+	r.addMessages(scope, []string{}, inFile.GetMessageType(), module, features)
+}
+
+func (r *Registry) addEnums(scope string, preface []string, enumPbs []*descriptorpb.EnumDescriptorProto, module string, features Features) {
+	for _, enumPb := range enumPbs {
+		enumType := NestedType(enumPb.GetName(), preface)
+		r.symbols[scope+"."+enumPb.GetName()] = Symbol{
+			Module:   module,
+			Type:     enumType,
+			Enum:     enumPb,
+			Preface:  preface,
+			Features: features.Merge(enumPb.GetOptions().GetFeatures()),
+		}
+
+		r.names[module][string(enumType)] = true
+		r.names[module][string(EnumUnrecognizedVariantName(enumType))] = true
+		for _, value := range enumPb.GetValue() {
+			r.names[module][string(NestedVariantName(value.GetName(), preface))] = true
+		}
+	}
+}
+
+func (r *Registry) addMessages(scope string, preface []string, messagePbs []*descriptorpb.DescriptorProto, module string, features Features) {
+	for _, messagePb := range messagePbs {
+		fullName := scope + "." + messagePb.GetName()
+		messageFeatures := features.Merge(messagePb.GetOptions().GetFeatures())
+		messageType := NestedType(messagePb.GetName(), preface)
+		r.symbols[fullName] = Symbol{
+			Module:   module,
+			Type:     messageType,
+			Message:  messagePb,
+			Preface:  preface,
+			Features: messageFeatures,
+		}
+
+		// The type alias of a record is also its constructor, opaque types hide it under `Foo_`.
+		r.names[module][string(messageType)] = true
+		if r.options.Opaque {
+			r.names[module][string(messageType)+"_"] = true
+		}
+
+		newPreface := append([]string{messagePb.GetName()}, preface...)
+		r.addEnums(fullName, newPreface, messagePb.GetEnumType(), module, messageFeatures)
+		r.addMessages(fullName, newPreface, messagePb.GetNestedType(), module, messageFeatures)
+	}
+}
+
+// uniqueName - name of a definition generated for a field or one-of, with underscores appended while
+// it or a name derived from it clashes with the types and variants of the messages and enums of the
+// module, which can't end with an underscore
+func (r *Registry) uniqueName(name string, derived ...func(string) string) string {
+	taken := func(name string) bool {
+		if r.names[r.module][name] {
+			return true
+		}
+		for _, d := range derived {
+			if r.names[r.module][d(name)] {
+				return true
+			}
+		}
+		return false
+	}
+
+	for taken(name) {
+		name += "_"
+	}
+
+	return name
+}
+
+// OneOfType - Elm custom type for a PB one-of, scoped by the path of its message
+func (r *Registry) OneOfType(name string, preface []string) Type {
+	unspecified := func(t string) string {
+		return string(OneOfUnspecifiedVariantName(Type(t)))
+	}
+
+	return Type(r.uniqueName(string(NestedType(name, preface)), unspecified))
+}
+
+// OneOfVariantName - Elm variant of a field of a PB one-of, scoped by the path of its message
+func (r *Registry) OneOfVariantName(name string, preface []string) VariantName {
+	return VariantName(r.uniqueName(string(NestedVariantName(name, preface))))
+}
+
+// Lookup - finds the symbol for a fully qualified PB name
+func (r *Registry) Lookup(fullyQualifiedName string) (Symbol, error) {
+	symbol, ok := r.symbols[fullyQualifiedName]
+	if !ok {
+		return Symbol{}, fmt.Errorf("unknown type %s", fullyQualifiedName)
+	}
+
+	return symbol, nil
+}
+
+// EnumValueVariant - Elm variant of a named value of an enum, aliases resolve to the first value
+// with the same number
+func (r *Registry) EnumValueVariant(symbol Symbol, name string) (VariantName, error) {
+	for _, value := range symbol.Enum.GetValue() {
+		if value.GetName() != name {
+			continue
+		}
+
+		for _, canonical := range symbol.Enum.GetValue() {
+			if canonical.GetNumber() == value.GetNumber() {
+				return NestedVariantName(canonical.GetName(), symbol.Preface), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("unknown value %s of enum %s", name, symbol.Enum.GetName())
+}
+
+// MapEntry - PB map entry message referenced by a field, nil if the field is not a map
+func (r *Registry) MapEntry(inField *descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+	if inField.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE ||
+		inField.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+		return nil
+	}
+
+	symbol, err := r.Lookup(inField.GetTypeName())
+	if err != nil || !symbol.Message.GetOptions().GetMapEntry() {
+		return nil
+	}
+
+	return symbol.Message
+}
+
+// RecursiveField - whether a field of a message references a message of the same cycle, Elm type
+// aliases can't be recursive so the field needs a wrapper custom type
+func (r *Registry) RecursiveField(messagePb *descriptorpb.DescriptorProto, inField *descriptorpb.FieldDescriptorProto) bool {
+	component, ok := r.cycles[messagePb]
+	if !ok {
+		return false
+	}
+
+	target := r.fieldMessage(inField)
+	return target != nil && r.cycles[target] == component
+}
+
+// fieldMessage - message a field is an alias of in the generated record, the value of map fields,
+// nil for scalars and enums
+func (r *Registry) fieldMessage(inField *descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+	if inField.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE &&
+		inField.GetType() != descriptorpb.FieldDescriptorProto_TYPE_GROUP {
+		return nil
+	}
+
+	symbol, err := r.Lookup(inField.GetTypeName())
+	if err != nil || symbol.Message == nil {
+		return nil
+	}
+
+	if entry := r.MapEntry(inField); entry != nil {
+		_, valueField, err := mapEntryFields(entry)
+		if err != nil {
+			return nil
+		}
+
+		return r.fieldMessage(valueField)
+	}
+
+	return symbol.Message
+}
+
+// aliasedMessages - messages referenced by the record of a message, one-ofs are custom types
+// and don't take part in cycles
+func (r *Registry) aliasedMessages(messagePb *descriptorpb.DescriptorProto) []*descriptorpb.DescriptorProto {
+	var result []*descriptorpb.DescriptorProto
+	for _, inField := range messagePb.GetField() {
+		if inField.OneofIndex != nil && !inField.GetProto3Optional() {
+			continue
+		}
+
+		if target := r.fieldMessage(inField); target != nil {
+			result = append(result, target)
+		}
+	}
+
+	return result
+}
+
+// messageCycles - strongly connected components of the message graph that contain a cycle,
+// found with Tarjan's algorithm
+func (r *Registry) messageCycles() map[*descriptorpb.DescriptorProto]int {
+	var names []string
+	for name, symbol := range r.symbols {
+		if symbol.Message != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	index := map[*descriptorpb.DescriptorProto]int{}
+	lowLink := map[*descriptorpb.DescriptorProto]int{}
+	onStack := map[*descriptorpb.DescriptorProto]bool{}
+	var stack []*descriptorpb.DescriptorProto
+	result := map[*descriptorpb.DescriptorProto]int{}
+	components := 0
+
+	var visit func(*descriptorpb.DescriptorProto)
+	visit = func(v *descriptorpb.DescriptorProto) {
+		index[v] = len(index)
+		lowLink[v] = index[v]
+		stack = append(stack, v)
+		onStack[v] = true
+
+		selfReference := false
+		for _, w := range r.aliasedMessages(v) {
+			if w == v {
+				selfReference = true
+			}
+
+			if _, visited := index[w]; !visited {
+				visit(w)
+				if lowLink[w] < lowLink[v] {
+					lowLink[v] = lowLink[w]
+				}
+			} else if onStack[w] && index[w] < lowLink[v] {
+				lowLink[v] = index[w]
+			}
+		}
+
+		if lowLink[v] != index[v] {
+			return
+		}
+
+		var component []*descriptorpb.DescriptorProto
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			component = append(component, w)
+			if w == v {
+				break
+			}
+		}
+
+		if len(component) > 1 || selfReference {
+			components++
+			for _, w := range component {
+				result[w] = components
+			}
+		}
+	}
+
+	for _, name := range names {
+		if _, visited := index[r.symbols[name].Message]; !visited {
+			visit(r.symbols[name].Message)
+		}
+	}
+
+	return result
+}
@@ -2,7 +2,7 @@ package elm
 
 import (
 	"fmt"
-	"text/template"
+	"strings"
 
 	"github.com/jalandis/elm-protobuf/pkg/stringextras"
 
@@ -14,6 +14,10 @@ type WellKnownType struct {
 	Type    Type
 	Encoder VariableName
 	Decoder VariableName
+	// Default - default value of a well known enum
+	Default Expr
+	// Binary - Protobuf.Binary field type, nil when the type has no binary representation
+	Binary Expr
 }
 
 var (
@@ -23,51 +27,110 @@ var (
 			Type:    "Timestamp",
 			Decoder: "timestampDecoder",
 			Encoder: "timestampEncoder",
+			Binary:  VariableName("PB.timestamp"),
+		},
+		".google.protobuf.Duration": {
+			Type:    "Duration",
+			Decoder: "durationDecoder",
+			Encoder: "durationEncoder",
+			Binary:  VariableName("PB.duration"),
+		},
+		// Qualified, common names such as `Value` are likely to clash with generated types.
+		".google.protobuf.Struct": {
+			Type:    "Protobuf.Struct",
+			Decoder: "Protobuf.structDecoder",
+			Encoder: "Protobuf.structEncoder",
+			Binary:  VariableName("PB.struct"),
+		},
+		".google.protobuf.Value": {
+			Type:    "Protobuf.Value",
+			Decoder: "Protobuf.valueDecoder",
+			Encoder: "Protobuf.valueEncoder",
+			Binary:  VariableName("PB.value"),
+		},
+		".google.protobuf.ListValue": {
+			Type:    "Protobuf.ListValue",
+			Decoder: "Protobuf.listValueDecoder",
+			Encoder: "Protobuf.listValueEncoder",
+			Binary:  VariableName("PB.listValue"),
+		},
+		".google.protobuf.Any": {
+			Type:    "Protobuf.Any",
+			Decoder: "Protobuf.anyDecoder",
+			Encoder: "Protobuf.anyEncoder",
+		},
+		".google.protobuf.FieldMask": {
+			Type:    "Protobuf.FieldMask",
+			Decoder: "Protobuf.fieldMaskDecoder",
+			Encoder: "Protobuf.fieldMaskEncoder",
+			Binary:  VariableName("PB.fieldMask"),
+		},
+		".google.protobuf.Empty": {
+			Type:    "Protobuf.Empty",
+			Decoder: "Protobuf.emptyDecoder",
+			Encoder: "Protobuf.emptyEncoder",
+			Binary:  VariableName("PB.empty"),
+		},
+		".google.protobuf.NullValue": {
+			Type:    "()",
+			Decoder: "Protobuf.nullValueDecoder",
+			Encoder: "Protobuf.nullValueEncoder",
+			Default: Tuple{},
+			Binary:  VariableName("PB.nullValue"),
 		},
 		".google.protobuf.Int32Value": {
 			Type:    intType,
 			Decoder: "intValueDecoder",
 			Encoder: "intValueEncoder",
+			Binary:  Apply(VariableName("PB.wrapper"), VariableName("PB.int32")),
 		},
 		".google.protobuf.Int64Value": {
 			Type:    intType,
 			Decoder: "intValueDecoder",
 			Encoder: "numericStringEncoder",
+			Binary:  Apply(VariableName("PB.wrapper"), VariableName("PB.int64")),
 		},
 		".google.protobuf.UInt32Value": {
 			Type:    intType,
 			Decoder: "intValueDecoder",
 			Encoder: "intValueEncoder",
+			Binary:  Apply(VariableName("PB.wrapper"), VariableName("PB.uint32")),
 		},
 		".google.protobuf.UInt64Value": {
 			Type:    intType,
 			Decoder: "intValueDecoder",
 			Encoder: "numericStringEncoder",
+			Binary:  Apply(VariableName("PB.wrapper"), VariableName("PB.uint64")),
 		},
 		".google.protobuf.DoubleValue": {
 			Type:    floatType,
 			Decoder: "floatValueDecoder",
 			Encoder: "floatValueEncoder",
+			Binary:  Apply(VariableName("PB.wrapper"), VariableName("PB.double")),
 		},
 		".google.protobuf.FloatValue": {
 			Type:    floatType,
 			Decoder: "floatValueDecoder",
 			Encoder: "floatValueEncoder",
+			Binary:  Apply(VariableName("PB.wrapper"), VariableName("PB.float")),
 		},
 		".google.protobuf.StringValue": {
 			Type:    stringType,
 			Decoder: "stringValueDecoder",
 			Encoder: "stringValueEncoder",
+			Binary:  Apply(VariableName("PB.wrapper"), VariableName("PB.string")),
 		},
 		".google.protobuf.BytesValue": {
 			Type:    bytesType,
 			Decoder: "bytesValueDecoder",
 			Encoder: "bytesValueEncoder",
+			Binary:  Apply(VariableName("PB.wrapper"), VariableName("PB.bytes")),
 		},
 		".google.protobuf.BoolValue": {
 			Type:    boolType,
 			Decoder: "boolValueDecoder",
 			Encoder: "boolValueEncoder",
+			Binary:  Apply(VariableName("PB.wrapper"), VariableName("PB.bool")),
 		},
 	}
 
@@ -95,22 +158,151 @@ type TypeAlias struct {
 	Name    Type
 	Decoder VariableName
 	Encoder VariableName
-	Fields  []TypeAliasField
+	// Decode - runtime helper the decoder starts with, see MessageDecode
+	Decode Expr
+	// BinaryDecoder, BinaryEncoder - empty unless binary codecs are generated
+	BinaryDecoder VariableName
+	BinaryEncoder VariableName
+	Fields        []TypeAliasField
+	// Extendable - messages with extension ranges keep the JSON values of their extensions in an
+	// additional `extensions_` field
+	Extendable bool
+	Comment    Comment
+	// Opaque - the record is hidden behind a custom type of the same name, see OpaqueTypeAlias
+	Opaque      bool
+	Constructor VariableName
 }
 
-// FieldDecoder used in type alias decdoer (ex. )
-type FieldDecoder string
-
-// FieldEncoder used in type alias decdoer (ex. )
-type FieldEncoder string
+// extensionsField - record field keeping the extensions of a message with extension ranges
+const extensionsField VariableName = "extensions_"
 
 // TypeAliasField - type alias field definition
 type TypeAliasField struct {
-	Name    VariableName
-	Type    Type
-	Number  ProtobufFieldNumber
-	Decoder FieldDecoder
-	Encoder FieldEncoder
+	Name          VariableName
+	Type          TypeExpr
+	Number        ProtobufFieldNumber
+	Decoder       Expr
+	Encoder       Expr
+	BinaryDecoder Expr
+	BinaryEncoder Expr
+	Comment       Comment
+	// Default - value of the field in the constructor of an opaque type, nil for fields without
+	// default passed as argument
+	Default Expr
+	// Getter, Setter - accessors of the field of an opaque type
+	Getter VariableName
+	Setter VariableName
+}
+
+// WrapperType - custom type wrapping the value of a field of a recursive message, as Elm type
+// aliases can't reference themselves
+type WrapperType struct {
+	Name   Type
+	Type   TypeExpr
+	Unwrap VariableName
+}
+
+// NewWrapperType - wrapper custom type for a field of a possibly nested message, named after the field
+// unless a nested definition takes that name
+func NewWrapperType(r *Registry, name string, preface []string, t TypeExpr) WrapperType {
+	wrapperType := Type(r.uniqueName(string(NestedType(name, preface))))
+	return WrapperType{
+		Name:   wrapperType,
+		Type:   t,
+		Unwrap: VariableName(fmt.Sprintf("unwrap%s", wrapperType)),
+	}
+}
+
+// WrapField - field holding its value in a wrapper custom type, decoders wrap the decoded value
+// and encoders unwrap it
+func WrapField(field TypeAliasField, wrapper WrapperType) (TypeAliasField, error) {
+	encoder, err := unwrapValue(field.Encoder, wrapper.Unwrap)
+	if err != nil {
+		return TypeAliasField{}, err
+	}
+
+	field.Type = wrapper.Name
+	field.Decoder = Apply(VariableName("wrapped"), VariantName(wrapper.Name), field.Decoder)
+	field.Encoder = encoder
+	if field.BinaryDecoder != nil {
+		binaryEncoder, err := unwrapValue(field.BinaryEncoder, wrapper.Unwrap)
+		if err != nil {
+			return TypeAliasField{}, err
+		}
+
+		field.BinaryDecoder = Apply(VariableName("PB.wrapped"), VariantName(wrapper.Name), field.BinaryDecoder)
+		field.BinaryEncoder = binaryEncoder
+	}
+
+	return field, nil
+}
+
+// unwrapValue - field encoder applied to the unwrapped value, passed as its last argument
+func unwrapValue(encoder Expr, unwrap VariableName) (Expr, error) {
+	call, ok := encoder.(Call)
+	if !ok || len(call.Args) == 0 {
+		return nil, fmt.Errorf("no value to unwrap in field encoder %T", encoder)
+	}
+
+	args := append([]Expr{}, call.Args...)
+	args[len(args)-1] = Apply(unwrap, args[len(args)-1])
+
+	return Call{Func: call.Func, Args: args}, nil
+}
+
+// OpaqueTypeAlias - type alias whose record is wrapped in a custom type of the same name, built by
+// a constructor taking the fields without default and accessed through getters and setters
+func OpaqueTypeAlias(t TypeAlias) TypeAlias {
+	t.Opaque = true
+	t.Constructor = ConstructorName(t.Name)
+
+	fields := make([]TypeAliasField, len(t.Fields))
+	for i, field := range t.Fields {
+		field.Getter = GetterName(t.Name, field.Name)
+		field.Setter = SetterName(t.Name, field.Name)
+		fields[i] = field
+	}
+	t.Fields = fields
+
+	return t
+}
+
+// Record - type alias of the record of a message, hidden behind the opaque type
+func (t TypeAlias) Record() Type {
+	if t.Opaque {
+		return Type(fmt.Sprintf("%s_", t.Name))
+	}
+
+	return t.Name
+}
+
+// ExtensionsGetter - reads the extensions of an opaque type
+func (t TypeAlias) ExtensionsGetter() VariableName {
+	return GetterName(t.Name, extensionsField)
+}
+
+// ExtensionsSetter - replaces the extensions of an opaque type
+func (t TypeAlias) ExtensionsSetter() VariableName {
+	return SetterName(t.Name, extensionsField)
+}
+
+// ConstructorName - function building an opaque type
+func ConstructorName(t Type) VariableName {
+	return VariableName(fmt.Sprintf("new%s", t))
+}
+
+// GetterName - function reading a field of an opaque type
+func GetterName(t Type, field VariableName) VariableName {
+	return VariableName(fmt.Sprintf("get%s%s", t, accessorSuffix(field)))
+}
+
+// SetterName - function replacing a field of an opaque type
+func SetterName(t Type, field VariableName) VariableName {
+	return VariableName(fmt.Sprintf("set%s%s", t, accessorSuffix(field)))
+}
+
+func accessorSuffix(field VariableName) string {
+	return stringextras.FirstUpper(strings.TrimSuffix(string(field), "_"))
 }
 
 func appendUnderscoreToReservedKeywords(in string) string {
@@ -131,145 +323,488 @@ func FieldJSONName(pb *descriptorpb.FieldDescriptorProto) VariantJSONName {
 	return VariantJSONName(pb.GetJsonName())
 }
 
-func RequiredFieldEncoder(pb *descriptorpb.FieldDescriptorProto) FieldEncoder {
-	return FieldEncoder(fmt.Sprintf(
-		"requiredFieldEncoder \"%s\" %s %s v.%s",
-		FieldJSONName(pb),
-		BasicFieldEncoder(pb),
-		BasicFieldDefaultValue(pb),
-		FieldName(pb.GetName()),
-	))
+// fieldValue - value of a PB field in the record `v` being encoded
+func fieldValue(pb *descriptorpb.FieldDescriptorProto) Access {
+	return Access{Record: "v", Field: FieldName(pb.GetName())}
+}
+
+// fieldJSONName - JSON identifier of a PB field, as String literal
+func fieldJSONName(pb *descriptorpb.FieldDescriptorProto) Literal {
+	return StringLiteral(string(FieldJSONName(pb)))
+}
+
+// RequiredFieldEncoder - encoder for a PB field with a default value
+func RequiredFieldEncoder(r *Registry, pb *descriptorpb.FieldDescriptorProto) (Expr, error) {
+	// elm/bytes values can't be compared to a default value.
+	if pb.GetType() == descriptorpb.FieldDescriptorProto_TYPE_BYTES && r.options.Bytes == BytesAsElmBytes {
+		if pb.DefaultValue != nil {
+			return MandatoryFieldEncoder(r, pb)
+		}
+
+		return Apply(VariableName("requiredBytesFieldEncoder"), fieldJSONName(pb), fieldValue(pb)), nil
+	}
+
+	encoder, err := BasicFieldEncoder(r, pb)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultValue, err := FieldDefaultValue(r, pb)
+	if err != nil {
+		return nil, err
+	}
+
+	return Apply(VariableName("requiredFieldEncoder"), fieldJSONName(pb), encoder, defaultValue, fieldValue(pb)), nil
+}
+
+// RequiredFieldDecoder - decoder for a PB field with a default value
+func RequiredFieldDecoder(r *Registry, pb *descriptorpb.FieldDescriptorProto) (Expr, error) {
+	decoder, err := BasicFieldDecoder(r, pb)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultValue, err := FieldDefaultValue(r, pb)
+	if err != nil {
+		return nil, err
+	}
+
+	return Apply(r.decoderHelper("required"), fieldJSONName(pb), decoder, defaultValue), nil
+}
+
+// MandatoryFieldEncoder - encoder for a proto2 `required` field, always present
+func MandatoryFieldEncoder(r *Registry, pb *descriptorpb.FieldDescriptorProto) (Expr, error) {
+	encoder, err := BasicFieldEncoder(r, pb)
+	if err != nil {
+		return nil, err
+	}
+
+	return Apply(VariableName("mandatoryFieldEncoder"), fieldJSONName(pb), encoder, fieldValue(pb)), nil
+}
+
+// MandatoryFieldDecoder - decoder for a proto2 `required` field, failing when missing
+func MandatoryFieldDecoder(r *Registry, pb *descriptorpb.FieldDescriptorProto) (Expr, error) {
+	decoder, err := BasicFieldDecoder(r, pb)
+	if err != nil {
+		return nil, err
+	}
+
+	return Apply(VariableName("mandatory"), fieldJSONName(pb), decoder), nil
+}
+
+// MessageDecode - runtime helper starting the decoder of a PB message, listing the JSON names of
+// its fields when unknown keys are rejected, extension keys excepted
+func MessageDecode(r *Registry, messagePb *descriptorpb.DescriptorProto) Expr {
+	if r.options.Strict != StrictUnknownFields {
+		return r.decoderHelper("decode")
+	}
+
+	var names List
+	for _, fieldPb := range messagePb.GetField() {
+		names.Items = append(names.Items, fieldJSONName(fieldPb))
+	}
+
+	decode := VariableName("strictDecodeKnown")
+	if len(messagePb.GetExtensionRange()) > 0 {
+		decode = "strictDecodeExtendable"
+	}
+
+	return Apply(decode, names)
+}
+
+// OneOfEncoder - encoder for a PB one-of
+func OneOfEncoder(pb *descriptorpb.OneofDescriptorProto, t Type) Expr {
+	return Apply(EncoderName(t), Access{Record: "v", Field: FieldName(pb.GetName())})
+}
+
+// OneOfDefaultValue - value of a PB one-of without any field set
+func OneOfDefaultValue(t Type) Expr {
+	return OneOfUnspecifiedVariantName(t)
+}
+
+// OneOfDecoder - decoder for a PB one-of
+func OneOfDecoder(t Type) Expr {
+	return Apply(VariableName("field"), DecoderName(t))
 }
 
-func RequiredFieldDecoder(pb *descriptorpb.FieldDescriptorProto) FieldDecoder {
-	return FieldDecoder(fmt.Sprintf(
-		"required \"%s\" %s %s",
-		FieldJSONName(pb),
-		BasicFieldDecoder(pb),
-		BasicFieldDefaultValue(pb),
-	))
+func mapEntryFields(messagePb *descriptorpb.DescriptorProto) (*descriptorpb.FieldDescriptorProto, *descriptorpb.FieldDescriptorProto, error) {
+	if len(messagePb.GetField()) != 2 {
+		return nil, nil, fmt.Errorf("map entry %s has %d fields, expected key and value", messagePb.GetName(), len(messagePb.GetField()))
+	}
+
+	return messagePb.GetField()[0], messagePb.GetField()[1], nil
 }
 
-func OneOfEncoder(pb *descriptorpb.OneofDescriptorProto) FieldEncoder {
-	return FieldEncoder(fmt.Sprintf("%s v.%s",
-		EncoderName(Type(stringextras.CamelCase(pb.GetName()))),
-		FieldName(pb.GetName()),
-	))
+// MapDefaultValue - empty value of a PB map field
+func MapDefaultValue(messagePb *descriptorpb.DescriptorProto) (Expr, error) {
+	keyField, _, err := mapEntryFields(messagePb)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyField.GetType() == descriptorpb.FieldDescriptorProto_TYPE_BOOL {
+		return List{}, nil
+	}
+
+	return VariableName("Dict.empty"), nil
 }
 
-func OneOfDecoder(pb *descriptorpb.OneofDescriptorProto) FieldDecoder {
-	return FieldDecoder(fmt.Sprintf(
-		"field %s",
-		DecoderName(Type(stringextras.CamelCase(pb.GetName()))),
-	))
+// MapType - Elm Dict type for a PB map entry, a list of entries for bool keys
+func MapType(r *Registry, messagePb *descriptorpb.DescriptorProto) (TypeExpr, error) {
+	keyField, valueField, err := mapEntryFields(messagePb)
+	if err != nil {
+		return nil, err
+	}
+
+	keyType, err := BasicFieldType(r.mapKeys(), keyField)
+	if err != nil {
+		return nil, err
+	}
+
+	valueType, err := BasicFieldType(r, valueField)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyField.GetType() == descriptorpb.FieldDescriptorProto_TYPE_BOOL {
+		return ListType(TupleType{keyType, valueType}), nil
+	}
+
+	return TypeRef{Name: "Dict.Dict", Args: []TypeExpr{keyType, valueType}}, nil
 }
 
-func MapType(messagePb *descriptorpb.DescriptorProto) Type {
-	keyField := messagePb.GetField()[0]
-	valueField := messagePb.GetField()[1]
+// mapKeyCodec - runtime functions parsing a map key from a JSON object key and printing it back,
+// empty for string and bool keys which have their own helpers
+func mapKeyCodec(r *Registry, keyField *descriptorpb.FieldDescriptorProto) (VariableName, VariableName) {
+	switch keyField.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING,
+		descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		return "", ""
+	case descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		if r.options.Int64 != Int64AsInt && isUnsigned64(keyField) {
+			return "uint64KeyFromString", "identity"
+		}
+		if r.options.Int64 != Int64AsInt {
+			return "int64KeyFromString", "identity"
+		}
+	}
 
-	return Type(fmt.Sprintf(
-		"Dict.Dict %s %s",
-		BasicFieldType(keyField),
-		BasicFieldType(valueField),
-	))
+	return "intKeyFromString", "String.fromInt"
 }
 
+// MapEncoder - encoder for a PB map field
 func MapEncoder(
+	r *Registry,
 	fieldPb *descriptorpb.FieldDescriptorProto,
 	messagePb *descriptorpb.DescriptorProto,
-) FieldEncoder {
-	valueField := messagePb.GetField()[1]
+) (Expr, error) {
+	keyField, valueField, err := mapEntryFields(messagePb)
+	if err != nil {
+		return nil, err
+	}
+
+	valueEncoder, err := BasicFieldEncoder(r, valueField)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyField.GetType() == descriptorpb.FieldDescriptorProto_TYPE_BOOL {
+		return Apply(VariableName("boolMapEntriesFieldEncoder"), fieldJSONName(fieldPb), valueEncoder, fieldValue(fieldPb)), nil
+	}
 
-	return FieldEncoder(fmt.Sprintf(
-		"mapEntriesFieldEncoder \"%s\" %s v.%s",
-		FieldJSONName(fieldPb),
-		BasicFieldEncoder(valueField),
-		FieldName(fieldPb.GetName()),
-	))
+	if _, keyToString := mapKeyCodec(r, keyField); keyToString != "" {
+		return Apply(VariableName("keyedMapEntriesFieldEncoder"), fieldJSONName(fieldPb), keyToString, valueEncoder, fieldValue(fieldPb)), nil
+	}
+
+	return Apply(VariableName("mapEntriesFieldEncoder"), fieldJSONName(fieldPb), valueEncoder, fieldValue(fieldPb)), nil
 }
 
+// MapDecoder - decoder for a PB map field
 func MapDecoder(
+	r *Registry,
 	fieldPb *descriptorpb.FieldDescriptorProto,
 	messagePb *descriptorpb.DescriptorProto,
-) FieldDecoder {
-	valueField := messagePb.GetField()[1]
+) (Expr, error) {
+	keyField, valueField, err := mapEntryFields(messagePb)
+	if err != nil {
+		return nil, err
+	}
 
-	return FieldDecoder(fmt.Sprintf(
-		"mapEntries \"%s\" %s",
-		FieldJSONName(fieldPb),
-		BasicFieldDecoder(valueField),
-	))
+	valueDecoder, err := BasicFieldDecoder(r, valueField)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyField.GetType() == descriptorpb.FieldDescriptorProto_TYPE_BOOL {
+		return Apply(r.decoderHelper("boolMapEntries"), fieldJSONName(fieldPb), valueDecoder), nil
+	}
+
+	if keyFromString, _ := mapKeyCodec(r, keyField); keyFromString != "" {
+		return Apply(r.decoderHelper("keyedMapEntries"), fieldJSONName(fieldPb), keyFromString, valueDecoder), nil
+	}
+
+	return Apply(r.decoderHelper("mapEntries"), fieldJSONName(fieldPb), valueDecoder), nil
 }
 
-func MaybeType(t Type) Type {
-	return Type(fmt.Sprintf("Maybe %s", t))
+// MaybeType - Elm type for an optional value
+func MaybeType(t TypeExpr) TypeExpr {
+	return TypeRef{Name: "Maybe", Args: []TypeExpr{t}}
 }
 
-func MaybeEncoder(pb *descriptorpb.FieldDescriptorProto) FieldEncoder {
-	return FieldEncoder(fmt.Sprintf(
-		"optionalEncoder \"%s\" %s v.%s",
-		FieldJSONName(pb),
-		BasicFieldEncoder(pb),
-		FieldName(pb.GetName()),
-	))
+// MaybeEncoder - encoder for an optional PB field
+func MaybeEncoder(r *Registry, pb *descriptorpb.FieldDescriptorProto) (Expr, error) {
+	encoder, err := BasicFieldEncoder(r, pb)
+	if err != nil {
+		return nil, err
+	}
+
+	return Apply(VariableName("optionalEncoder"), fieldJSONName(pb), encoder, fieldValue(pb)), nil
 }
 
-func MaybeDecoder(pb *descriptorpb.FieldDescriptorProto) FieldDecoder {
-	return FieldDecoder(fmt.Sprintf(
-		"optional \"%s\" %s",
-		FieldJSONName(pb),
-		BasicFieldDecoder(pb),
-	))
+// MaybeDecoder - decoder for an optional PB field
+func MaybeDecoder(r *Registry, pb *descriptorpb.FieldDescriptorProto) (Expr, error) {
+	decoder, err := BasicFieldDecoder(r, pb)
+	if err != nil {
+		return nil, err
+	}
+
+	return Apply(r.decoderHelper("optional"), fieldJSONName(pb), decoder), nil
+}
+
+// ListType - Elm type for a repeated value
+func ListType(t TypeExpr) TypeExpr {
+	return TypeRef{Name: "List", Args: []TypeExpr{t}}
+}
+
+// ListEncoder - encoder for a repeated PB field
+func ListEncoder(r *Registry, pb *descriptorpb.FieldDescriptorProto) (Expr, error) {
+	encoder, err := BasicFieldEncoder(r, pb)
+	if err != nil {
+		return nil, err
+	}
+
+	return Apply(VariableName("repeatedFieldEncoder"), fieldJSONName(pb), encoder, fieldValue(pb)), nil
 }
 
-func ListType(t Type) Type {
-	return Type(fmt.Sprintf("List %s", t))
+// ListDecoder - decoder for a repeated PB field
+func ListDecoder(r *Registry, pb *descriptorpb.FieldDescriptorProto) (Expr, error) {
+	decoder, err := BasicFieldDecoder(r, pb)
+	if err != nil {
+		return nil, err
+	}
+
+	return Apply(r.decoderHelper("repeated"), fieldJSONName(pb), decoder), nil
 }
 
-func ListEncoder(pb *descriptorpb.FieldDescriptorProto) FieldEncoder {
-	return FieldEncoder(fmt.Sprintf(
-		"repeatedFieldEncoder \"%s\" %s v.%s",
-		FieldJSONName(pb),
-		BasicFieldEncoder(pb),
-		FieldName(pb.GetName()),
-	))
+// recordType - record of the fields of a type alias, numbered after their PB field
+func (t TypeAlias) recordType() RecordType {
+	var result RecordType
+	for _, field := range t.Fields {
+		f := RecordFieldType{
+			Name:     field.Name,
+			Type:     field.Type,
+			Comments: field.Comment.Lines(),
+		}
+		if field.Number != 0 {
+			f.EOLComment = fmt.Sprint(field.Number)
+		}
+
+		result.Fields = append(result.Fields, f)
+	}
+
+	if t.Extendable {
+		result.Fields = append(result.Fields, RecordFieldType{Name: extensionsField, Type: Type("Extensions")})
+	}
+
+	return result
 }
 
-func ListDecoder(pb *descriptorpb.FieldDescriptorProto) FieldDecoder {
-	return FieldDecoder(fmt.Sprintf(
-		"repeated \"%s\" %s",
-		FieldJSONName(pb),
-		BasicFieldDecoder(pb),
-	))
+// encodedValue - pattern binding the record `v` of an encoded type alias
+func (t TypeAlias) encodedValue() Pattern {
+	if t.Opaque {
+		return ConstructorPattern{Name: VariantName(t.Name), Args: []Pattern{VariableName("v")}}
+	}
+
+	return VariableName("v")
 }
 
-func OneOfType(in string) Type {
-	return Type(appendUnderscoreToReservedKeywords(stringextras.UpperCamelCase(in)))
+// Declarations - type alias, its codecs and the accessors of an opaque type
+func (t TypeAlias) Declarations() []Declaration {
+	result := t.Comment.Declarations()
+	if t.Opaque {
+		result = append(result, CustomTypeDeclaration{
+			Doc:          t.Comment.Doc(),
+			Name:         t.Name,
+			Constructors: []Constructor{{Name: VariantName(t.Name), Args: []TypeExpr{t.Record()}}},
+		}, TypeAliasDeclaration{
+			Name: t.Record(),
+			Type: t.recordType(),
+		})
+	} else {
+		result = append(result, TypeAliasDeclaration{
+			Doc:  t.Comment.Doc(),
+			Name: t.Name,
+			Type: t.recordType(),
+		})
+	}
+
+	var decoders, encoders, binaryDecoders, binaryEncoders []Expr
+	for _, field := range t.Fields {
+		decoders = append(decoders, field.Decoder)
+		encoders = append(encoders, field.Encoder)
+		binaryDecoders = append(binaryDecoders, field.BinaryDecoder)
+		binaryEncoders = append(binaryEncoders, field.BinaryEncoder)
+	}
+
+	if t.Extendable {
+		// Extensions are kept as JSON values, binary decoding skips them.
+		decoders = append(decoders, VariableName("extensions"))
+		binaryDecoders = append(binaryDecoders, Apply(VariableName("PB.field"), Apply(VariableName("PB.decode"), VariableName("noExtensions"))))
+	}
+
+	if t.Opaque {
+		decoders = append(decoders, Apply(VariableName("JD.map"), VariantName(t.Name)))
+		binaryDecoders = append(binaryDecoders, Apply(VariableName("PB.map"), VariantName(t.Name)))
+	}
+
+	var fields Expr = List{Items: encoders, Multiline: true}
+	if t.Extendable {
+		fields = BinOp{
+			Left:  fields,
+			Op:    "++",
+			Right: Apply(VariableName("extensionFields"), Access{Record: "v", Field: extensionsField}),
+		}
+	}
+
+	result = append(result, ValueDeclaration{
+		Name:       t.Decoder,
+		Annotation: TypeRef{Name: "JD.Decoder", Args: []TypeExpr{t.Name}},
+		Body:       lazy("JD.lazy", Pipeline{Head: Apply(t.Decode, VariantName(t.Record())), Steps: decoders}),
+	}, ValueDeclaration{
+		Name:       t.Encoder,
+		Annotation: FunctionType{Args: []TypeExpr{t.Name}, Result: Type("JE.Value")},
+		Args:       []Pattern{t.encodedValue()},
+		Body: BinOp{
+			Left:  VariableName("JE.object"),
+			Op:    "<|",
+			Right: BinOp{Left: Apply(VariableName("List.filterMap"), VariableName("identity")), Op: "<|", Right: fields},
+		},
+	})
+
+	if t.BinaryDecoder != "" {
+		result = append(result, ValueDeclaration{
+			Name:       t.BinaryDecoder,
+			Annotation: TypeRef{Name: "PB.Decoder", Args: []TypeExpr{t.Name}},
+			Body:       lazy("PB.lazy", Pipeline{Head: Apply(VariableName("PB.decode"), VariantName(t.Record())), Steps: binaryDecoders}),
+		}, ValueDeclaration{
+			Name:       t.BinaryEncoder,
+			Annotation: FunctionType{Args: []TypeExpr{t.Name}, Result: Type("PB.Encoder")},
+			Args:       []Pattern{t.encodedValue()},
+			Body:       Apply(VariableName("PB.encode"), List{Items: binaryEncoders, Multiline: true}),
+		})
+	}
+
+	if t.Opaque {
+		result = append(result, t.accessors()...)
+	}
+
+	return result
 }
 
-// TypeAliasTemplate - defines templates for self contained type aliases
-func TypeAliasTemplate(t *template.Template) (*template.Template, error) {
-	return t.Parse(`
-{{- define "type-alias" -}}
-type alias {{ .Name }} =
-    { {{ range $i, $v := .Fields }}
-        {{- if $i }}, {{ end }}{{ .Name }} : {{ .Type }}{{ if .Number }} -- {{ .Number }}{{ end }}
-    {{ end }}}
+// accessors - constructor, getters and setters of an opaque type
+func (t TypeAlias) accessors() []Declaration {
+	var argTypes []TypeExpr
+	var args []Pattern
+	var fields []RecordField
+	for _, field := range t.Fields {
+		if field.Default != nil {
+			fields = append(fields, RecordField{Name: field.Name, Value: field.Default})
+			continue
+		}
+
+		arg := VariableName(fmt.Sprintf("%s_", field.Name))
+		argTypes = append(argTypes, field.Type)
+		args = append(args, arg)
+		fields = append(fields, RecordField{Name: field.Name, Value: arg})
+	}
+
+	if t.Extendable {
+		fields = append(fields, RecordField{Name: extensionsField, Value: VariableName("noExtensions")})
+	}
 
+	var annotation TypeExpr = t.Name
+	if len(argTypes) > 0 {
+		annotation = FunctionType{Args: argTypes, Result: t.Name}
+	}
 
-{{ .Decoder }} : JD.Decoder {{ .Name }}
-{{ .Decoder }} =
-    JD.lazy <| \_ -> decode {{ .Name }}{{ range .Fields }}
-        |> {{ .Decoder }}{{ end }}
+	result := []Declaration{ValueDeclaration{
+		Name:       t.Constructor,
+		Annotation: annotation,
+		Args:       args,
+		Body:       Apply(VariantName(t.Name), Record{Fields: fields, Multiline: true}),
+	}}
 
+	for _, field := range t.Fields {
+		result = append(result, t.getter(field.Getter, field.Name, field.Type), t.setter(field.Setter, field.Name, field.Type))
+	}
 
-{{ .Encoder }} : {{ .Name }} -> JE.Value
-{{ .Encoder }} v =
-    JE.object <| List.filterMap identity <|
-        [{{ range $i, $v := .Fields }}
-            {{- if $i }},{{ end }} ({{ .Encoder }})
-        {{ end }}]
-{{- end -}}
-`)
+	if t.Extendable {
+		result = append(result,
+			t.getter(t.ExtensionsGetter(), extensionsField, Type("Extensions")),
+			t.setter(t.ExtensionsSetter(), extensionsField, Type("Extensions")),
+		)
+	}
+
+	return result
+}
+
+func (t TypeAlias) getter(name VariableName, field VariableName, fieldType TypeExpr) Declaration {
+	return ValueDeclaration{
+		Name:       name,
+		Annotation: FunctionType{Args: []TypeExpr{t.Name}, Result: fieldType},
+		Args:       []Pattern{t.encodedValue()},
+		Body:       Access{Record: "v", Field: field},
+	}
+}
+
+func (t TypeAlias) setter(name VariableName, field VariableName, fieldType TypeExpr) Declaration {
+	return ValueDeclaration{
+		Name:       name,
+		Annotation: FunctionType{Args: []TypeExpr{fieldType, t.Name}, Result: t.Name},
+		Args:       []Pattern{VariableName("x"), t.encodedValue()},
+		Body: Apply(VariantName(t.Name), RecordUpdate{
+			Record: "v",
+			Fields: []RecordField{{Name: field, Value: VariableName("x")}},
+		}),
+	}
+}
+
+// lazy - decoder deferred with the given `lazy` helper, so recursive decoders can refer to each
+// other
+func lazy(helper VariableName, decoder Expr) Expr {
+	return BinOp{
+		Left:  helper,
+		Op:    "<|",
+		Right: Lambda{Params: []Pattern{wildcard}, Body: decoder},
+	}
+}
+
+// Declarations - wrapper custom type and its unwrapping function
+func (t WrapperType) Declarations() []Declaration {
+	return []Declaration{
+		CustomTypeDeclaration{
+			Name:         t.Name,
+			Constructors: []Constructor{{Name: VariantName(t.Name), Args: []TypeExpr{t.Type}}},
+		},
+		ValueDeclaration{
+			Name:       t.Unwrap,
+			Annotation: FunctionType{Args: []TypeExpr{t.Name}, Result: t.Type},
+			Args:       []Pattern{ConstructorPattern{Name: VariantName(t.Name), Args: []Pattern{VariableName("v")}}},
+			Body:       VariableName("v"),
+		},
+	}
 }
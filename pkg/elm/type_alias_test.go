@@ -0,0 +1,33 @@
+package elm
+
+import "testing"
+
+func TestWrapFieldErrors(t *testing.T) {
+	wrapper := WrapperType{Name: "Child", Unwrap: "unwrapChild"}
+	tests := []struct {
+		name  string
+		field TypeAliasField
+		err   string
+	}{
+		{"encoder", TypeAliasField{Encoder: VariableName("encoder")}, "no value to unwrap in field encoder elm.VariableName"},
+		{"encoder without arguments", TypeAliasField{Encoder: Call{Func: VariableName("encoder")}}, "no value to unwrap in field encoder elm.Call"},
+		{
+			"binary encoder",
+			TypeAliasField{Encoder: Apply(VariableName("encoder"), VariableName("v")), BinaryDecoder: VariableName("decoder"), BinaryEncoder: VariableName("encoder")},
+			"no value to unwrap in field encoder elm.VariableName",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := WrapField(test.field, wrapper)
+			if err == nil {
+				t.Fatal("WrapField succeeded")
+			}
+
+			if err.Error() != test.err {
+				t.Errorf("WrapField error = %q, want %q", err.Error(), test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+module Any exposing (Created, Created_LabelsEntry, Created_Source, Event, createdDecoder, createdEncoder, created_LabelsEntryDecoder, created_LabelsEntryEncoder, created_SourceDecoder, created_SourceEncoder, eventDecoder, eventEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: any.proto
+
+import Dict
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+
+
+type alias Event =
+    { id : String -- 1
+    , payload : Maybe Protobuf.Any -- 2
+    , details : List Protobuf.Any -- 3
+    }
+
+
+eventDecoder : JD.Decoder Event
+eventDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Event
+                |> required "id" JD.string ""
+                |> optional "payload" Protobuf.anyDecoder
+                |> repeated "details" Protobuf.anyDecoder
+
+
+eventEncoder : Event -> JE.Value
+eventEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "id" JE.string "" v.id
+            , optionalEncoder "payload" Protobuf.anyEncoder v.payload
+            , repeatedFieldEncoder "details" Protobuf.anyEncoder v.details
+            ]
+
+
+type alias Created =
+    { name : String -- 1
+    , labels : Dict.Dict String String -- 2
+    }
+
+
+createdDecoder : JD.Decoder Created
+createdDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Created
+                |> required "name" JD.string ""
+                |> mapEntries "labels" JD.string
+
+
+createdEncoder : Created -> JE.Value
+createdEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "name" JE.string "" v.name
+            , mapEntriesFieldEncoder "labels" JE.string v.labels
+            ]
+
+
+type alias Created_Source =
+    { url : String -- 1
+    }
+
+
+created_SourceDecoder : JD.Decoder Created_Source
+created_SourceDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Created_Source
+                |> required "url" JD.string ""
+
+
+created_SourceEncoder : Created_Source -> JE.Value
+created_SourceEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "url" JE.string "" v.url
+            ]
+
+
+type alias Created_LabelsEntry =
+    { key : String -- 1
+    , value : String -- 2
+    }
+
+
+created_LabelsEntryDecoder : JD.Decoder Created_LabelsEntry
+created_LabelsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Created_LabelsEntry
+                |> required "key" JD.string ""
+                |> required "value" JD.string ""
+
+
+created_LabelsEntryEncoder : Created_LabelsEntry -> JE.Value
+created_LabelsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.string "" v.key
+            , requiredFieldEncoder "value" JE.string "" v.value
+            ]
@@ -0,0 +1,67 @@
+module AnyRegistry exposing (Message(..), pack, unpack)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- type URL registry of the messages generated in the same run
+
+import Any
+import Json.Decode as JD
+import Other
+import Protobuf
+
+
+type Message
+    = Acme_Events_Event Any.Event
+    | Acme_Events_Created Any.Created
+    | Acme_Events_Created_Source Any.Created_Source
+    | Deleted Other.Deleted
+    | Unknown_ Protobuf.Any
+
+
+unpack : Protobuf.Any -> Message
+unpack any =
+    case Protobuf.anyTypeName any of
+        "acme.events.Event" ->
+            unpackAs Acme_Events_Event Any.eventDecoder any
+
+        "acme.events.Created" ->
+            unpackAs Acme_Events_Created Any.createdDecoder any
+
+        "acme.events.Created.Source" ->
+            unpackAs Acme_Events_Created_Source Any.created_SourceDecoder any
+
+        "Deleted" ->
+            unpackAs Deleted Other.deletedDecoder any
+
+        _ ->
+            Unknown_ any
+
+
+pack : Message -> Protobuf.Any
+pack message =
+    case message of
+        Acme_Events_Event v ->
+            Protobuf.pack "type.googleapis.com/acme.events.Event" Any.eventEncoder v
+
+        Acme_Events_Created v ->
+            Protobuf.pack "type.googleapis.com/acme.events.Created" Any.createdEncoder v
+
+        Acme_Events_Created_Source v ->
+            Protobuf.pack "type.googleapis.com/acme.events.Created.Source" Any.created_SourceEncoder v
+
+        Deleted v ->
+            Protobuf.pack "type.googleapis.com/Deleted" Other.deletedEncoder v
+
+        Unknown_ any ->
+            any
+
+
+unpackAs : (a -> Message) -> JD.Decoder a -> Protobuf.Any -> Message
+unpackAs variant decoder any =
+    case Protobuf.unpack decoder any of
+        Ok v ->
+            variant v
+
+        Err _ ->
+            Unknown_ any
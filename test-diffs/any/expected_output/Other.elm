@@ -0,0 +1,31 @@
+module Other exposing (Deleted, deletedDecoder, deletedEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: other.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+
+
+type alias Deleted =
+    { permanent : Bool -- 1
+    }
+
+
+deletedDecoder : JD.Decoder Deleted
+deletedDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Deleted
+                |> required "permanent" JD.bool False
+
+
+deletedEncoder : Deleted -> JE.Value
+deletedEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "permanent" JE.bool False v.permanent
+            ]
@@ -0,0 +1,519 @@
+module Binary exposing (Account, Account_Contact(..), Account_LimitsEntry, Account_PinnedEntry, Account_RanksEntry, Account_Tag, Scalars, Status(..), accountBinaryDecoder, accountBinaryEncoder, accountDecoder, accountEncoder, account_ContactBinaryDecoder, account_ContactBinaryEncoder, account_ContactDecoder, account_ContactEncoder, account_LimitsEntryBinaryDecoder, account_LimitsEntryBinaryEncoder, account_LimitsEntryDecoder, account_LimitsEntryEncoder, account_PinnedEntryBinaryDecoder, account_PinnedEntryBinaryEncoder, account_PinnedEntryDecoder, account_PinnedEntryEncoder, account_RanksEntryBinaryDecoder, account_RanksEntryBinaryEncoder, account_RanksEntryDecoder, account_RanksEntryEncoder, account_TagBinaryDecoder, account_TagBinaryEncoder, account_TagDecoder, account_TagEncoder, scalarsBinaryDecoder, scalarsBinaryEncoder, scalarsDecoder, scalarsEncoder, statusBinaryDecoder, statusBinaryEncoder, statusDecoder, statusDefault, statusEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: binary.proto
+
+import Dict
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+import Protobuf.Binary as PB
+
+
+type Status
+    = StatusUnspecified -- 0
+    | Active -- 1
+    | Suspended -- 2
+    | StatusUnrecognized_ Int
+
+
+statusDecoder : JD.Decoder Status
+statusDecoder =
+    let
+        lookup s =
+            case s of
+                "STATUS_UNSPECIFIED" ->
+                    StatusUnspecified
+
+                "ACTIVE" ->
+                    Active
+
+                "SUSPENDED" ->
+                    Suspended
+
+                _ ->
+                    StatusUnspecified
+
+        fromNumber n =
+            case n of
+                0 ->
+                    StatusUnspecified
+
+                1 ->
+                    Active
+
+                2 ->
+                    Suspended
+
+                _ ->
+                    StatusUnrecognized_ n
+    in
+    JD.oneOf [ JD.map lookup JD.string, JD.map fromNumber JD.int ]
+
+
+statusDefault : Status
+statusDefault =
+    StatusUnspecified
+
+
+statusEncoder : Status -> JE.Value
+statusEncoder v =
+    let
+        lookup s =
+            case s of
+                StatusUnspecified ->
+                    JE.string "STATUS_UNSPECIFIED"
+
+                Active ->
+                    JE.string "ACTIVE"
+
+                Suspended ->
+                    JE.string "SUSPENDED"
+
+                StatusUnrecognized_ n ->
+                    JE.int n
+    in
+    lookup v
+
+
+statusBinaryDecoder : Int -> Status
+statusBinaryDecoder v =
+    case v of
+        0 ->
+            StatusUnspecified
+
+        1 ->
+            Active
+
+        2 ->
+            Suspended
+
+        _ ->
+            StatusUnrecognized_ v
+
+
+statusBinaryEncoder : Status -> Int
+statusBinaryEncoder v =
+    case v of
+        StatusUnspecified ->
+            0
+
+        Active ->
+            1
+
+        Suspended ->
+            2
+
+        StatusUnrecognized_ n ->
+            n
+
+
+type alias Scalars =
+    { int32Field : Int -- 1
+    , int64Field : Int -- 2
+    , uint32Field : Int -- 3
+    , uint64Field : Int -- 4
+    , sint32Field : Int -- 5
+    , sint64Field : Int -- 6
+    , fixed32Field : Int -- 7
+    , fixed64Field : Int -- 8
+    , sfixed32Field : Int -- 9
+    , sfixed64Field : Int -- 10
+    , floatField : Float -- 11
+    , doubleField : Float -- 12
+    , boolField : Bool -- 13
+    , stringField : String -- 14
+    , bytesField : Bytes -- 15
+    }
+
+
+scalarsDecoder : JD.Decoder Scalars
+scalarsDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Scalars
+                |> required "int32Field" intDecoder 0
+                |> required "int64Field" intDecoder 0
+                |> required "uint32Field" intDecoder 0
+                |> required "uint64Field" intDecoder 0
+                |> required "sint32Field" intDecoder 0
+                |> required "sint64Field" intDecoder 0
+                |> required "fixed32Field" intDecoder 0
+                |> required "fixed64Field" intDecoder 0
+                |> required "sfixed32Field" intDecoder 0
+                |> required "sfixed64Field" intDecoder 0
+                |> required "floatField" JD.float 0.0
+                |> required "doubleField" JD.float 0.0
+                |> required "boolField" JD.bool False
+                |> required "stringField" JD.string ""
+                |> required "bytesField" bytesFieldDecoder []
+
+
+scalarsEncoder : Scalars -> JE.Value
+scalarsEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "int32Field" JE.int 0 v.int32Field
+            , requiredFieldEncoder "int64Field" numericStringEncoder 0 v.int64Field
+            , requiredFieldEncoder "uint32Field" JE.int 0 v.uint32Field
+            , requiredFieldEncoder "uint64Field" numericStringEncoder 0 v.uint64Field
+            , requiredFieldEncoder "sint32Field" JE.int 0 v.sint32Field
+            , requiredFieldEncoder "sint64Field" numericStringEncoder 0 v.sint64Field
+            , requiredFieldEncoder "fixed32Field" JE.int 0 v.fixed32Field
+            , requiredFieldEncoder "fixed64Field" numericStringEncoder 0 v.fixed64Field
+            , requiredFieldEncoder "sfixed32Field" JE.int 0 v.sfixed32Field
+            , requiredFieldEncoder "sfixed64Field" numericStringEncoder 0 v.sfixed64Field
+            , requiredFieldEncoder "floatField" JE.float 0.0 v.floatField
+            , requiredFieldEncoder "doubleField" JE.float 0.0 v.doubleField
+            , requiredFieldEncoder "boolField" JE.bool False v.boolField
+            , requiredFieldEncoder "stringField" JE.string "" v.stringField
+            , requiredFieldEncoder "bytesField" bytesFieldEncoder [] v.bytesField
+            ]
+
+
+scalarsBinaryDecoder : PB.Decoder Scalars
+scalarsBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Scalars
+                |> PB.required 1 PB.int32
+                |> PB.required 2 PB.int64
+                |> PB.required 3 PB.uint32
+                |> PB.required 4 PB.uint64
+                |> PB.required 5 PB.sint32
+                |> PB.required 6 PB.sint64
+                |> PB.required 7 PB.fixed32
+                |> PB.required 8 PB.fixed64
+                |> PB.required 9 PB.sfixed32
+                |> PB.required 10 PB.sfixed64
+                |> PB.required 11 PB.float
+                |> PB.required 12 PB.double
+                |> PB.required 13 PB.bool
+                |> PB.required 14 PB.string
+                |> PB.required 15 PB.bytes
+
+
+scalarsBinaryEncoder : Scalars -> PB.Encoder
+scalarsBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.int32 v.int32Field
+        , PB.requiredFieldEncoder 2 PB.int64 v.int64Field
+        , PB.requiredFieldEncoder 3 PB.uint32 v.uint32Field
+        , PB.requiredFieldEncoder 4 PB.uint64 v.uint64Field
+        , PB.requiredFieldEncoder 5 PB.sint32 v.sint32Field
+        , PB.requiredFieldEncoder 6 PB.sint64 v.sint64Field
+        , PB.requiredFieldEncoder 7 PB.fixed32 v.fixed32Field
+        , PB.requiredFieldEncoder 8 PB.fixed64 v.fixed64Field
+        , PB.requiredFieldEncoder 9 PB.sfixed32 v.sfixed32Field
+        , PB.requiredFieldEncoder 10 PB.sfixed64 v.sfixed64Field
+        , PB.requiredFieldEncoder 11 PB.float v.floatField
+        , PB.requiredFieldEncoder 12 PB.double v.doubleField
+        , PB.requiredFieldEncoder 13 PB.bool v.boolField
+        , PB.requiredFieldEncoder 14 PB.string v.stringField
+        , PB.requiredFieldEncoder 15 PB.bytes v.bytesField
+        ]
+
+
+type alias Account =
+    { name : String -- 1
+    , status : Status -- 2
+    , scores : List Int -- 3
+    , history : List Scalars -- 4
+    , limits : Dict.Dict String Int -- 5
+    , created : Maybe Timestamp -- 6
+    , nickname : Maybe String -- 7
+    , tags : List Account_Tag -- 11
+    , ranks : Dict.Dict Int String -- 12
+    , pinned : List ( Bool, Account_Tag ) -- 13
+    , contact : Account_Contact
+    , age : Maybe Int
+    }
+
+
+accountDecoder : JD.Decoder Account
+accountDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Account
+                |> required "name" JD.string ""
+                |> required "status" statusDecoder statusDefault
+                |> repeated "scores" intDecoder
+                |> repeated "history" scalarsDecoder
+                |> mapEntries "limits" intDecoder
+                |> optional "created" timestampDecoder
+                |> optional "nickname" stringValueDecoder
+                |> repeated "tags" account_TagDecoder
+                |> keyedMapEntries "ranks" intKeyFromString JD.string
+                |> boolMapEntries "pinned" account_TagDecoder
+                |> field account_ContactDecoder
+                |> optional "age" intDecoder
+
+
+accountEncoder : Account -> JE.Value
+accountEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "name" JE.string "" v.name
+            , requiredFieldEncoder "status" statusEncoder statusDefault v.status
+            , repeatedFieldEncoder "scores" JE.int v.scores
+            , repeatedFieldEncoder "history" scalarsEncoder v.history
+            , mapEntriesFieldEncoder "limits" numericStringEncoder v.limits
+            , optionalEncoder "created" timestampEncoder v.created
+            , optionalEncoder "nickname" stringValueEncoder v.nickname
+            , repeatedFieldEncoder "tags" account_TagEncoder v.tags
+            , keyedMapEntriesFieldEncoder "ranks" String.fromInt JE.string v.ranks
+            , boolMapEntriesFieldEncoder "pinned" account_TagEncoder v.pinned
+            , account_ContactEncoder v.contact
+            , optionalEncoder "age" JE.int v.age
+            ]
+
+
+accountBinaryDecoder : PB.Decoder Account
+accountBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Account
+                |> PB.required 1 PB.string
+                |> PB.required 2 (PB.enum statusBinaryDecoder statusBinaryEncoder)
+                |> PB.repeated 3 PB.int32
+                |> PB.repeated 4 (PB.embedded scalarsBinaryDecoder scalarsBinaryEncoder)
+                |> PB.mapEntries 5 PB.string PB.int64
+                |> PB.optional 6 PB.timestamp
+                |> PB.optional 7 (PB.wrapper PB.string)
+                |> PB.repeated 11 (PB.embedded account_TagBinaryDecoder account_TagBinaryEncoder)
+                |> PB.mapEntries 12 PB.int32 PB.string
+                |> PB.boolMapEntries 13 (PB.embedded account_TagBinaryDecoder account_TagBinaryEncoder)
+                |> PB.field account_ContactBinaryDecoder
+                |> PB.optional 10 PB.int32
+
+
+accountBinaryEncoder : Account -> PB.Encoder
+accountBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.string v.name
+        , PB.requiredFieldEncoder 2 (PB.enum statusBinaryDecoder statusBinaryEncoder) v.status
+        , PB.repeatedFieldEncoder 3 PB.int32 v.scores
+        , PB.repeatedFieldEncoder 4 (PB.embedded scalarsBinaryDecoder scalarsBinaryEncoder) v.history
+        , PB.mapEntriesFieldEncoder 5 PB.string PB.int64 v.limits
+        , PB.optionalEncoder 6 PB.timestamp v.created
+        , PB.optionalEncoder 7 (PB.wrapper PB.string) v.nickname
+        , PB.repeatedFieldEncoder 11 (PB.embedded account_TagBinaryDecoder account_TagBinaryEncoder) v.tags
+        , PB.mapEntriesFieldEncoder 12 PB.int32 PB.string v.ranks
+        , PB.boolMapEntriesFieldEncoder 13 (PB.embedded account_TagBinaryDecoder account_TagBinaryEncoder) v.pinned
+        , account_ContactBinaryEncoder v.contact
+        , PB.optionalEncoder 10 PB.int32 v.age
+        ]
+
+
+type Account_Contact
+    = Account_ContactUnspecified
+    | Account_Email String
+    | Account_Referrer Account
+
+
+account_ContactDecoder : JD.Decoder Account_Contact
+account_ContactDecoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map Account_Email (JD.field "email" JD.string)
+                , JD.map Account_Referrer (JD.field "referrer" accountDecoder)
+                , JD.succeed Account_ContactUnspecified
+                ]
+
+
+account_ContactEncoder : Account_Contact -> Maybe ( String, JE.Value )
+account_ContactEncoder v =
+    case v of
+        Account_ContactUnspecified ->
+            Nothing
+
+        Account_Email x ->
+            Just ( "email", JE.string x )
+
+        Account_Referrer x ->
+            Just ( "referrer", accountEncoder x )
+
+
+account_ContactBinaryDecoder : PB.Decoder Account_Contact
+account_ContactBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.oneOf Account_ContactUnspecified
+                [ PB.variant 8 Account_Email PB.string
+                , PB.variant 9 Account_Referrer (PB.embedded accountBinaryDecoder accountBinaryEncoder)
+                ]
+
+
+account_ContactBinaryEncoder : Account_Contact -> PB.FieldEncoder
+account_ContactBinaryEncoder v =
+    case v of
+        Account_ContactUnspecified ->
+            PB.noFieldEncoder
+
+        Account_Email x ->
+            PB.fieldEncoder 8 PB.string x
+
+        Account_Referrer x ->
+            PB.fieldEncoder 9 (PB.embedded accountBinaryDecoder accountBinaryEncoder) x
+
+
+type alias Account_LimitsEntry =
+    { key : String -- 1
+    , value : Int -- 2
+    }
+
+
+account_LimitsEntryDecoder : JD.Decoder Account_LimitsEntry
+account_LimitsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Account_LimitsEntry
+                |> required "key" JD.string ""
+                |> required "value" intDecoder 0
+
+
+account_LimitsEntryEncoder : Account_LimitsEntry -> JE.Value
+account_LimitsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.string "" v.key
+            , requiredFieldEncoder "value" numericStringEncoder 0 v.value
+            ]
+
+
+account_LimitsEntryBinaryDecoder : PB.Decoder Account_LimitsEntry
+account_LimitsEntryBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Account_LimitsEntry
+                |> PB.required 1 PB.string
+                |> PB.required 2 PB.int64
+
+
+account_LimitsEntryBinaryEncoder : Account_LimitsEntry -> PB.Encoder
+account_LimitsEntryBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.string v.key
+        , PB.requiredFieldEncoder 2 PB.int64 v.value
+        ]
+
+
+type alias Account_Tag =
+    { label : String -- 1
+    }
+
+
+account_TagDecoder : JD.Decoder Account_Tag
+account_TagDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Account_Tag
+                |> required "label" JD.string ""
+
+
+account_TagEncoder : Account_Tag -> JE.Value
+account_TagEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "label" JE.string "" v.label
+            ]
+
+
+account_TagBinaryDecoder : PB.Decoder Account_Tag
+account_TagBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Account_Tag
+                |> PB.required 1 PB.string
+
+
+account_TagBinaryEncoder : Account_Tag -> PB.Encoder
+account_TagBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.string v.label
+        ]
+
+
+type alias Account_RanksEntry =
+    { key : Int -- 1
+    , value : String -- 2
+    }
+
+
+account_RanksEntryDecoder : JD.Decoder Account_RanksEntry
+account_RanksEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Account_RanksEntry
+                |> required "key" intDecoder 0
+                |> required "value" JD.string ""
+
+
+account_RanksEntryEncoder : Account_RanksEntry -> JE.Value
+account_RanksEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.int 0 v.key
+            , requiredFieldEncoder "value" JE.string "" v.value
+            ]
+
+
+account_RanksEntryBinaryDecoder : PB.Decoder Account_RanksEntry
+account_RanksEntryBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Account_RanksEntry
+                |> PB.required 1 PB.int32
+                |> PB.required 2 PB.string
+
+
+account_RanksEntryBinaryEncoder : Account_RanksEntry -> PB.Encoder
+account_RanksEntryBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.int32 v.key
+        , PB.requiredFieldEncoder 2 PB.string v.value
+        ]
+
+
+type alias Account_PinnedEntry =
+    { key : Bool -- 1
+    , value : Maybe Account_Tag -- 2
+    }
+
+
+account_PinnedEntryDecoder : JD.Decoder Account_PinnedEntry
+account_PinnedEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Account_PinnedEntry
+                |> required "key" JD.bool False
+                |> optional "value" account_TagDecoder
+
+
+account_PinnedEntryEncoder : Account_PinnedEntry -> JE.Value
+account_PinnedEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.bool False v.key
+            , optionalEncoder "value" account_TagEncoder v.value
+            ]
+
+
+account_PinnedEntryBinaryDecoder : PB.Decoder Account_PinnedEntry
+account_PinnedEntryBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Account_PinnedEntry
+                |> PB.required 1 PB.bool
+                |> PB.optional 2 (PB.embedded account_TagBinaryDecoder account_TagBinaryEncoder)
+
+
+account_PinnedEntryBinaryEncoder : Account_PinnedEntry -> PB.Encoder
+account_PinnedEntryBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.bool v.key
+        , PB.optionalEncoder 2 (PB.embedded account_TagBinaryDecoder account_TagBinaryEncoder) v.value
+        ]
@@ -0,0 +1,101 @@
+module Bytes exposing (Blob, Blob_AttachmentsEntry, Blob_Payload(..), blobDecoder, blobEncoder, blob_AttachmentsEntryDecoder, blob_AttachmentsEntryEncoder, blob_PayloadDecoder, blob_PayloadEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: bytes.proto
+
+import Dict
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+
+
+type alias Blob =
+    { data : Bytes -- 1
+    , chunks : List Bytes -- 2
+    , attachments : Dict.Dict String Bytes -- 4
+    , wrapped : Maybe Bytes -- 5
+    , payload : Blob_Payload
+    , checksum : Maybe Bytes
+    }
+
+
+blobDecoder : JD.Decoder Blob
+blobDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Blob
+                |> required "data" bytesFieldDecoder []
+                |> repeated "chunks" bytesFieldDecoder
+                |> mapEntries "attachments" bytesFieldDecoder
+                |> optional "wrapped" bytesValueDecoder
+                |> field blob_PayloadDecoder
+                |> optional "checksum" bytesFieldDecoder
+
+
+blobEncoder : Blob -> JE.Value
+blobEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "data" bytesFieldEncoder [] v.data
+            , repeatedFieldEncoder "chunks" bytesFieldEncoder v.chunks
+            , mapEntriesFieldEncoder "attachments" bytesFieldEncoder v.attachments
+            , optionalEncoder "wrapped" bytesValueEncoder v.wrapped
+            , blob_PayloadEncoder v.payload
+            , optionalEncoder "checksum" bytesFieldEncoder v.checksum
+            ]
+
+
+type Blob_Payload
+    = Blob_PayloadUnspecified
+    | Blob_Raw Bytes
+    | Blob_Text String
+
+
+blob_PayloadDecoder : JD.Decoder Blob_Payload
+blob_PayloadDecoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map Blob_Raw (JD.field "raw" bytesFieldDecoder)
+                , JD.map Blob_Text (JD.field "text" JD.string)
+                , JD.succeed Blob_PayloadUnspecified
+                ]
+
+
+blob_PayloadEncoder : Blob_Payload -> Maybe ( String, JE.Value )
+blob_PayloadEncoder v =
+    case v of
+        Blob_PayloadUnspecified ->
+            Nothing
+
+        Blob_Raw x ->
+            Just ( "raw", bytesFieldEncoder x )
+
+        Blob_Text x ->
+            Just ( "text", JE.string x )
+
+
+type alias Blob_AttachmentsEntry =
+    { key : String -- 1
+    , value : Bytes -- 2
+    }
+
+
+blob_AttachmentsEntryDecoder : JD.Decoder Blob_AttachmentsEntry
+blob_AttachmentsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Blob_AttachmentsEntry
+                |> required "key" JD.string ""
+                |> required "value" bytesFieldDecoder []
+
+
+blob_AttachmentsEntryEncoder : Blob_AttachmentsEntry -> JE.Value
+blob_AttachmentsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.string "" v.key
+            , requiredFieldEncoder "value" bytesFieldEncoder [] v.value
+            ]
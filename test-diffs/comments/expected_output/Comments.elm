@@ -0,0 +1,182 @@
+module Comments exposing (Account, Account_Contact(..), Status(..), Uncommented, accountDecoder, accountEncoder, account_ContactDecoder, account_ContactEncoder, statusDecoder, statusDefault, statusEncoder, uncommentedDecoder, uncommentedEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: comments.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+
+
+{-| Status of an account, Elm comment delimiters { - and - } are escaped in docs.
+-}
+type Status
+    = -- Not set.
+      StatusUnspecified -- 0
+      -- In use.
+    | Active -- 1
+    | StatusUnrecognized_ Int
+
+
+statusDecoder : JD.Decoder Status
+statusDecoder =
+    let
+        lookup s =
+            case s of
+                "STATUS_UNSPECIFIED" ->
+                    StatusUnspecified
+
+                "ACTIVE" ->
+                    Active
+
+                _ ->
+                    StatusUnspecified
+
+        fromNumber n =
+            case n of
+                0 ->
+                    StatusUnspecified
+
+                1 ->
+                    Active
+
+                _ ->
+                    StatusUnrecognized_ n
+    in
+    JD.oneOf [ JD.map lookup JD.string, JD.map fromNumber JD.int ]
+
+
+statusDefault : Status
+statusDefault =
+    StatusUnspecified
+
+
+statusEncoder : Status -> JE.Value
+statusEncoder v =
+    let
+        lookup s =
+            case s of
+                StatusUnspecified ->
+                    JE.string "STATUS_UNSPECIFIED"
+
+                Active ->
+                    JE.string "ACTIVE"
+
+                StatusUnrecognized_ n ->
+                    JE.int n
+    in
+    lookup v
+
+
+
+-- Detached comment about the file layout.
+
+
+{-| A user account.
+
+Indented lines keep their indentation:
+  - relative to the comment
+-}
+type alias Account =
+    { -- Unique identifier.
+      id : String -- 1
+
+    -- Display name, trailing.
+    , name : String -- 2
+
+    -- Block comment
+    -- over several lines.
+    , emails : List String -- 3
+
+    -- Elm comment delimiters {- and -} are kept in line comments.
+    , status : Status -- 7
+    , contact : Account_Contact
+
+    -- Before a detached comment.
+    --
+    -- Proto3 optional field.
+    , age : Maybe Int
+    }
+
+
+accountDecoder : JD.Decoder Account
+accountDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Account
+                |> required "id" JD.string ""
+                |> required "name" JD.string ""
+                |> repeated "emails" JD.string
+                |> required "status" statusDecoder statusDefault
+                |> field account_ContactDecoder
+                |> optional "age" intDecoder
+
+
+accountEncoder : Account -> JE.Value
+accountEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "id" JE.string "" v.id
+            , requiredFieldEncoder "name" JE.string "" v.name
+            , repeatedFieldEncoder "emails" JE.string v.emails
+            , requiredFieldEncoder "status" statusEncoder statusDefault v.status
+            , account_ContactEncoder v.contact
+            , optionalEncoder "age" JE.int v.age
+            ]
+
+
+{-| How the account is reached.
+-}
+type Account_Contact
+    = Account_ContactUnspecified
+      -- Phone number.
+    | Account_Phone String
+      -- Postal address.
+    | Account_Address String
+
+
+account_ContactDecoder : JD.Decoder Account_Contact
+account_ContactDecoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map Account_Phone (JD.field "phone" JD.string)
+                , JD.map Account_Address (JD.field "address" JD.string)
+                , JD.succeed Account_ContactUnspecified
+                ]
+
+
+account_ContactEncoder : Account_Contact -> Maybe ( String, JE.Value )
+account_ContactEncoder v =
+    case v of
+        Account_ContactUnspecified ->
+            Nothing
+
+        Account_Phone x ->
+            Just ( "phone", JE.string x )
+
+        Account_Address x ->
+            Just ( "address", JE.string x )
+
+
+type alias Uncommented =
+    { count : Int -- 1
+    }
+
+
+uncommentedDecoder : JD.Decoder Uncommented
+uncommentedDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Uncommented
+                |> required "count" intDecoder 0
+
+
+uncommentedEncoder : Uncommented -> JE.Value
+uncommentedEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "count" JE.int 0 v.count
+            ]
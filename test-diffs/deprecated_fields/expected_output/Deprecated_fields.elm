@@ -1,22 +1,19 @@
-module Deprecated_fields exposing (..)
+module Deprecated_fields exposing (Bar, EnumBar(..), barDecoder, barEncoder, enumBarDecoder, enumBarDefault, enumBarEncoder)
 
 -- DO NOT EDIT
 -- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
 -- https://github.com/tiziano88/elm-protobuf
 -- source file: deprecated_fields.proto
 
-import Protobuf exposing (..)
-
 import Json.Decode as JD
 import Json.Encode as JE
-
-
-uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+import Protobuf exposing (..)
 
 
 type EnumBar
     = EnumbarValueDefault -- 0
     | EnumbarValue1 -- 1
+    | EnumBarUnrecognized_ Int
 
 
 enumBarDecoder : JD.Decoder EnumBar
@@ -32,12 +29,24 @@ enumBarDecoder =
 
                 _ ->
                     EnumbarValueDefault
+
+        fromNumber n =
+            case n of
+                0 ->
+                    EnumbarValueDefault
+
+                1 ->
+                    EnumbarValue1
+
+                _ ->
+                    EnumBarUnrecognized_ n
     in
-        JD.map lookup JD.string
+    JD.oneOf [ JD.map lookup JD.string, JD.map fromNumber JD.int ]
 
 
 enumBarDefault : EnumBar
-enumBarDefault = EnumbarValueDefault
+enumBarDefault =
+    EnumbarValueDefault
 
 
 enumBarEncoder : EnumBar -> JE.Value
@@ -46,13 +55,15 @@ enumBarEncoder v =
         lookup s =
             case s of
                 EnumbarValueDefault ->
-                    "ENUMBAR_VALUE_DEFAULT"
+                    JE.string "ENUMBAR_VALUE_DEFAULT"
 
                 EnumbarValue1 ->
-                    "ENUMBAR_VALUE_1"
+                    JE.string "ENUMBAR_VALUE_1"
 
+                EnumBarUnrecognized_ n ->
+                    JE.int n
     in
-        JE.string <| lookup v
+    lookup v
 
 
 type alias Bar =
@@ -62,12 +73,15 @@ type alias Bar =
 
 barDecoder : JD.Decoder Bar
 barDecoder =
-    JD.lazy <| \_ -> decode Bar
-        |> required "field" JD.bool False
+    JD.lazy <|
+        \_ ->
+            decode Bar
+                |> required "field" JD.bool False
 
 
 barEncoder : Bar -> JE.Value
 barEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "field" JE.bool False v.field)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "field" JE.bool False v.field
+            ]
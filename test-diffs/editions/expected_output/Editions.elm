@@ -0,0 +1,316 @@
+module Editions exposing (Colour(..), Item, Item_Settings, Size(..), colourBinaryDecoder, colourBinaryEncoder, colourDecoder, colourDefault, colourEncoder, itemBinaryDecoder, itemBinaryEncoder, itemDecoder, itemEncoder, item_SettingsBinaryDecoder, item_SettingsBinaryEncoder, item_SettingsDecoder, item_SettingsEncoder, sizeBinaryDecoder, sizeBinaryEncoder, sizeDecoder, sizeDefault, sizeEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: editions.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+import Protobuf.Binary as PB
+
+
+type Colour
+    = ColourUnspecified -- 0
+    | Red -- 1
+    | Green -- 2
+    | ColourUnrecognized_ Int
+
+
+colourDecoder : JD.Decoder Colour
+colourDecoder =
+    let
+        lookup s =
+            case s of
+                "COLOUR_UNSPECIFIED" ->
+                    ColourUnspecified
+
+                "RED" ->
+                    Red
+
+                "GREEN" ->
+                    Green
+
+                _ ->
+                    ColourUnspecified
+
+        fromNumber n =
+            case n of
+                0 ->
+                    ColourUnspecified
+
+                1 ->
+                    Red
+
+                2 ->
+                    Green
+
+                _ ->
+                    ColourUnrecognized_ n
+    in
+    JD.oneOf [ JD.map lookup JD.string, JD.map fromNumber JD.int ]
+
+
+colourDefault : Colour
+colourDefault =
+    ColourUnspecified
+
+
+colourEncoder : Colour -> JE.Value
+colourEncoder v =
+    let
+        lookup s =
+            case s of
+                ColourUnspecified ->
+                    JE.string "COLOUR_UNSPECIFIED"
+
+                Red ->
+                    JE.string "RED"
+
+                Green ->
+                    JE.string "GREEN"
+
+                ColourUnrecognized_ n ->
+                    JE.int n
+    in
+    lookup v
+
+
+colourBinaryDecoder : Int -> Colour
+colourBinaryDecoder v =
+    case v of
+        0 ->
+            ColourUnspecified
+
+        1 ->
+            Red
+
+        2 ->
+            Green
+
+        _ ->
+            ColourUnrecognized_ v
+
+
+colourBinaryEncoder : Colour -> Int
+colourBinaryEncoder v =
+    case v of
+        ColourUnspecified ->
+            0
+
+        Red ->
+            1
+
+        Green ->
+            2
+
+        ColourUnrecognized_ n ->
+            n
+
+
+type Size
+    = Small -- 1
+    | Large -- 2
+
+
+sizeDecoder : JD.Decoder Size
+sizeDecoder =
+    let
+        lookup s =
+            case s of
+                "SMALL" ->
+                    JD.succeed Small
+
+                "LARGE" ->
+                    JD.succeed Large
+
+                _ ->
+                    JD.fail ("unknown value \"" ++ s ++ "\"")
+
+        fromNumber n =
+            case n of
+                1 ->
+                    JD.succeed Small
+
+                2 ->
+                    JD.succeed Large
+
+                _ ->
+                    JD.fail ("unknown value " ++ String.fromInt n)
+    in
+    JD.oneOf [ JD.andThen lookup JD.string, JD.andThen fromNumber JD.int ]
+
+
+sizeDefault : Size
+sizeDefault =
+    Small
+
+
+sizeEncoder : Size -> JE.Value
+sizeEncoder v =
+    let
+        lookup s =
+            case s of
+                Small ->
+                    JE.string "SMALL"
+
+                Large ->
+                    JE.string "LARGE"
+    in
+    lookup v
+
+
+sizeBinaryDecoder : Int -> Maybe Size
+sizeBinaryDecoder v =
+    case v of
+        1 ->
+            Just Small
+
+        2 ->
+            Just Large
+
+        _ ->
+            Nothing
+
+
+sizeBinaryEncoder : Size -> Int
+sizeBinaryEncoder v =
+    case v of
+        Small ->
+            1
+
+        Large ->
+            2
+
+
+type alias Item =
+    { count : Maybe Int -- 1
+    , name : String -- 2
+    , limit : Int -- 3
+    , id : String -- 4
+    , packed : List Int -- 5
+    , expanded : List Int -- 6
+    , colour : Maybe Colour -- 7
+    , size : Size -- 8
+    , settings : Maybe Item_Settings -- 9
+    , delimited : Maybe Item_Settings -- 10
+    , history : List Item_Settings -- 11
+    }
+
+
+itemDecoder : JD.Decoder Item
+itemDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Item
+                |> optional "count" intDecoder
+                |> required "name" JD.string ""
+                |> required "limit" intDecoder 10
+                |> mandatory "id" JD.string
+                |> repeated "packed" intDecoder
+                |> repeated "expanded" intDecoder
+                |> optional "colour" colourDecoder
+                |> required "size" sizeDecoder Large
+                |> optional "settings" item_SettingsDecoder
+                |> optional "delimited" item_SettingsDecoder
+                |> repeated "history" item_SettingsDecoder
+
+
+itemEncoder : Item -> JE.Value
+itemEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "count" JE.int v.count
+            , requiredFieldEncoder "name" JE.string "" v.name
+            , requiredFieldEncoder "limit" JE.int 10 v.limit
+            , mandatoryFieldEncoder "id" JE.string v.id
+            , repeatedFieldEncoder "packed" JE.int v.packed
+            , repeatedFieldEncoder "expanded" JE.int v.expanded
+            , optionalEncoder "colour" colourEncoder v.colour
+            , requiredFieldEncoder "size" sizeEncoder Large v.size
+            , optionalEncoder "settings" item_SettingsEncoder v.settings
+            , optionalEncoder "delimited" item_SettingsEncoder v.delimited
+            , repeatedFieldEncoder "history" item_SettingsEncoder v.history
+            ]
+
+
+itemBinaryDecoder : PB.Decoder Item
+itemBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Item
+                |> PB.optional 1 PB.int32
+                |> PB.required 2 PB.string
+                |> PB.defaulted 3 PB.int32 10
+                |> PB.mandatory 4 PB.string
+                |> PB.repeated 5 PB.int32
+                |> PB.repeated 6 PB.int32
+                |> PB.optional 7 (PB.enum colourBinaryDecoder colourBinaryEncoder)
+                |> PB.defaulted 8 (PB.closedEnum sizeBinaryDecoder sizeBinaryEncoder) Large
+                |> PB.optional 9 (PB.embedded item_SettingsBinaryDecoder item_SettingsBinaryEncoder)
+                |> PB.optional 10 (PB.group item_SettingsBinaryDecoder item_SettingsBinaryEncoder)
+                |> PB.repeated 11 (PB.group item_SettingsBinaryDecoder item_SettingsBinaryEncoder)
+
+
+itemBinaryEncoder : Item -> PB.Encoder
+itemBinaryEncoder v =
+    PB.encode
+        [ PB.optionalEncoder 1 PB.int32 v.count
+        , PB.requiredFieldEncoder 2 PB.string v.name
+        , PB.defaultedFieldEncoder 3 PB.int32 10 v.limit
+        , PB.mandatoryFieldEncoder 4 PB.string v.id
+        , PB.repeatedFieldEncoder 5 PB.int32 v.packed
+        , PB.expandedFieldEncoder 6 PB.int32 v.expanded
+        , PB.optionalEncoder 7 (PB.enum colourBinaryDecoder colourBinaryEncoder) v.colour
+        , PB.defaultedFieldEncoder 8 (PB.closedEnum sizeBinaryDecoder sizeBinaryEncoder) Large v.size
+        , PB.optionalEncoder 9 (PB.embedded item_SettingsBinaryDecoder item_SettingsBinaryEncoder) v.settings
+        , PB.optionalEncoder 10 (PB.group item_SettingsBinaryDecoder item_SettingsBinaryEncoder) v.delimited
+        , PB.repeatedFieldEncoder 11 (PB.group item_SettingsBinaryDecoder item_SettingsBinaryEncoder) v.history
+        ]
+
+
+type alias Item_Settings =
+    { enabled : Bool -- 1
+    , colour : Colour -- 2
+    , label : Maybe String -- 3
+    }
+
+
+item_SettingsDecoder : JD.Decoder Item_Settings
+item_SettingsDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Item_Settings
+                |> required "enabled" JD.bool False
+                |> required "colour" colourDecoder colourDefault
+                |> optional "label" JD.string
+
+
+item_SettingsEncoder : Item_Settings -> JE.Value
+item_SettingsEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "enabled" JE.bool False v.enabled
+            , requiredFieldEncoder "colour" colourEncoder colourDefault v.colour
+            , optionalEncoder "label" JE.string v.label
+            ]
+
+
+item_SettingsBinaryDecoder : PB.Decoder Item_Settings
+item_SettingsBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Item_Settings
+                |> PB.required 1 PB.bool
+                |> PB.required 2 (PB.enum colourBinaryDecoder colourBinaryEncoder)
+                |> PB.optional 3 PB.string
+
+
+item_SettingsBinaryEncoder : Item_Settings -> PB.Encoder
+item_SettingsBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.bool v.enabled
+        , PB.requiredFieldEncoder 2 (PB.enum colourBinaryDecoder colourBinaryEncoder) v.colour
+        , PB.optionalEncoder 3 PB.string v.label
+        ]
@@ -0,0 +1,57 @@
+module Implicit exposing (Counter, counterBinaryDecoder, counterBinaryEncoder, counterDecoder, counterEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: implicit.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+import Protobuf.Binary as PB
+
+
+type alias Counter =
+    { total : Int -- 1
+    , label : Maybe String -- 2
+    , steps : List Int -- 3
+    }
+
+
+counterDecoder : JD.Decoder Counter
+counterDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Counter
+                |> required "total" intDecoder 0
+                |> optional "label" JD.string
+                |> repeated "steps" intDecoder
+
+
+counterEncoder : Counter -> JE.Value
+counterEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "total" numericStringEncoder 0 v.total
+            , optionalEncoder "label" JE.string v.label
+            , repeatedFieldEncoder "steps" JE.int v.steps
+            ]
+
+
+counterBinaryDecoder : PB.Decoder Counter
+counterBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Counter
+                |> PB.required 1 PB.int64
+                |> PB.optional 2 PB.string
+                |> PB.repeated 3 PB.uint32
+
+
+counterBinaryEncoder : Counter -> PB.Encoder
+counterBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.int64 v.total
+        , PB.optionalEncoder 2 PB.string v.label
+        , PB.expandedFieldEncoder 3 PB.uint32 v.steps
+        ]
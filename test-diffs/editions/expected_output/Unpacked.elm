@@ -0,0 +1,52 @@
+module Unpacked exposing (Samples, samplesBinaryDecoder, samplesBinaryEncoder, samplesDecoder, samplesEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: unpacked.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+import Protobuf.Binary as PB
+
+
+type alias Samples =
+    { packed : List Int -- 1
+    , unpacked : List Int -- 2
+    }
+
+
+samplesDecoder : JD.Decoder Samples
+samplesDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Samples
+                |> repeated "packed" intDecoder
+                |> repeated "unpacked" intDecoder
+
+
+samplesEncoder : Samples -> JE.Value
+samplesEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ repeatedFieldEncoder "packed" JE.int v.packed
+            , repeatedFieldEncoder "unpacked" JE.int v.unpacked
+            ]
+
+
+samplesBinaryDecoder : PB.Decoder Samples
+samplesBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Samples
+                |> PB.repeated 1 PB.int32
+                |> PB.repeated 2 PB.int32
+
+
+samplesBinaryEncoder : Samples -> PB.Encoder
+samplesBinaryEncoder v =
+    PB.encode
+        [ PB.repeatedFieldEncoder 1 PB.int32 v.packed
+        , PB.expandedFieldEncoder 2 PB.int32 v.unpacked
+        ]
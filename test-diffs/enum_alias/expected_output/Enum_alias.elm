@@ -0,0 +1,264 @@
+module Enum_alias exposing (Job, Job_Kind(..), Status(..), done, finished, jobBinaryDecoder, jobBinaryEncoder, jobDecoder, jobEncoder, job_KindBinaryDecoder, job_KindBinaryEncoder, job_KindDecoder, job_KindDefault, job_KindEncoder, job_Offline, running, statusBinaryDecoder, statusBinaryEncoder, statusDecoder, statusDefault, statusEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: enum_alias.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+import Protobuf.Binary as PB
+
+
+type Status
+    = StatusUnspecified -- 0
+    | Started -- 1
+    | Stopped -- 2
+    | StatusUnrecognized_ Int
+
+
+statusDecoder : JD.Decoder Status
+statusDecoder =
+    let
+        lookup s =
+            case s of
+                "STATUS_UNSPECIFIED" ->
+                    StatusUnspecified
+
+                "STARTED" ->
+                    Started
+
+                "RUNNING" ->
+                    Started
+
+                "STOPPED" ->
+                    Stopped
+
+                "DONE" ->
+                    Stopped
+
+                "FINISHED" ->
+                    Stopped
+
+                _ ->
+                    StatusUnspecified
+
+        fromNumber n =
+            case n of
+                0 ->
+                    StatusUnspecified
+
+                1 ->
+                    Started
+
+                2 ->
+                    Stopped
+
+                _ ->
+                    StatusUnrecognized_ n
+    in
+    JD.oneOf [ JD.map lookup JD.string, JD.map fromNumber JD.int ]
+
+
+statusDefault : Status
+statusDefault =
+    StatusUnspecified
+
+
+running : Status
+running =
+    Started
+
+
+done : Status
+done =
+    Stopped
+
+
+finished : Status
+finished =
+    Stopped
+
+
+statusEncoder : Status -> JE.Value
+statusEncoder v =
+    let
+        lookup s =
+            case s of
+                StatusUnspecified ->
+                    JE.string "STATUS_UNSPECIFIED"
+
+                Started ->
+                    JE.string "STARTED"
+
+                Stopped ->
+                    JE.string "STOPPED"
+
+                StatusUnrecognized_ n ->
+                    JE.int n
+    in
+    lookup v
+
+
+statusBinaryDecoder : Int -> Status
+statusBinaryDecoder v =
+    case v of
+        0 ->
+            StatusUnspecified
+
+        1 ->
+            Started
+
+        2 ->
+            Stopped
+
+        _ ->
+            StatusUnrecognized_ v
+
+
+statusBinaryEncoder : Status -> Int
+statusBinaryEncoder v =
+    case v of
+        StatusUnspecified ->
+            0
+
+        Started ->
+            1
+
+        Stopped ->
+            2
+
+        StatusUnrecognized_ n ->
+            n
+
+
+type alias Job =
+    { status : Status -- 1
+    , kind : Job_Kind -- 2
+    }
+
+
+jobDecoder : JD.Decoder Job
+jobDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Job
+                |> required "status" statusDecoder statusDefault
+                |> required "kind" job_KindDecoder job_KindDefault
+
+
+jobEncoder : Job -> JE.Value
+jobEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "status" statusEncoder statusDefault v.status
+            , requiredFieldEncoder "kind" job_KindEncoder job_KindDefault v.kind
+            ]
+
+
+jobBinaryDecoder : PB.Decoder Job
+jobBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Job
+                |> PB.required 1 (PB.enum statusBinaryDecoder statusBinaryEncoder)
+                |> PB.required 2 (PB.enum job_KindBinaryDecoder job_KindBinaryEncoder)
+
+
+jobBinaryEncoder : Job -> PB.Encoder
+jobBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 (PB.enum statusBinaryDecoder statusBinaryEncoder) v.status
+        , PB.requiredFieldEncoder 2 (PB.enum job_KindBinaryDecoder job_KindBinaryEncoder) v.kind
+        ]
+
+
+type Job_Kind
+    = Job_KindUnspecified -- 0
+    | Job_Batch -- 1
+    | Job_KindUnrecognized_ Int
+
+
+job_KindDecoder : JD.Decoder Job_Kind
+job_KindDecoder =
+    let
+        lookup s =
+            case s of
+                "KIND_UNSPECIFIED" ->
+                    Job_KindUnspecified
+
+                "BATCH" ->
+                    Job_Batch
+
+                "OFFLINE" ->
+                    Job_Batch
+
+                _ ->
+                    Job_KindUnspecified
+
+        fromNumber n =
+            case n of
+                0 ->
+                    Job_KindUnspecified
+
+                1 ->
+                    Job_Batch
+
+                _ ->
+                    Job_KindUnrecognized_ n
+    in
+    JD.oneOf [ JD.map lookup JD.string, JD.map fromNumber JD.int ]
+
+
+job_KindDefault : Job_Kind
+job_KindDefault =
+    Job_KindUnspecified
+
+
+job_Offline : Job_Kind
+job_Offline =
+    Job_Batch
+
+
+job_KindEncoder : Job_Kind -> JE.Value
+job_KindEncoder v =
+    let
+        lookup s =
+            case s of
+                Job_KindUnspecified ->
+                    JE.string "KIND_UNSPECIFIED"
+
+                Job_Batch ->
+                    JE.string "BATCH"
+
+                Job_KindUnrecognized_ n ->
+                    JE.int n
+    in
+    lookup v
+
+
+job_KindBinaryDecoder : Int -> Job_Kind
+job_KindBinaryDecoder v =
+    case v of
+        0 ->
+            Job_KindUnspecified
+
+        1 ->
+            Job_Batch
+
+        _ ->
+            Job_KindUnrecognized_ v
+
+
+job_KindBinaryEncoder : Job_Kind -> Int
+job_KindBinaryEncoder v =
+    case v of
+        Job_KindUnspecified ->
+            0
+
+        Job_Batch ->
+            1
+
+        Job_KindUnrecognized_ n ->
+            n
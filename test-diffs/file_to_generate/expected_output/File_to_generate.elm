@@ -0,0 +1,32 @@
+module File_to_generate exposing (Message, messageDecoder, messageEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: file_to_generate.proto
+
+import Dep.Dependency as DepDependency
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+
+
+type alias Message =
+    { dependencyField : Maybe DepDependency.Dependency -- 1
+    }
+
+
+messageDecoder : JD.Decoder Message
+messageDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Message
+                |> optional "dependencyField" DepDependency.dependencyDecoder
+
+
+messageEncoder : Message -> JE.Value
+messageEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "dependencyField" DepDependency.dependencyEncoder v.dependencyField
+            ]
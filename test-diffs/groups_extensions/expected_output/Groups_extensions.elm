@@ -0,0 +1,465 @@
+module Groups_extensions exposing (Audit, Empty, Gift, Order, Order_Card, Order_Line, Order_Payment(..), Order_Shipping, Priority(..), auditBinaryDecoder, auditBinaryEncoder, auditDecoder, auditEncoder, emptyBinaryDecoder, emptyBinaryEncoder, emptyDecoder, emptyEncoder, getAudit_Audit, getGift, getNote, getPriority, getTags, giftBinaryDecoder, giftBinaryEncoder, giftDecoder, giftEncoder, orderBinaryDecoder, orderBinaryEncoder, orderDecoder, orderEncoder, order_CardBinaryDecoder, order_CardBinaryEncoder, order_CardDecoder, order_CardEncoder, order_LineBinaryDecoder, order_LineBinaryEncoder, order_LineDecoder, order_LineEncoder, order_PaymentBinaryDecoder, order_PaymentBinaryEncoder, order_PaymentDecoder, order_PaymentEncoder, order_ShippingBinaryDecoder, order_ShippingBinaryEncoder, order_ShippingDecoder, order_ShippingEncoder, priorityBinaryDecoder, priorityBinaryEncoder, priorityDecoder, priorityDefault, priorityEncoder, setAudit_Audit, setGift, setNote, setPriority, setTags)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: groups_extensions.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+import Protobuf.Binary as PB
+
+
+type Priority
+    = Normal -- 0
+    | Urgent -- 1
+
+
+priorityDecoder : JD.Decoder Priority
+priorityDecoder =
+    let
+        lookup s =
+            case s of
+                "NORMAL" ->
+                    JD.succeed Normal
+
+                "URGENT" ->
+                    JD.succeed Urgent
+
+                _ ->
+                    JD.fail ("unknown value \"" ++ s ++ "\"")
+
+        fromNumber n =
+            case n of
+                0 ->
+                    JD.succeed Normal
+
+                1 ->
+                    JD.succeed Urgent
+
+                _ ->
+                    JD.fail ("unknown value " ++ String.fromInt n)
+    in
+    JD.oneOf [ JD.andThen lookup JD.string, JD.andThen fromNumber JD.int ]
+
+
+priorityDefault : Priority
+priorityDefault =
+    Normal
+
+
+priorityEncoder : Priority -> JE.Value
+priorityEncoder v =
+    let
+        lookup s =
+            case s of
+                Normal ->
+                    JE.string "NORMAL"
+
+                Urgent ->
+                    JE.string "URGENT"
+    in
+    lookup v
+
+
+priorityBinaryDecoder : Int -> Maybe Priority
+priorityBinaryDecoder v =
+    case v of
+        0 ->
+            Just Normal
+
+        1 ->
+            Just Urgent
+
+        _ ->
+            Nothing
+
+
+priorityBinaryEncoder : Priority -> Int
+priorityBinaryEncoder v =
+    case v of
+        Normal ->
+            0
+
+        Urgent ->
+            1
+
+
+type alias Order =
+    { id : Maybe String -- 1
+    , shipping : Maybe Order_Shipping -- 2
+    , line : List Order_Line -- 3
+    , payment : Order_Payment
+    , extensions_ : Extensions
+    }
+
+
+orderDecoder : JD.Decoder Order
+orderDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Order
+                |> optional "id" JD.string
+                |> optional "shipping" order_ShippingDecoder
+                |> repeated "line" order_LineDecoder
+                |> field order_PaymentDecoder
+                |> extensions
+
+
+orderEncoder : Order -> JE.Value
+orderEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "id" JE.string v.id
+            , optionalEncoder "shipping" order_ShippingEncoder v.shipping
+            , repeatedFieldEncoder "line" order_LineEncoder v.line
+            , order_PaymentEncoder v.payment
+            ]
+                ++ extensionFields v.extensions_
+
+
+orderBinaryDecoder : PB.Decoder Order
+orderBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Order
+                |> PB.optional 1 PB.string
+                |> PB.optional 2 (PB.group order_ShippingBinaryDecoder order_ShippingBinaryEncoder)
+                |> PB.repeated 3 (PB.group order_LineBinaryDecoder order_LineBinaryEncoder)
+                |> PB.field order_PaymentBinaryDecoder
+                |> PB.field (PB.decode noExtensions)
+
+
+orderBinaryEncoder : Order -> PB.Encoder
+orderBinaryEncoder v =
+    PB.encode
+        [ PB.optionalEncoder 1 PB.string v.id
+        , PB.optionalEncoder 2 (PB.group order_ShippingBinaryDecoder order_ShippingBinaryEncoder) v.shipping
+        , PB.expandedFieldEncoder 3 (PB.group order_LineBinaryDecoder order_LineBinaryEncoder) v.line
+        , order_PaymentBinaryEncoder v.payment
+        ]
+
+
+type Order_Payment
+    = Order_PaymentUnspecified
+    | Order_Card_ Order_Card
+    | Order_Voucher String
+
+
+order_PaymentDecoder : JD.Decoder Order_Payment
+order_PaymentDecoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map Order_Card_ (JD.field "card" order_CardDecoder)
+                , JD.map Order_Voucher (JD.field "voucher" JD.string)
+                , JD.succeed Order_PaymentUnspecified
+                ]
+
+
+order_PaymentEncoder : Order_Payment -> Maybe ( String, JE.Value )
+order_PaymentEncoder v =
+    case v of
+        Order_PaymentUnspecified ->
+            Nothing
+
+        Order_Card_ x ->
+            Just ( "card", order_CardEncoder x )
+
+        Order_Voucher x ->
+            Just ( "voucher", JE.string x )
+
+
+order_PaymentBinaryDecoder : PB.Decoder Order_Payment
+order_PaymentBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.oneOf Order_PaymentUnspecified
+                [ PB.variant 4 Order_Card_ (PB.group order_CardBinaryDecoder order_CardBinaryEncoder)
+                , PB.variant 5 Order_Voucher PB.string
+                ]
+
+
+order_PaymentBinaryEncoder : Order_Payment -> PB.FieldEncoder
+order_PaymentBinaryEncoder v =
+    case v of
+        Order_PaymentUnspecified ->
+            PB.noFieldEncoder
+
+        Order_Card_ x ->
+            PB.fieldEncoder 4 (PB.group order_CardBinaryDecoder order_CardBinaryEncoder) x
+
+        Order_Voucher x ->
+            PB.fieldEncoder 5 PB.string x
+
+
+type alias Order_Shipping =
+    { address : Maybe String -- 1
+    , days : Maybe Int -- 2
+    }
+
+
+order_ShippingDecoder : JD.Decoder Order_Shipping
+order_ShippingDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Order_Shipping
+                |> optional "address" JD.string
+                |> optional "days" intDecoder
+
+
+order_ShippingEncoder : Order_Shipping -> JE.Value
+order_ShippingEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "address" JE.string v.address
+            , optionalEncoder "days" JE.int v.days
+            ]
+
+
+order_ShippingBinaryDecoder : PB.Decoder Order_Shipping
+order_ShippingBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Order_Shipping
+                |> PB.optional 1 PB.string
+                |> PB.optional 2 PB.int32
+
+
+order_ShippingBinaryEncoder : Order_Shipping -> PB.Encoder
+order_ShippingBinaryEncoder v =
+    PB.encode
+        [ PB.optionalEncoder 1 PB.string v.address
+        , PB.optionalEncoder 2 PB.int32 v.days
+        ]
+
+
+type alias Order_Line =
+    { sku : String -- 1
+    , quantity : Int -- 2
+    }
+
+
+order_LineDecoder : JD.Decoder Order_Line
+order_LineDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Order_Line
+                |> mandatory "sku" JD.string
+                |> required "quantity" intDecoder 1
+
+
+order_LineEncoder : Order_Line -> JE.Value
+order_LineEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ mandatoryFieldEncoder "sku" JE.string v.sku
+            , requiredFieldEncoder "quantity" JE.int 1 v.quantity
+            ]
+
+
+order_LineBinaryDecoder : PB.Decoder Order_Line
+order_LineBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Order_Line
+                |> PB.mandatory 1 PB.string
+                |> PB.defaulted 2 PB.uint32 1
+
+
+order_LineBinaryEncoder : Order_Line -> PB.Encoder
+order_LineBinaryEncoder v =
+    PB.encode
+        [ PB.mandatoryFieldEncoder 1 PB.string v.sku
+        , PB.defaultedFieldEncoder 2 PB.uint32 1 v.quantity
+        ]
+
+
+type alias Order_Card =
+    { number : Maybe String -- 1
+    }
+
+
+order_CardDecoder : JD.Decoder Order_Card
+order_CardDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Order_Card
+                |> optional "number" JD.string
+
+
+order_CardEncoder : Order_Card -> JE.Value
+order_CardEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "number" JE.string v.number
+            ]
+
+
+order_CardBinaryDecoder : PB.Decoder Order_Card
+order_CardBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Order_Card
+                |> PB.optional 1 PB.string
+
+
+order_CardBinaryEncoder : Order_Card -> PB.Encoder
+order_CardBinaryEncoder v =
+    PB.encode
+        [ PB.optionalEncoder 1 PB.string v.number
+        ]
+
+
+type alias Empty =
+    { extensions_ : Extensions
+    }
+
+
+emptyDecoder : JD.Decoder Empty
+emptyDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Empty
+                |> extensions
+
+
+emptyEncoder : Empty -> JE.Value
+emptyEncoder v =
+    JE.object <| List.filterMap identity <| [] ++ extensionFields v.extensions_
+
+
+emptyBinaryDecoder : PB.Decoder Empty
+emptyBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Empty
+                |> PB.field (PB.decode noExtensions)
+
+
+emptyBinaryEncoder : Empty -> PB.Encoder
+emptyBinaryEncoder v =
+    PB.encode []
+
+
+type alias Gift =
+    { message : Maybe String -- 1
+    }
+
+
+giftDecoder : JD.Decoder Gift
+giftDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Gift
+                |> optional "message" JD.string
+
+
+giftEncoder : Gift -> JE.Value
+giftEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "message" JE.string v.message
+            ]
+
+
+giftBinaryDecoder : PB.Decoder Gift
+giftBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Gift
+                |> PB.optional 1 PB.string
+
+
+giftBinaryEncoder : Gift -> PB.Encoder
+giftBinaryEncoder v =
+    PB.encode
+        [ PB.optionalEncoder 1 PB.string v.message
+        ]
+
+
+type alias Audit =
+    { user : Maybe String -- 1
+    }
+
+
+auditDecoder : JD.Decoder Audit
+auditDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Audit
+                |> optional "user" JD.string
+
+
+auditEncoder : Audit -> JE.Value
+auditEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "user" JE.string v.user
+            ]
+
+
+auditBinaryDecoder : PB.Decoder Audit
+auditBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Audit
+                |> PB.optional 1 PB.string
+
+
+auditBinaryEncoder : Audit -> PB.Encoder
+auditBinaryEncoder v =
+    PB.encode
+        [ PB.optionalEncoder 1 PB.string v.user
+        ]
+
+
+getNote : Order -> Maybe String
+getNote v =
+    getExtension "[legacy.note]" JD.string v.extensions_
+
+
+setNote : Maybe String -> Order -> Order
+setNote x v =
+    { v | extensions_ = setExtension "[legacy.note]" JE.string x v.extensions_ }
+
+
+getTags : Order -> List Int
+getTags v =
+    getRepeatedExtension "[legacy.tags]" intDecoder v.extensions_
+
+
+setTags : List Int -> Order -> Order
+setTags x v =
+    { v | extensions_ = setRepeatedExtension "[legacy.tags]" numericStringEncoder x v.extensions_ }
+
+
+getPriority : Order -> Maybe Priority
+getPriority v =
+    getExtension "[legacy.priority]" priorityDecoder v.extensions_
+
+
+setPriority : Maybe Priority -> Order -> Order
+setPriority x v =
+    { v | extensions_ = setExtension "[legacy.priority]" priorityEncoder x v.extensions_ }
+
+
+getGift : Order -> Maybe Gift
+getGift v =
+    getExtension "[legacy.gift]" giftDecoder v.extensions_
+
+
+setGift : Maybe Gift -> Order -> Order
+setGift x v =
+    { v | extensions_ = setExtension "[legacy.gift]" giftEncoder x v.extensions_ }
+
+
+getAudit_Audit : Order -> Maybe Audit
+getAudit_Audit v =
+    getExtension "[legacy.Audit.audit]" auditDecoder v.extensions_
+
+
+setAudit_Audit : Maybe Audit -> Order -> Order
+setAudit_Audit x v =
+    { v | extensions_ = setExtension "[legacy.Audit.audit]" auditEncoder x v.extensions_ }
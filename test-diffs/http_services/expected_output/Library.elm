@@ -0,0 +1,270 @@
+module Library exposing (Book, DeleteBookRequest, Genre(..), GetBookRequest, ListBooksRequest, ListBooksResponse, MoveBookRequest, UpdateBookRequest, WatchRequest, bookDecoder, bookEncoder, deleteBookRequestDecoder, deleteBookRequestEncoder, genreDecoder, genreDefault, genreEncoder, getBookRequestDecoder, getBookRequestEncoder, listBooksRequestDecoder, listBooksRequestEncoder, listBooksResponseDecoder, listBooksResponseEncoder, moveBookRequestDecoder, moveBookRequestEncoder, updateBookRequestDecoder, updateBookRequestEncoder, watchRequestDecoder, watchRequestEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: library.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+
+
+type Genre
+    = GenreUnspecified -- 0
+    | Fiction -- 1
+    | GenreUnrecognized_ Int
+
+
+genreDecoder : JD.Decoder Genre
+genreDecoder =
+    let
+        lookup s =
+            case s of
+                "GENRE_UNSPECIFIED" ->
+                    GenreUnspecified
+
+                "FICTION" ->
+                    Fiction
+
+                _ ->
+                    GenreUnspecified
+
+        fromNumber n =
+            case n of
+                0 ->
+                    GenreUnspecified
+
+                1 ->
+                    Fiction
+
+                _ ->
+                    GenreUnrecognized_ n
+    in
+    JD.oneOf [ JD.map lookup JD.string, JD.map fromNumber JD.int ]
+
+
+genreDefault : Genre
+genreDefault =
+    GenreUnspecified
+
+
+genreEncoder : Genre -> JE.Value
+genreEncoder v =
+    let
+        lookup s =
+            case s of
+                GenreUnspecified ->
+                    JE.string "GENRE_UNSPECIFIED"
+
+                Fiction ->
+                    JE.string "FICTION"
+
+                GenreUnrecognized_ n ->
+                    JE.int n
+    in
+    lookup v
+
+
+type alias Book =
+    { name : String -- 1
+    , title : String -- 2
+    , pages : Int -- 3
+    , genre : Genre -- 4
+    }
+
+
+bookDecoder : JD.Decoder Book
+bookDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Book
+                |> required "name" JD.string ""
+                |> required "title" JD.string ""
+                |> required "pages" intDecoder 0
+                |> required "genre" genreDecoder genreDefault
+
+
+bookEncoder : Book -> JE.Value
+bookEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "name" JE.string "" v.name
+            , requiredFieldEncoder "title" JE.string "" v.title
+            , requiredFieldEncoder "pages" numericStringEncoder 0 v.pages
+            , requiredFieldEncoder "genre" genreEncoder genreDefault v.genre
+            ]
+
+
+type alias GetBookRequest =
+    { name : String -- 1
+    }
+
+
+getBookRequestDecoder : JD.Decoder GetBookRequest
+getBookRequestDecoder =
+    JD.lazy <|
+        \_ ->
+            decode GetBookRequest
+                |> required "name" JD.string ""
+
+
+getBookRequestEncoder : GetBookRequest -> JE.Value
+getBookRequestEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "name" JE.string "" v.name
+            ]
+
+
+type alias ListBooksRequest =
+    { shelf : Int -- 1
+    , pageSize : Int -- 2
+    , pageToken : String -- 3
+    , authors : List String -- 4
+    , genre : Genre -- 5
+    }
+
+
+listBooksRequestDecoder : JD.Decoder ListBooksRequest
+listBooksRequestDecoder =
+    JD.lazy <|
+        \_ ->
+            decode ListBooksRequest
+                |> required "shelf" intDecoder 0
+                |> required "pageSize" intDecoder 0
+                |> required "pageToken" JD.string ""
+                |> repeated "authors" JD.string
+                |> required "genre" genreDecoder genreDefault
+
+
+listBooksRequestEncoder : ListBooksRequest -> JE.Value
+listBooksRequestEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "shelf" JE.int 0 v.shelf
+            , requiredFieldEncoder "pageSize" JE.int 0 v.pageSize
+            , requiredFieldEncoder "pageToken" JE.string "" v.pageToken
+            , repeatedFieldEncoder "authors" JE.string v.authors
+            , requiredFieldEncoder "genre" genreEncoder genreDefault v.genre
+            ]
+
+
+type alias ListBooksResponse =
+    { books : List Book -- 1
+    , nextPageToken : String -- 2
+    }
+
+
+listBooksResponseDecoder : JD.Decoder ListBooksResponse
+listBooksResponseDecoder =
+    JD.lazy <|
+        \_ ->
+            decode ListBooksResponse
+                |> repeated "books" bookDecoder
+                |> required "nextPageToken" JD.string ""
+
+
+listBooksResponseEncoder : ListBooksResponse -> JE.Value
+listBooksResponseEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ repeatedFieldEncoder "books" bookEncoder v.books
+            , requiredFieldEncoder "nextPageToken" JE.string "" v.nextPageToken
+            ]
+
+
+type alias UpdateBookRequest =
+    { book : Maybe Book -- 1
+    , validateOnly : Bool -- 2
+    }
+
+
+updateBookRequestDecoder : JD.Decoder UpdateBookRequest
+updateBookRequestDecoder =
+    JD.lazy <|
+        \_ ->
+            decode UpdateBookRequest
+                |> optional "book" bookDecoder
+                |> required "validateOnly" JD.bool False
+
+
+updateBookRequestEncoder : UpdateBookRequest -> JE.Value
+updateBookRequestEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "book" bookEncoder v.book
+            , requiredFieldEncoder "validateOnly" JE.bool False v.validateOnly
+            ]
+
+
+type alias DeleteBookRequest =
+    { name : String -- 1
+    }
+
+
+deleteBookRequestDecoder : JD.Decoder DeleteBookRequest
+deleteBookRequestDecoder =
+    JD.lazy <|
+        \_ ->
+            decode DeleteBookRequest
+                |> required "name" JD.string ""
+
+
+deleteBookRequestEncoder : DeleteBookRequest -> JE.Value
+deleteBookRequestEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "name" JE.string "" v.name
+            ]
+
+
+type alias MoveBookRequest =
+    { shelf : Int -- 1
+    , bookId : Int -- 2
+    , genre : Genre -- 3
+    , otherShelf : Int -- 4
+    }
+
+
+moveBookRequestDecoder : JD.Decoder MoveBookRequest
+moveBookRequestDecoder =
+    JD.lazy <|
+        \_ ->
+            decode MoveBookRequest
+                |> required "shelf" intDecoder 0
+                |> required "bookId" intDecoder 0
+                |> required "genre" genreDecoder genreDefault
+                |> required "otherShelf" intDecoder 0
+
+
+moveBookRequestEncoder : MoveBookRequest -> JE.Value
+moveBookRequestEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "shelf" JE.int 0 v.shelf
+            , requiredFieldEncoder "bookId" numericStringEncoder 0 v.bookId
+            , requiredFieldEncoder "genre" genreEncoder genreDefault v.genre
+            , requiredFieldEncoder "otherShelf" JE.int 0 v.otherShelf
+            ]
+
+
+type alias WatchRequest =
+    { name : String -- 1
+    }
+
+
+watchRequestDecoder : JD.Decoder WatchRequest
+watchRequestDecoder =
+    JD.lazy <|
+        \_ ->
+            decode WatchRequest
+                |> required "name" JD.string ""
+
+
+watchRequestEncoder : WatchRequest -> JE.Value
+watchRequestEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "name" JE.string "" v.name
+            ]
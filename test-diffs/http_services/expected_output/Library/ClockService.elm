@@ -0,0 +1,26 @@
+module Library.ClockService exposing (getTime)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: library.proto
+-- service: library.v1.ClockService
+--
+-- Well known types are qualified, the runtime library being imported without exposing it.
+
+import Http
+import Protobuf
+import Protobuf.Http as PH
+
+
+getTime : PH.Config -> (Result Http.Error Protobuf.Timestamp -> msg) -> Protobuf.Empty -> Cmd msg
+getTime config toMsg request =
+    PH.send
+        { method = "GET"
+        , path = [ PH.literal "/v1/time" ]
+        , body = PH.NoBody
+        , decoder = Protobuf.timestampDecoder
+        }
+        config
+        toMsg
+        (Protobuf.emptyEncoder request)
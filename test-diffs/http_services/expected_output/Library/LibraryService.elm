@@ -0,0 +1,126 @@
+module Library.LibraryService exposing (config_, createBook, deleteBook, getBook, listBookTitles, listBooks, moveBook, updateBook)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: library.proto
+-- service: library.v1.LibraryService
+--
+-- Manages the books of a library.
+--
+-- Books belong to shelves.
+
+import Http
+import Library
+import Protobuf
+import Protobuf.Http as PH
+
+
+{-| Gets a book by its resource name.
+-}
+getBook : PH.Config -> (Result Http.Error Library.Book -> msg) -> Library.GetBookRequest -> Cmd msg
+getBook config toMsg request =
+    PH.send
+        { method = "GET"
+        , path = [ PH.literal "/v1/", PH.multiSegmentVariable [ "name" ] "" ]
+        , body = PH.NoBody
+        , decoder = Library.bookDecoder
+        }
+        config
+        toMsg
+        (Library.getBookRequestEncoder request)
+
+
+{-| Lists the books of a shelf.
+-}
+listBooks : PH.Config -> (Result Http.Error Library.ListBooksResponse -> msg) -> Library.ListBooksRequest -> Cmd msg
+listBooks config toMsg request =
+    PH.send
+        { method = "GET"
+        , path = [ PH.literal "/v1/shelves/", PH.variable [ "shelf" ] "0", PH.literal "/books" ]
+        , body = PH.NoBody
+        , decoder = Library.listBooksResponseDecoder
+        }
+        config
+        toMsg
+        (Library.listBooksRequestEncoder request)
+
+
+listBookTitles : PH.Config -> (Result Http.Error Library.ListBooksResponse -> msg) -> Library.ListBooksRequest -> Cmd msg
+listBookTitles config toMsg request =
+    PH.send
+        { method = "GET"
+        , path = [ PH.literal "/v1/shelves/", PH.variable [ "shelf" ] "0", PH.literal "/books:titles" ]
+        , body = PH.NoBody
+        , decoder = PH.responseBody "books" Library.listBooksResponseDecoder
+        }
+        config
+        toMsg
+        (Library.listBooksRequestEncoder request)
+
+
+updateBook : PH.Config -> (Result Http.Error Library.Book -> msg) -> Library.UpdateBookRequest -> Cmd msg
+updateBook config toMsg request =
+    PH.send
+        { method = "PATCH"
+        , path = [ PH.literal "/v1/", PH.multiSegmentVariable [ "book", "name" ] "" ]
+        , body = PH.RequestField "book"
+        , decoder = Library.bookDecoder
+        }
+        config
+        toMsg
+        (Library.updateBookRequestEncoder request)
+
+
+createBook : PH.Config -> (Result Http.Error Library.Book -> msg) -> Library.Book -> Cmd msg
+createBook config toMsg request =
+    PH.send
+        { method = "POST"
+        , path = [ PH.literal "/v1/books" ]
+        , body = PH.WholeRequest
+        , decoder = Library.bookDecoder
+        }
+        config
+        toMsg
+        (Library.bookEncoder request)
+
+
+deleteBook : PH.Config -> (Result Http.Error Protobuf.Empty -> msg) -> Library.DeleteBookRequest -> Cmd msg
+deleteBook config toMsg request =
+    PH.send
+        { method = "DELETE"
+        , path = [ PH.literal "/v1/", PH.multiSegmentVariable [ "name" ] "" ]
+        , body = PH.NoBody
+        , decoder = Protobuf.emptyDecoder
+        }
+        config
+        toMsg
+        (Library.deleteBookRequestEncoder request)
+
+
+moveBook : PH.Config -> (Result Http.Error Library.Book -> msg) -> Library.MoveBookRequest -> Cmd msg
+moveBook config toMsg request =
+    PH.send
+        { method = "MOVE"
+        , path = [ PH.literal "/v1/shelves/", PH.variable [ "shelf" ] "0", PH.literal "/books/", PH.variable [ "bookId" ] "0", PH.literal "/", PH.variable [ "genre" ] "GENRE_UNSPECIFIED" ]
+        , body = PH.NoBody
+        , decoder = Library.bookDecoder
+        }
+        config
+        toMsg
+        (Library.moveBookRequestEncoder request)
+
+
+{-| Not bound to an HTTP endpoint.
+-}
+config_ : PH.Config -> (Result Http.Error Library.Book -> msg) -> Library.GetBookRequest -> Cmd msg
+config_ config toMsg request =
+    PH.send
+        { method = "POST"
+        , path = [ PH.literal "/library.v1.LibraryService/Config" ]
+        , body = PH.WholeRequest
+        , decoder = Library.bookDecoder
+        }
+        config
+        toMsg
+        (Library.getBookRequestEncoder request)
@@ -0,0 +1,192 @@
+module Int64_exact exposing (Order, Order_NotesEntry, Order_Reference(..), orderBinaryDecoder, orderBinaryEncoder, orderDecoder, orderEncoder, order_NotesEntryBinaryDecoder, order_NotesEntryBinaryEncoder, order_NotesEntryDecoder, order_NotesEntryEncoder, order_ReferenceBinaryDecoder, order_ReferenceBinaryEncoder, order_ReferenceDecoder, order_ReferenceEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: int64_exact.proto
+
+import Dict
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+import Protobuf.Binary as PB
+
+
+type alias Order =
+    { id : Protobuf.Int64 -- 1
+    , total : Protobuf.Int64 -- 2
+    , delta : Protobuf.Int64 -- 3
+    , checksum : Protobuf.Int64 -- 4
+    , offset : Protobuf.Int64 -- 5
+    , itemIds : List Protobuf.Int64 -- 6
+    , previousId : Maybe Protobuf.Int64 -- 8
+    , quantity : Maybe Protobuf.Int64 -- 9
+    , notes : Dict.Dict String String -- 10
+    , reference : Order_Reference
+    , parentId : Maybe Protobuf.Int64
+    }
+
+
+orderDecoder : JD.Decoder Order
+orderDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Order
+                |> required "id" Protobuf.int64Decoder Protobuf.int64Zero
+                |> required "total" Protobuf.uint64Decoder Protobuf.int64Zero
+                |> required "delta" Protobuf.int64Decoder Protobuf.int64Zero
+                |> required "checksum" Protobuf.uint64Decoder Protobuf.int64Zero
+                |> required "offset" Protobuf.int64Decoder Protobuf.int64Zero
+                |> repeated "itemIds" Protobuf.int64Decoder
+                |> optional "previousId" Protobuf.int64Decoder
+                |> optional "quantity" Protobuf.uint64Decoder
+                |> keyedMapEntries "notes" int64KeyFromString JD.string
+                |> field order_ReferenceDecoder
+                |> optional "parentId" Protobuf.int64Decoder
+
+
+orderEncoder : Order -> JE.Value
+orderEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "id" Protobuf.int64Encoder Protobuf.int64Zero v.id
+            , requiredFieldEncoder "total" Protobuf.int64Encoder Protobuf.int64Zero v.total
+            , requiredFieldEncoder "delta" Protobuf.int64Encoder Protobuf.int64Zero v.delta
+            , requiredFieldEncoder "checksum" Protobuf.int64Encoder Protobuf.int64Zero v.checksum
+            , requiredFieldEncoder "offset" Protobuf.int64Encoder Protobuf.int64Zero v.offset
+            , repeatedFieldEncoder "itemIds" Protobuf.int64Encoder v.itemIds
+            , optionalEncoder "previousId" Protobuf.int64Encoder v.previousId
+            , optionalEncoder "quantity" Protobuf.int64Encoder v.quantity
+            , keyedMapEntriesFieldEncoder "notes" identity JE.string v.notes
+            , order_ReferenceEncoder v.reference
+            , optionalEncoder "parentId" Protobuf.int64Encoder v.parentId
+            ]
+
+
+orderBinaryDecoder : PB.Decoder Order
+orderBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Order
+                |> PB.required 1 PB.exactInt64
+                |> PB.required 2 PB.exactUint64
+                |> PB.required 3 PB.exactSint64
+                |> PB.required 4 PB.exactFixed64
+                |> PB.required 5 PB.exactSfixed64
+                |> PB.repeated 6 PB.exactInt64
+                |> PB.optional 8 (PB.wrapper PB.exactInt64)
+                |> PB.optional 9 (PB.wrapper PB.exactUint64)
+                |> PB.mapEntries 10 (PB.decimal PB.exactInt64) PB.string
+                |> PB.field order_ReferenceBinaryDecoder
+                |> PB.optional 7 PB.exactInt64
+
+
+orderBinaryEncoder : Order -> PB.Encoder
+orderBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.exactInt64 v.id
+        , PB.requiredFieldEncoder 2 PB.exactUint64 v.total
+        , PB.requiredFieldEncoder 3 PB.exactSint64 v.delta
+        , PB.requiredFieldEncoder 4 PB.exactFixed64 v.checksum
+        , PB.requiredFieldEncoder 5 PB.exactSfixed64 v.offset
+        , PB.repeatedFieldEncoder 6 PB.exactInt64 v.itemIds
+        , PB.optionalEncoder 8 (PB.wrapper PB.exactInt64) v.previousId
+        , PB.optionalEncoder 9 (PB.wrapper PB.exactUint64) v.quantity
+        , PB.mapEntriesFieldEncoder 10 (PB.decimal PB.exactInt64) PB.string v.notes
+        , order_ReferenceBinaryEncoder v.reference
+        , PB.optionalEncoder 7 PB.exactInt64 v.parentId
+        ]
+
+
+type Order_Reference
+    = Order_ReferenceUnspecified
+    | Order_CustomerId Protobuf.Int64
+    | Order_Email String
+
+
+order_ReferenceDecoder : JD.Decoder Order_Reference
+order_ReferenceDecoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map Order_CustomerId (JD.field "customerId" Protobuf.int64Decoder)
+                , JD.map Order_Email (JD.field "email" JD.string)
+                , JD.succeed Order_ReferenceUnspecified
+                ]
+
+
+order_ReferenceEncoder : Order_Reference -> Maybe ( String, JE.Value )
+order_ReferenceEncoder v =
+    case v of
+        Order_ReferenceUnspecified ->
+            Nothing
+
+        Order_CustomerId x ->
+            Just ( "customerId", Protobuf.int64Encoder x )
+
+        Order_Email x ->
+            Just ( "email", JE.string x )
+
+
+order_ReferenceBinaryDecoder : PB.Decoder Order_Reference
+order_ReferenceBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.oneOf Order_ReferenceUnspecified
+                [ PB.variant 11 Order_CustomerId PB.exactInt64
+                , PB.variant 12 Order_Email PB.string
+                ]
+
+
+order_ReferenceBinaryEncoder : Order_Reference -> PB.FieldEncoder
+order_ReferenceBinaryEncoder v =
+    case v of
+        Order_ReferenceUnspecified ->
+            PB.noFieldEncoder
+
+        Order_CustomerId x ->
+            PB.fieldEncoder 11 PB.exactInt64 x
+
+        Order_Email x ->
+            PB.fieldEncoder 12 PB.string x
+
+
+type alias Order_NotesEntry =
+    { key : Protobuf.Int64 -- 1
+    , value : String -- 2
+    }
+
+
+order_NotesEntryDecoder : JD.Decoder Order_NotesEntry
+order_NotesEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Order_NotesEntry
+                |> required "key" Protobuf.int64Decoder Protobuf.int64Zero
+                |> required "value" JD.string ""
+
+
+order_NotesEntryEncoder : Order_NotesEntry -> JE.Value
+order_NotesEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" Protobuf.int64Encoder Protobuf.int64Zero v.key
+            , requiredFieldEncoder "value" JE.string "" v.value
+            ]
+
+
+order_NotesEntryBinaryDecoder : PB.Decoder Order_NotesEntry
+order_NotesEntryBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Order_NotesEntry
+                |> PB.required 1 PB.exactInt64
+                |> PB.required 2 PB.string
+
+
+order_NotesEntryBinaryEncoder : Order_NotesEntry -> PB.Encoder
+order_NotesEntryBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.exactInt64 v.key
+        , PB.requiredFieldEncoder 2 PB.string v.value
+        ]
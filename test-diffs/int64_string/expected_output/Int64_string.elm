@@ -0,0 +1,192 @@
+module Int64_string exposing (Order, Order_NotesEntry, Order_Reference(..), orderBinaryDecoder, orderBinaryEncoder, orderDecoder, orderEncoder, order_NotesEntryBinaryDecoder, order_NotesEntryBinaryEncoder, order_NotesEntryDecoder, order_NotesEntryEncoder, order_ReferenceBinaryDecoder, order_ReferenceBinaryEncoder, order_ReferenceDecoder, order_ReferenceEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: int64_string.proto
+
+import Dict
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+import Protobuf.Binary as PB
+
+
+type alias Order =
+    { id : String -- 1
+    , total : String -- 2
+    , delta : String -- 3
+    , checksum : String -- 4
+    , offset : String -- 5
+    , itemIds : List String -- 6
+    , previousId : Maybe String -- 8
+    , quantity : Maybe String -- 9
+    , notes : Dict.Dict String String -- 10
+    , reference : Order_Reference
+    , parentId : Maybe String
+    }
+
+
+orderDecoder : JD.Decoder Order
+orderDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Order
+                |> required "id" Protobuf.int64StringDecoder "0"
+                |> required "total" Protobuf.uint64StringDecoder "0"
+                |> required "delta" Protobuf.int64StringDecoder "0"
+                |> required "checksum" Protobuf.uint64StringDecoder "0"
+                |> required "offset" Protobuf.int64StringDecoder "0"
+                |> repeated "itemIds" Protobuf.int64StringDecoder
+                |> optional "previousId" Protobuf.int64StringDecoder
+                |> optional "quantity" Protobuf.uint64StringDecoder
+                |> keyedMapEntries "notes" int64KeyFromString JD.string
+                |> field order_ReferenceDecoder
+                |> optional "parentId" Protobuf.int64StringDecoder
+
+
+orderEncoder : Order -> JE.Value
+orderEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "id" JE.string "0" v.id
+            , requiredFieldEncoder "total" JE.string "0" v.total
+            , requiredFieldEncoder "delta" JE.string "0" v.delta
+            , requiredFieldEncoder "checksum" JE.string "0" v.checksum
+            , requiredFieldEncoder "offset" JE.string "0" v.offset
+            , repeatedFieldEncoder "itemIds" JE.string v.itemIds
+            , optionalEncoder "previousId" JE.string v.previousId
+            , optionalEncoder "quantity" JE.string v.quantity
+            , keyedMapEntriesFieldEncoder "notes" identity JE.string v.notes
+            , order_ReferenceEncoder v.reference
+            , optionalEncoder "parentId" JE.string v.parentId
+            ]
+
+
+orderBinaryDecoder : PB.Decoder Order
+orderBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Order
+                |> PB.required 1 (PB.decimal PB.exactInt64)
+                |> PB.required 2 (PB.unsignedDecimal PB.exactUint64)
+                |> PB.required 3 (PB.decimal PB.exactSint64)
+                |> PB.required 4 (PB.unsignedDecimal PB.exactFixed64)
+                |> PB.required 5 (PB.decimal PB.exactSfixed64)
+                |> PB.repeated 6 (PB.decimal PB.exactInt64)
+                |> PB.optional 8 (PB.wrapper (PB.decimal PB.exactInt64))
+                |> PB.optional 9 (PB.wrapper (PB.unsignedDecimal PB.exactUint64))
+                |> PB.mapEntries 10 (PB.decimal PB.exactInt64) PB.string
+                |> PB.field order_ReferenceBinaryDecoder
+                |> PB.optional 7 (PB.decimal PB.exactInt64)
+
+
+orderBinaryEncoder : Order -> PB.Encoder
+orderBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 (PB.decimal PB.exactInt64) v.id
+        , PB.requiredFieldEncoder 2 (PB.unsignedDecimal PB.exactUint64) v.total
+        , PB.requiredFieldEncoder 3 (PB.decimal PB.exactSint64) v.delta
+        , PB.requiredFieldEncoder 4 (PB.unsignedDecimal PB.exactFixed64) v.checksum
+        , PB.requiredFieldEncoder 5 (PB.decimal PB.exactSfixed64) v.offset
+        , PB.repeatedFieldEncoder 6 (PB.decimal PB.exactInt64) v.itemIds
+        , PB.optionalEncoder 8 (PB.wrapper (PB.decimal PB.exactInt64)) v.previousId
+        , PB.optionalEncoder 9 (PB.wrapper (PB.unsignedDecimal PB.exactUint64)) v.quantity
+        , PB.mapEntriesFieldEncoder 10 (PB.decimal PB.exactInt64) PB.string v.notes
+        , order_ReferenceBinaryEncoder v.reference
+        , PB.optionalEncoder 7 (PB.decimal PB.exactInt64) v.parentId
+        ]
+
+
+type Order_Reference
+    = Order_ReferenceUnspecified
+    | Order_CustomerId String
+    | Order_Email String
+
+
+order_ReferenceDecoder : JD.Decoder Order_Reference
+order_ReferenceDecoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map Order_CustomerId (JD.field "customerId" Protobuf.int64StringDecoder)
+                , JD.map Order_Email (JD.field "email" JD.string)
+                , JD.succeed Order_ReferenceUnspecified
+                ]
+
+
+order_ReferenceEncoder : Order_Reference -> Maybe ( String, JE.Value )
+order_ReferenceEncoder v =
+    case v of
+        Order_ReferenceUnspecified ->
+            Nothing
+
+        Order_CustomerId x ->
+            Just ( "customerId", JE.string x )
+
+        Order_Email x ->
+            Just ( "email", JE.string x )
+
+
+order_ReferenceBinaryDecoder : PB.Decoder Order_Reference
+order_ReferenceBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.oneOf Order_ReferenceUnspecified
+                [ PB.variant 11 Order_CustomerId (PB.decimal PB.exactInt64)
+                , PB.variant 12 Order_Email PB.string
+                ]
+
+
+order_ReferenceBinaryEncoder : Order_Reference -> PB.FieldEncoder
+order_ReferenceBinaryEncoder v =
+    case v of
+        Order_ReferenceUnspecified ->
+            PB.noFieldEncoder
+
+        Order_CustomerId x ->
+            PB.fieldEncoder 11 (PB.decimal PB.exactInt64) x
+
+        Order_Email x ->
+            PB.fieldEncoder 12 PB.string x
+
+
+type alias Order_NotesEntry =
+    { key : String -- 1
+    , value : String -- 2
+    }
+
+
+order_NotesEntryDecoder : JD.Decoder Order_NotesEntry
+order_NotesEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Order_NotesEntry
+                |> required "key" Protobuf.int64StringDecoder "0"
+                |> required "value" JD.string ""
+
+
+order_NotesEntryEncoder : Order_NotesEntry -> JE.Value
+order_NotesEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.string "0" v.key
+            , requiredFieldEncoder "value" JE.string "" v.value
+            ]
+
+
+order_NotesEntryBinaryDecoder : PB.Decoder Order_NotesEntry
+order_NotesEntryBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Order_NotesEntry
+                |> PB.required 1 (PB.decimal PB.exactInt64)
+                |> PB.required 2 PB.string
+
+
+order_NotesEntryBinaryEncoder : Order_NotesEntry -> PB.Encoder
+order_NotesEntryBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 (PB.decimal PB.exactInt64) v.key
+        , PB.requiredFieldEncoder 2 PB.string v.value
+        ]
@@ -1,18 +1,14 @@
-module Map_entry exposing (..)
+module Map_entry exposing (Bar, Foo, Foo_BoolToBarsEntry, Foo_BoolToStringsEntry, Foo_Fixed32ToBarsEntry, Foo_Fixed64ToBarsEntry, Foo_Int32ToStringsEntry, Foo_Int64ToStringsEntry, Foo_Sfixed32ToIntsEntry, Foo_Sfixed64ToIntsEntry, Foo_Sint32ToStringsEntry, Foo_Sint64ToStringsEntry, Foo_StringToBarsEntry, Foo_StringToStringsEntry, Foo_Uint32ToStringsEntry, Foo_Uint64ToStringsEntry, barDecoder, barEncoder, fooDecoder, fooEncoder, foo_BoolToBarsEntryDecoder, foo_BoolToBarsEntryEncoder, foo_BoolToStringsEntryDecoder, foo_BoolToStringsEntryEncoder, foo_Fixed32ToBarsEntryDecoder, foo_Fixed32ToBarsEntryEncoder, foo_Fixed64ToBarsEntryDecoder, foo_Fixed64ToBarsEntryEncoder, foo_Int32ToStringsEntryDecoder, foo_Int32ToStringsEntryEncoder, foo_Int64ToStringsEntryDecoder, foo_Int64ToStringsEntryEncoder, foo_Sfixed32ToIntsEntryDecoder, foo_Sfixed32ToIntsEntryEncoder, foo_Sfixed64ToIntsEntryDecoder, foo_Sfixed64ToIntsEntryEncoder, foo_Sint32ToStringsEntryDecoder, foo_Sint32ToStringsEntryEncoder, foo_Sint64ToStringsEntryDecoder, foo_Sint64ToStringsEntryEncoder, foo_StringToBarsEntryDecoder, foo_StringToBarsEntryEncoder, foo_StringToStringsEntryDecoder, foo_StringToStringsEntryEncoder, foo_Uint32ToStringsEntryDecoder, foo_Uint32ToStringsEntryEncoder, foo_Uint64ToStringsEntryDecoder, foo_Uint64ToStringsEntryEncoder)
 
 -- DO NOT EDIT
 -- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
 -- https://github.com/tiziano88/elm-protobuf
 -- source file: map_entry.proto
 
-import Protobuf exposing (..)
-
+import Dict
 import Json.Decode as JD
 import Json.Encode as JE
-import Dict
-
-
-uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+import Protobuf exposing (..)
 
 
 type alias Bar =
@@ -22,36 +18,78 @@ type alias Bar =
 
 barDecoder : JD.Decoder Bar
 barDecoder =
-    JD.lazy <| \_ -> decode Bar
-        |> required "field" JD.bool False
+    JD.lazy <|
+        \_ ->
+            decode Bar
+                |> required "field" JD.bool False
 
 
 barEncoder : Bar -> JE.Value
 barEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "field" JE.bool False v.field)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "field" JE.bool False v.field
+            ]
 
 
 type alias Foo =
     { stringToBars : Dict.Dict String Bar -- 8
     , stringToStrings : Dict.Dict String String -- 7
+    , int32ToStrings : Dict.Dict Int String -- 1
+    , int64ToStrings : Dict.Dict Int String -- 2
+    , uint32ToStrings : Dict.Dict Int String -- 3
+    , uint64ToStrings : Dict.Dict Int String -- 4
+    , sint32ToStrings : Dict.Dict Int String -- 5
+    , sint64ToStrings : Dict.Dict Int String -- 6
+    , fixed32ToBars : Dict.Dict Int Bar -- 9
+    , fixed64ToBars : Dict.Dict Int Bar -- 10
+    , sfixed32ToInts : Dict.Dict Int Int -- 11
+    , sfixed64ToInts : Dict.Dict Int Int -- 12
+    , boolToStrings : List ( Bool, String ) -- 13
+    , boolToBars : List ( Bool, Bar ) -- 14
     }
 
 
 fooDecoder : JD.Decoder Foo
 fooDecoder =
-    JD.lazy <| \_ -> decode Foo
-        |> mapEntries "stringToBars" barDecoder
-        |> mapEntries "stringToStrings" JD.string
+    JD.lazy <|
+        \_ ->
+            decode Foo
+                |> mapEntries "stringToBars" barDecoder
+                |> mapEntries "stringToStrings" JD.string
+                |> keyedMapEntries "int32ToStrings" intKeyFromString JD.string
+                |> keyedMapEntries "int64ToStrings" intKeyFromString JD.string
+                |> keyedMapEntries "uint32ToStrings" intKeyFromString JD.string
+                |> keyedMapEntries "uint64ToStrings" intKeyFromString JD.string
+                |> keyedMapEntries "sint32ToStrings" intKeyFromString JD.string
+                |> keyedMapEntries "sint64ToStrings" intKeyFromString JD.string
+                |> keyedMapEntries "fixed32ToBars" intKeyFromString barDecoder
+                |> keyedMapEntries "fixed64ToBars" intKeyFromString barDecoder
+                |> keyedMapEntries "sfixed32ToInts" intKeyFromString intDecoder
+                |> keyedMapEntries "sfixed64ToInts" intKeyFromString intDecoder
+                |> boolMapEntries "boolToStrings" JD.string
+                |> boolMapEntries "boolToBars" barDecoder
 
 
 fooEncoder : Foo -> JE.Value
 fooEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (mapEntriesFieldEncoder "stringToBars" barEncoder v.stringToBars)
-        , (mapEntriesFieldEncoder "stringToStrings" JE.string v.stringToStrings)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ mapEntriesFieldEncoder "stringToBars" barEncoder v.stringToBars
+            , mapEntriesFieldEncoder "stringToStrings" JE.string v.stringToStrings
+            , keyedMapEntriesFieldEncoder "int32ToStrings" String.fromInt JE.string v.int32ToStrings
+            , keyedMapEntriesFieldEncoder "int64ToStrings" String.fromInt JE.string v.int64ToStrings
+            , keyedMapEntriesFieldEncoder "uint32ToStrings" String.fromInt JE.string v.uint32ToStrings
+            , keyedMapEntriesFieldEncoder "uint64ToStrings" String.fromInt JE.string v.uint64ToStrings
+            , keyedMapEntriesFieldEncoder "sint32ToStrings" String.fromInt JE.string v.sint32ToStrings
+            , keyedMapEntriesFieldEncoder "sint64ToStrings" String.fromInt JE.string v.sint64ToStrings
+            , keyedMapEntriesFieldEncoder "fixed32ToBars" String.fromInt barEncoder v.fixed32ToBars
+            , keyedMapEntriesFieldEncoder "fixed64ToBars" String.fromInt barEncoder v.fixed64ToBars
+            , keyedMapEntriesFieldEncoder "sfixed32ToInts" String.fromInt JE.int v.sfixed32ToInts
+            , keyedMapEntriesFieldEncoder "sfixed64ToInts" String.fromInt JE.int v.sfixed64ToInts
+            , boolMapEntriesFieldEncoder "boolToStrings" JE.string v.boolToStrings
+            , boolMapEntriesFieldEncoder "boolToBars" barEncoder v.boolToBars
+            ]
 
 
 type alias Foo_StringToBarsEntry =
@@ -62,17 +100,20 @@ type alias Foo_StringToBarsEntry =
 
 foo_StringToBarsEntryDecoder : JD.Decoder Foo_StringToBarsEntry
 foo_StringToBarsEntryDecoder =
-    JD.lazy <| \_ -> decode Foo_StringToBarsEntry
-        |> required "key" JD.string ""
-        |> optional "value" barDecoder
+    JD.lazy <|
+        \_ ->
+            decode Foo_StringToBarsEntry
+                |> required "key" JD.string ""
+                |> optional "value" barDecoder
 
 
 foo_StringToBarsEntryEncoder : Foo_StringToBarsEntry -> JE.Value
 foo_StringToBarsEntryEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "key" JE.string "" v.key)
-        , (optionalEncoder "value" barEncoder v.value)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.string "" v.key
+            , optionalEncoder "value" barEncoder v.value
+            ]
 
 
 type alias Foo_StringToStringsEntry =
@@ -83,14 +124,305 @@ type alias Foo_StringToStringsEntry =
 
 foo_StringToStringsEntryDecoder : JD.Decoder Foo_StringToStringsEntry
 foo_StringToStringsEntryDecoder =
-    JD.lazy <| \_ -> decode Foo_StringToStringsEntry
-        |> required "key" JD.string ""
-        |> required "value" JD.string ""
+    JD.lazy <|
+        \_ ->
+            decode Foo_StringToStringsEntry
+                |> required "key" JD.string ""
+                |> required "value" JD.string ""
 
 
 foo_StringToStringsEntryEncoder : Foo_StringToStringsEntry -> JE.Value
 foo_StringToStringsEntryEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "key" JE.string "" v.key)
-        , (requiredFieldEncoder "value" JE.string "" v.value)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.string "" v.key
+            , requiredFieldEncoder "value" JE.string "" v.value
+            ]
+
+
+type alias Foo_Int32ToStringsEntry =
+    { key : Int -- 1
+    , value : String -- 2
+    }
+
+
+foo_Int32ToStringsEntryDecoder : JD.Decoder Foo_Int32ToStringsEntry
+foo_Int32ToStringsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Foo_Int32ToStringsEntry
+                |> required "key" intDecoder 0
+                |> required "value" JD.string ""
+
+
+foo_Int32ToStringsEntryEncoder : Foo_Int32ToStringsEntry -> JE.Value
+foo_Int32ToStringsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.int 0 v.key
+            , requiredFieldEncoder "value" JE.string "" v.value
+            ]
+
+
+type alias Foo_Int64ToStringsEntry =
+    { key : Int -- 1
+    , value : String -- 2
+    }
+
+
+foo_Int64ToStringsEntryDecoder : JD.Decoder Foo_Int64ToStringsEntry
+foo_Int64ToStringsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Foo_Int64ToStringsEntry
+                |> required "key" intDecoder 0
+                |> required "value" JD.string ""
+
+
+foo_Int64ToStringsEntryEncoder : Foo_Int64ToStringsEntry -> JE.Value
+foo_Int64ToStringsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" numericStringEncoder 0 v.key
+            , requiredFieldEncoder "value" JE.string "" v.value
+            ]
+
+
+type alias Foo_Uint32ToStringsEntry =
+    { key : Int -- 1
+    , value : String -- 2
+    }
+
+
+foo_Uint32ToStringsEntryDecoder : JD.Decoder Foo_Uint32ToStringsEntry
+foo_Uint32ToStringsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Foo_Uint32ToStringsEntry
+                |> required "key" intDecoder 0
+                |> required "value" JD.string ""
+
+
+foo_Uint32ToStringsEntryEncoder : Foo_Uint32ToStringsEntry -> JE.Value
+foo_Uint32ToStringsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.int 0 v.key
+            , requiredFieldEncoder "value" JE.string "" v.value
+            ]
+
+
+type alias Foo_Uint64ToStringsEntry =
+    { key : Int -- 1
+    , value : String -- 2
+    }
+
+
+foo_Uint64ToStringsEntryDecoder : JD.Decoder Foo_Uint64ToStringsEntry
+foo_Uint64ToStringsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Foo_Uint64ToStringsEntry
+                |> required "key" intDecoder 0
+                |> required "value" JD.string ""
+
+
+foo_Uint64ToStringsEntryEncoder : Foo_Uint64ToStringsEntry -> JE.Value
+foo_Uint64ToStringsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" numericStringEncoder 0 v.key
+            , requiredFieldEncoder "value" JE.string "" v.value
+            ]
+
+
+type alias Foo_Sint32ToStringsEntry =
+    { key : Int -- 1
+    , value : String -- 2
+    }
+
+
+foo_Sint32ToStringsEntryDecoder : JD.Decoder Foo_Sint32ToStringsEntry
+foo_Sint32ToStringsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Foo_Sint32ToStringsEntry
+                |> required "key" intDecoder 0
+                |> required "value" JD.string ""
+
+
+foo_Sint32ToStringsEntryEncoder : Foo_Sint32ToStringsEntry -> JE.Value
+foo_Sint32ToStringsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.int 0 v.key
+            , requiredFieldEncoder "value" JE.string "" v.value
+            ]
+
+
+type alias Foo_Sint64ToStringsEntry =
+    { key : Int -- 1
+    , value : String -- 2
+    }
+
+
+foo_Sint64ToStringsEntryDecoder : JD.Decoder Foo_Sint64ToStringsEntry
+foo_Sint64ToStringsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Foo_Sint64ToStringsEntry
+                |> required "key" intDecoder 0
+                |> required "value" JD.string ""
+
+
+foo_Sint64ToStringsEntryEncoder : Foo_Sint64ToStringsEntry -> JE.Value
+foo_Sint64ToStringsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" numericStringEncoder 0 v.key
+            , requiredFieldEncoder "value" JE.string "" v.value
+            ]
+
+
+type alias Foo_Fixed32ToBarsEntry =
+    { key : Int -- 1
+    , value : Maybe Bar -- 2
+    }
+
+
+foo_Fixed32ToBarsEntryDecoder : JD.Decoder Foo_Fixed32ToBarsEntry
+foo_Fixed32ToBarsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Foo_Fixed32ToBarsEntry
+                |> required "key" intDecoder 0
+                |> optional "value" barDecoder
+
+
+foo_Fixed32ToBarsEntryEncoder : Foo_Fixed32ToBarsEntry -> JE.Value
+foo_Fixed32ToBarsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.int 0 v.key
+            , optionalEncoder "value" barEncoder v.value
+            ]
+
+
+type alias Foo_Fixed64ToBarsEntry =
+    { key : Int -- 1
+    , value : Maybe Bar -- 2
+    }
+
+
+foo_Fixed64ToBarsEntryDecoder : JD.Decoder Foo_Fixed64ToBarsEntry
+foo_Fixed64ToBarsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Foo_Fixed64ToBarsEntry
+                |> required "key" intDecoder 0
+                |> optional "value" barDecoder
+
+
+foo_Fixed64ToBarsEntryEncoder : Foo_Fixed64ToBarsEntry -> JE.Value
+foo_Fixed64ToBarsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" numericStringEncoder 0 v.key
+            , optionalEncoder "value" barEncoder v.value
+            ]
+
+
+type alias Foo_Sfixed32ToIntsEntry =
+    { key : Int -- 1
+    , value : Int -- 2
+    }
+
+
+foo_Sfixed32ToIntsEntryDecoder : JD.Decoder Foo_Sfixed32ToIntsEntry
+foo_Sfixed32ToIntsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Foo_Sfixed32ToIntsEntry
+                |> required "key" intDecoder 0
+                |> required "value" intDecoder 0
+
+
+foo_Sfixed32ToIntsEntryEncoder : Foo_Sfixed32ToIntsEntry -> JE.Value
+foo_Sfixed32ToIntsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.int 0 v.key
+            , requiredFieldEncoder "value" JE.int 0 v.value
+            ]
+
+
+type alias Foo_Sfixed64ToIntsEntry =
+    { key : Int -- 1
+    , value : Int -- 2
+    }
+
+
+foo_Sfixed64ToIntsEntryDecoder : JD.Decoder Foo_Sfixed64ToIntsEntry
+foo_Sfixed64ToIntsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Foo_Sfixed64ToIntsEntry
+                |> required "key" intDecoder 0
+                |> required "value" intDecoder 0
+
+
+foo_Sfixed64ToIntsEntryEncoder : Foo_Sfixed64ToIntsEntry -> JE.Value
+foo_Sfixed64ToIntsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" numericStringEncoder 0 v.key
+            , requiredFieldEncoder "value" JE.int 0 v.value
+            ]
+
+
+type alias Foo_BoolToStringsEntry =
+    { key : Bool -- 1
+    , value : String -- 2
+    }
+
+
+foo_BoolToStringsEntryDecoder : JD.Decoder Foo_BoolToStringsEntry
+foo_BoolToStringsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Foo_BoolToStringsEntry
+                |> required "key" JD.bool False
+                |> required "value" JD.string ""
+
+
+foo_BoolToStringsEntryEncoder : Foo_BoolToStringsEntry -> JE.Value
+foo_BoolToStringsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.bool False v.key
+            , requiredFieldEncoder "value" JE.string "" v.value
+            ]
+
+
+type alias Foo_BoolToBarsEntry =
+    { key : Bool -- 1
+    , value : Maybe Bar -- 2
+    }
+
+
+foo_BoolToBarsEntryDecoder : JD.Decoder Foo_BoolToBarsEntry
+foo_BoolToBarsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Foo_BoolToBarsEntry
+                |> required "key" JD.bool False
+                |> optional "value" barDecoder
+
+
+foo_BoolToBarsEntryEncoder : Foo_BoolToBarsEntry -> JE.Value
+foo_BoolToBarsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.bool False v.key
+            , optionalEncoder "value" barEncoder v.value
+            ]
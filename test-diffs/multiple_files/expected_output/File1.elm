@@ -1,17 +1,13 @@
-module File1 exposing (..)
+module File1 exposing (File1Message, file1MessageDecoder, file1MessageEncoder)
 
 -- DO NOT EDIT
 -- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
 -- https://github.com/tiziano88/elm-protobuf
 -- source file: file1.proto
 
-import Protobuf exposing (..)
-
 import Json.Decode as JD
 import Json.Encode as JE
-
-
-uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+import Protobuf exposing (..)
 
 
 type alias File1Message =
@@ -21,12 +17,15 @@ type alias File1Message =
 
 file1MessageDecoder : JD.Decoder File1Message
 file1MessageDecoder =
-    JD.lazy <| \_ -> decode File1Message
-        |> required "field" JD.bool False
+    JD.lazy <|
+        \_ ->
+            decode File1Message
+                |> required "field" JD.bool False
 
 
 file1MessageEncoder : File1Message -> JE.Value
 file1MessageEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "field" JE.bool False v.field)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "field" JE.bool False v.field
+            ]
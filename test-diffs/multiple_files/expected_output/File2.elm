@@ -1,17 +1,13 @@
-module File2 exposing (..)
+module File2 exposing (File2Message, file2MessageDecoder, file2MessageEncoder)
 
 -- DO NOT EDIT
 -- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
 -- https://github.com/tiziano88/elm-protobuf
 -- source file: file2.proto
 
-import Protobuf exposing (..)
-
 import Json.Decode as JD
 import Json.Encode as JE
-
-
-uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+import Protobuf exposing (..)
 
 
 type alias File2Message =
@@ -21,12 +17,15 @@ type alias File2Message =
 
 file2MessageDecoder : JD.Decoder File2Message
 file2MessageDecoder =
-    JD.lazy <| \_ -> decode File2Message
-        |> required "field" JD.bool False
+    JD.lazy <|
+        \_ ->
+            decode File2Message
+                |> required "field" JD.bool False
 
 
 file2MessageEncoder : File2Message -> JE.Value
 file2MessageEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "field" JE.bool False v.field)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "field" JE.bool False v.field
+            ]
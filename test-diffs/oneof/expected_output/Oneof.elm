@@ -1,22 +1,18 @@
-module Oneof exposing (..)
+module Oneof exposing (Foo, Foo2, Foo2_FirstOneof(..), Foo_FirstOneof(..), Foo_SecondOneof(..), Forest, Forest_Kind(..), Forest_Kind_(..), InnerMessage, Tree, Tree_Pick, Tree_Pick_(..), foo2Decoder, foo2Encoder, foo2_FirstOneofDecoder, foo2_FirstOneofEncoder, fooDecoder, fooEncoder, foo_FirstOneofDecoder, foo_FirstOneofEncoder, foo_SecondOneofDecoder, foo_SecondOneofEncoder, forestDecoder, forestEncoder, forest_KindDecoder, forest_KindDefault, forest_KindEncoder, forest_Kind_Decoder, forest_Kind_Encoder, innerMessageDecoder, innerMessageEncoder, treeDecoder, treeEncoder, tree_PickDecoder, tree_PickEncoder, tree_Pick_Decoder, tree_Pick_Encoder)
 
 -- DO NOT EDIT
 -- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
 -- https://github.com/tiziano88/elm-protobuf
 -- source file: oneof.proto
 
-import Protobuf exposing (..)
-
 import Json.Decode as JD
 import Json.Encode as JE
-
-
-uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+import Protobuf exposing (..)
 
 
 type alias Foo =
-    { firstOneof : FirstOneof
-    , secondOneof : SecondOneof
+    { firstOneof : Foo_FirstOneof
+    , secondOneof : Foo_SecondOneof
     , syntheticOneof : Maybe String
     , syntheticOneofInnerMessage : Maybe InnerMessage
     }
@@ -24,76 +20,83 @@ type alias Foo =
 
 fooDecoder : JD.Decoder Foo
 fooDecoder =
-    JD.lazy <| \_ -> decode Foo
-        |> field firstOneofDecoder
-        |> field secondOneofDecoder
-        |> optional "syntheticOneof" JD.string
-        |> optional "syntheticOneofInnerMessage" innerMessageDecoder
+    JD.lazy <|
+        \_ ->
+            decode Foo
+                |> field foo_FirstOneofDecoder
+                |> field foo_SecondOneofDecoder
+                |> optional "syntheticOneof" JD.string
+                |> optional "syntheticOneofInnerMessage" innerMessageDecoder
 
 
 fooEncoder : Foo -> JE.Value
 fooEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (firstOneofEncoder v.firstOneof)
-        , (secondOneofEncoder v.secondOneof)
-        , (optionalEncoder "syntheticOneof" JE.string v.syntheticOneof)
-        , (optionalEncoder "syntheticOneofInnerMessage" innerMessageEncoder v.syntheticOneofInnerMessage)
-        ]
-
-
-type FirstOneof
-    = FirstOneofUnspecified
-    | StringField String
-    | IntField Int
-
-
-firstOneofDecoder : JD.Decoder FirstOneof
-firstOneofDecoder =
-    JD.lazy <| \_ -> JD.oneOf
-        [ JD.map StringField (JD.field "stringField" JD.string)
-        , JD.map IntField (JD.field "intField" intDecoder)
-        , JD.succeed FirstOneofUnspecified
-        ]
-
-
-firstOneofEncoder : FirstOneof -> Maybe ( String, JE.Value )
-firstOneofEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ foo_FirstOneofEncoder v.firstOneof
+            , foo_SecondOneofEncoder v.secondOneof
+            , optionalEncoder "syntheticOneof" JE.string v.syntheticOneof
+            , optionalEncoder "syntheticOneofInnerMessage" innerMessageEncoder v.syntheticOneofInnerMessage
+            ]
+
+
+type Foo_FirstOneof
+    = Foo_FirstOneofUnspecified
+    | Foo_StringField String
+    | Foo_IntField Int
+
+
+foo_FirstOneofDecoder : JD.Decoder Foo_FirstOneof
+foo_FirstOneofDecoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map Foo_StringField (JD.field "stringField" JD.string)
+                , JD.map Foo_IntField (JD.field "intField" intDecoder)
+                , JD.succeed Foo_FirstOneofUnspecified
+                ]
+
+
+foo_FirstOneofEncoder : Foo_FirstOneof -> Maybe ( String, JE.Value )
+foo_FirstOneofEncoder v =
     case v of
-        FirstOneofUnspecified ->
+        Foo_FirstOneofUnspecified ->
             Nothing
 
-        StringField x ->
+        Foo_StringField x ->
             Just ( "stringField", JE.string x )
 
-        IntField x ->
+        Foo_IntField x ->
             Just ( "intField", JE.int x )
 
 
-type SecondOneof
-    = SecondOneofUnspecified
-    | BoolField Bool
-    | OtherStringField String
+type Foo_SecondOneof
+    = Foo_SecondOneofUnspecified
+    | Foo_BoolField Bool
+    | Foo_OtherStringField String
 
 
-secondOneofDecoder : JD.Decoder SecondOneof
-secondOneofDecoder =
-    JD.lazy <| \_ -> JD.oneOf
-        [ JD.map BoolField (JD.field "boolField" JD.bool)
-        , JD.map OtherStringField (JD.field "otherStringField" JD.string)
-        , JD.succeed SecondOneofUnspecified
-        ]
+foo_SecondOneofDecoder : JD.Decoder Foo_SecondOneof
+foo_SecondOneofDecoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map Foo_BoolField (JD.field "boolField" JD.bool)
+                , JD.map Foo_OtherStringField (JD.field "otherStringField" JD.string)
+                , JD.succeed Foo_SecondOneofUnspecified
+                ]
 
 
-secondOneofEncoder : SecondOneof -> Maybe ( String, JE.Value )
-secondOneofEncoder v =
+foo_SecondOneofEncoder : Foo_SecondOneof -> Maybe ( String, JE.Value )
+foo_SecondOneofEncoder v =
     case v of
-        SecondOneofUnspecified ->
+        Foo_SecondOneofUnspecified ->
             Nothing
 
-        BoolField x ->
+        Foo_BoolField x ->
             Just ( "boolField", JE.bool x )
 
-        OtherStringField x ->
+        Foo_OtherStringField x ->
             Just ( "otherStringField", JE.string x )
 
 
@@ -104,58 +107,251 @@ type alias InnerMessage =
 
 innerMessageDecoder : JD.Decoder InnerMessage
 innerMessageDecoder =
-    JD.lazy <| \_ -> decode InnerMessage
-        |> required "innerMessageVal" JD.string ""
+    JD.lazy <|
+        \_ ->
+            decode InnerMessage
+                |> required "innerMessageVal" JD.string ""
 
 
 innerMessageEncoder : InnerMessage -> JE.Value
 innerMessageEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "innerMessageVal" JE.string "" v.innerMessageVal)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "innerMessageVal" JE.string "" v.innerMessageVal
+            ]
 
 
 type alias Foo2 =
-    { firstOneof : FirstOneof
+    { firstOneof : Foo2_FirstOneof
     }
 
 
 foo2Decoder : JD.Decoder Foo2
 foo2Decoder =
-    JD.lazy <| \_ -> decode Foo2
-        |> field firstOneofDecoder
+    JD.lazy <|
+        \_ ->
+            decode Foo2
+                |> field foo2_FirstOneofDecoder
 
 
 foo2Encoder : Foo2 -> JE.Value
 foo2Encoder v =
-    JE.object <| List.filterMap identity <|
-        [ (firstOneofEncoder v.firstOneof)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ foo2_FirstOneofEncoder v.firstOneof
+            ]
 
 
-type FirstOneof
-    = FirstOneofUnspecified
-    | StringField String
-    | IntField Int
+{-| Same one-of name as in Foo, scoped by the message name.
+-}
+type Foo2_FirstOneof
+    = Foo2_FirstOneofUnspecified
+    | Foo2_StringField String
+    | Foo2_IntField Int
 
 
-firstOneofDecoder : JD.Decoder FirstOneof
-firstOneofDecoder =
-    JD.lazy <| \_ -> JD.oneOf
-        [ JD.map StringField (JD.field "stringField" JD.string)
-        , JD.map IntField (JD.field "intField" intDecoder)
-        , JD.succeed FirstOneofUnspecified
-        ]
+foo2_FirstOneofDecoder : JD.Decoder Foo2_FirstOneof
+foo2_FirstOneofDecoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map Foo2_StringField (JD.field "stringField" JD.string)
+                , JD.map Foo2_IntField (JD.field "intField" intDecoder)
+                , JD.succeed Foo2_FirstOneofUnspecified
+                ]
 
 
-firstOneofEncoder : FirstOneof -> Maybe ( String, JE.Value )
-firstOneofEncoder v =
+foo2_FirstOneofEncoder : Foo2_FirstOneof -> Maybe ( String, JE.Value )
+foo2_FirstOneofEncoder v =
     case v of
-        FirstOneofUnspecified ->
+        Foo2_FirstOneofUnspecified ->
             Nothing
 
-        StringField x ->
+        Foo2_StringField x ->
             Just ( "stringField", JE.string x )
 
-        IntField x ->
+        Foo2_IntField x ->
             Just ( "intField", JE.int x )
+
+
+type alias Tree =
+    { pick : Tree_Pick_
+    }
+
+
+treeDecoder : JD.Decoder Tree
+treeDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Tree
+                |> field tree_Pick_Decoder
+
+
+treeEncoder : Tree -> JE.Value
+treeEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ tree_Pick_Encoder v.pick
+            ]
+
+
+type Tree_Pick_
+    = Tree_Pick_Unspecified
+    | Tree_Left Tree_Pick
+    | Tree_Right String
+
+
+tree_Pick_Decoder : JD.Decoder Tree_Pick_
+tree_Pick_Decoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map Tree_Left (JD.field "left" tree_PickDecoder)
+                , JD.map Tree_Right (JD.field "right" JD.string)
+                , JD.succeed Tree_Pick_Unspecified
+                ]
+
+
+tree_Pick_Encoder : Tree_Pick_ -> Maybe ( String, JE.Value )
+tree_Pick_Encoder v =
+    case v of
+        Tree_Pick_Unspecified ->
+            Nothing
+
+        Tree_Left x ->
+            Just ( "left", tree_PickEncoder x )
+
+        Tree_Right x ->
+            Just ( "right", JE.string x )
+
+
+{-| One-of named after a nested message.
+-}
+type alias Tree_Pick =
+    { x : String -- 1
+    }
+
+
+tree_PickDecoder : JD.Decoder Tree_Pick
+tree_PickDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Tree_Pick
+                |> required "x" JD.string ""
+
+
+tree_PickEncoder : Tree_Pick -> JE.Value
+tree_PickEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "x" JE.string "" v.x
+            ]
+
+
+type alias Forest =
+    { kind : Forest_Kind_
+    }
+
+
+forestDecoder : JD.Decoder Forest
+forestDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Forest
+                |> field forest_Kind_Decoder
+
+
+forestEncoder : Forest -> JE.Value
+forestEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ forest_Kind_Encoder v.kind
+            ]
+
+
+type Forest_Kind_
+    = Forest_Kind_Unspecified
+    | Forest_Left_ Tree
+    | Forest_Name String
+
+
+forest_Kind_Decoder : JD.Decoder Forest_Kind_
+forest_Kind_Decoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map Forest_Left_ (JD.field "left" treeDecoder)
+                , JD.map Forest_Name (JD.field "name" JD.string)
+                , JD.succeed Forest_Kind_Unspecified
+                ]
+
+
+forest_Kind_Encoder : Forest_Kind_ -> Maybe ( String, JE.Value )
+forest_Kind_Encoder v =
+    case v of
+        Forest_Kind_Unspecified ->
+            Nothing
+
+        Forest_Left_ x ->
+            Just ( "left", treeEncoder x )
+
+        Forest_Name x ->
+            Just ( "name", JE.string x )
+
+
+{-| One-of named after a nested enum, with a field named after one of its values.
+-}
+type Forest_Kind
+    = Forest_KindUnspecified -- 0
+    | Forest_Left -- 1
+    | Forest_KindUnrecognized_ Int
+
+
+forest_KindDecoder : JD.Decoder Forest_Kind
+forest_KindDecoder =
+    let
+        lookup s =
+            case s of
+                "KIND_UNSPECIFIED" ->
+                    Forest_KindUnspecified
+
+                "LEFT" ->
+                    Forest_Left
+
+                _ ->
+                    Forest_KindUnspecified
+
+        fromNumber n =
+            case n of
+                0 ->
+                    Forest_KindUnspecified
+
+                1 ->
+                    Forest_Left
+
+                _ ->
+                    Forest_KindUnrecognized_ n
+    in
+    JD.oneOf [ JD.map lookup JD.string, JD.map fromNumber JD.int ]
+
+
+forest_KindDefault : Forest_Kind
+forest_KindDefault =
+    Forest_KindUnspecified
+
+
+forest_KindEncoder : Forest_Kind -> JE.Value
+forest_KindEncoder v =
+    let
+        lookup s =
+            case s of
+                Forest_KindUnspecified ->
+                    JE.string "KIND_UNSPECIFIED"
+
+                Forest_Left ->
+                    JE.string "LEFT"
+
+                Forest_KindUnrecognized_ n ->
+                    JE.int n
+    in
+    lookup v
@@ -0,0 +1,112 @@
+module Oneof_legacy exposing (Choice(..), FirstOneof(..), Foo, Foo_Bar, choiceDecoder, choiceEncoder, firstOneofDecoder, firstOneofEncoder, fooDecoder, fooEncoder, foo_BarDecoder, foo_BarEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: oneof_legacy.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+
+
+type alias Foo =
+    { firstOneof : FirstOneof
+    }
+
+
+fooDecoder : JD.Decoder Foo
+fooDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Foo
+                |> field firstOneofDecoder
+
+
+fooEncoder : Foo -> JE.Value
+fooEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ firstOneofEncoder v.firstOneof
+            ]
+
+
+type FirstOneof
+    = FirstOneofUnspecified
+    | StringField String
+    | IntField Int
+
+
+firstOneofDecoder : JD.Decoder FirstOneof
+firstOneofDecoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map StringField (JD.field "stringField" JD.string)
+                , JD.map IntField (JD.field "intField" intDecoder)
+                , JD.succeed FirstOneofUnspecified
+                ]
+
+
+firstOneofEncoder : FirstOneof -> Maybe ( String, JE.Value )
+firstOneofEncoder v =
+    case v of
+        FirstOneofUnspecified ->
+            Nothing
+
+        StringField x ->
+            Just ( "stringField", JE.string x )
+
+        IntField x ->
+            Just ( "intField", JE.int x )
+
+
+type alias Foo_Bar =
+    { choice : Choice
+    }
+
+
+foo_BarDecoder : JD.Decoder Foo_Bar
+foo_BarDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Foo_Bar
+                |> field choiceDecoder
+
+
+foo_BarEncoder : Foo_Bar -> JE.Value
+foo_BarEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ choiceEncoder v.choice
+            ]
+
+
+type Choice
+    = ChoiceUnspecified
+    | BoolField Bool
+    | Parent Foo
+
+
+choiceDecoder : JD.Decoder Choice
+choiceDecoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map BoolField (JD.field "boolField" JD.bool)
+                , JD.map Parent (JD.field "parent" fooDecoder)
+                , JD.succeed ChoiceUnspecified
+                ]
+
+
+choiceEncoder : Choice -> Maybe ( String, JE.Value )
+choiceEncoder v =
+    case v of
+        ChoiceUnspecified ->
+            Nothing
+
+        BoolField x ->
+            Just ( "boolField", JE.bool x )
+
+        Parent x ->
+            Just ( "parent", fooEncoder x )
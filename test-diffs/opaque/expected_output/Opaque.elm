@@ -0,0 +1,559 @@
+module Opaque exposing (Empty, Order, Order_FlagsEntry, Order_Payment(..), Order_PricesEntry, Order_Status(..), emptyBinaryDecoder, emptyBinaryEncoder, emptyDecoder, emptyEncoder, getGift, getOrderExtensions, getOrderFlags, getOrderId, getOrderIn, getOrderNote, getOrderParent, getOrderPayment, getOrderPrices, getOrderQuantity, getOrderStatus, getOrderTags, getOrder_FlagsEntryKey, getOrder_FlagsEntryValue, getOrder_PricesEntryKey, getOrder_PricesEntryValue, newEmpty, newOrder, newOrder_FlagsEntry, newOrder_PricesEntry, orderBinaryDecoder, orderBinaryEncoder, orderDecoder, orderEncoder, order_FlagsEntryBinaryDecoder, order_FlagsEntryBinaryEncoder, order_FlagsEntryDecoder, order_FlagsEntryEncoder, order_PaymentBinaryDecoder, order_PaymentBinaryEncoder, order_PaymentDecoder, order_PaymentEncoder, order_PricesEntryBinaryDecoder, order_PricesEntryBinaryEncoder, order_PricesEntryDecoder, order_PricesEntryEncoder, order_StatusBinaryDecoder, order_StatusBinaryEncoder, order_StatusDecoder, order_StatusDefault, order_StatusEncoder, setGift, setOrderExtensions, setOrderFlags, setOrderId, setOrderIn, setOrderNote, setOrderParent, setOrderPayment, setOrderPrices, setOrderQuantity, setOrderStatus, setOrderTags, setOrder_FlagsEntryKey, setOrder_FlagsEntryValue, setOrder_PricesEntryKey, setOrder_PricesEntryValue)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: opaque.proto
+
+import Dict
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+import Protobuf.Binary as PB
+
+
+{-| A customer order.
+-}
+type Order
+    = Order Order_
+
+
+type alias Order_ =
+    { id : String -- 1
+    , quantity : Int -- 2
+    , note : Maybe String -- 3
+    , tags : List String -- 4
+    , prices : Dict.Dict String Int -- 5
+    , flags : List ( Bool, String ) -- 6
+    , status : Order_Status -- 7
+    , parent : Maybe Order -- 8
+    , in_ : Maybe Bool -- 9
+    , payment : Order_Payment
+    , extensions_ : Extensions
+    }
+
+
+orderDecoder : JD.Decoder Order
+orderDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Order_
+                |> mandatory "id" JD.string
+                |> required "quantity" intDecoder 1
+                |> optional "note" JD.string
+                |> repeated "tags" JD.string
+                |> mapEntries "prices" intDecoder
+                |> boolMapEntries "flags" JD.string
+                |> mandatory "status" order_StatusDecoder
+                |> optional "parent" orderDecoder
+                |> optional "in" JD.bool
+                |> field order_PaymentDecoder
+                |> extensions
+                |> JD.map Order
+
+
+orderEncoder : Order -> JE.Value
+orderEncoder (Order v) =
+    JE.object <|
+        List.filterMap identity <|
+            [ mandatoryFieldEncoder "id" JE.string v.id
+            , requiredFieldEncoder "quantity" JE.int 1 v.quantity
+            , optionalEncoder "note" JE.string v.note
+            , repeatedFieldEncoder "tags" JE.string v.tags
+            , mapEntriesFieldEncoder "prices" JE.int v.prices
+            , boolMapEntriesFieldEncoder "flags" JE.string v.flags
+            , mandatoryFieldEncoder "status" order_StatusEncoder v.status
+            , optionalEncoder "parent" orderEncoder v.parent
+            , optionalEncoder "in" JE.bool v.in_
+            , order_PaymentEncoder v.payment
+            ]
+                ++ extensionFields v.extensions_
+
+
+orderBinaryDecoder : PB.Decoder Order
+orderBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Order_
+                |> PB.mandatory 1 PB.string
+                |> PB.defaulted 2 PB.int32 1
+                |> PB.optional 3 PB.string
+                |> PB.repeated 4 PB.string
+                |> PB.mapEntries 5 PB.string PB.int32
+                |> PB.boolMapEntries 6 PB.string
+                |> PB.mandatory 7 (PB.closedEnum order_StatusBinaryDecoder order_StatusBinaryEncoder)
+                |> PB.optional 8 (PB.embedded orderBinaryDecoder orderBinaryEncoder)
+                |> PB.optional 9 PB.bool
+                |> PB.field order_PaymentBinaryDecoder
+                |> PB.field (PB.decode noExtensions)
+                |> PB.map Order
+
+
+orderBinaryEncoder : Order -> PB.Encoder
+orderBinaryEncoder (Order v) =
+    PB.encode
+        [ PB.mandatoryFieldEncoder 1 PB.string v.id
+        , PB.defaultedFieldEncoder 2 PB.int32 1 v.quantity
+        , PB.optionalEncoder 3 PB.string v.note
+        , PB.expandedFieldEncoder 4 PB.string v.tags
+        , PB.mapEntriesFieldEncoder 5 PB.string PB.int32 v.prices
+        , PB.boolMapEntriesFieldEncoder 6 PB.string v.flags
+        , PB.mandatoryFieldEncoder 7 (PB.closedEnum order_StatusBinaryDecoder order_StatusBinaryEncoder) v.status
+        , PB.optionalEncoder 8 (PB.embedded orderBinaryDecoder orderBinaryEncoder) v.parent
+        , PB.optionalEncoder 9 PB.bool v.in_
+        , order_PaymentBinaryEncoder v.payment
+        ]
+
+
+newOrder : String -> Order_Status -> Order
+newOrder id_ status_ =
+    Order
+        { id = id_
+        , quantity = 1
+        , note = Nothing
+        , tags = []
+        , prices = Dict.empty
+        , flags = []
+        , status = status_
+        , parent = Nothing
+        , in_ = Nothing
+        , payment = Order_PaymentUnspecified
+        , extensions_ = noExtensions
+        }
+
+
+getOrderId : Order -> String
+getOrderId (Order v) =
+    v.id
+
+
+setOrderId : String -> Order -> Order
+setOrderId x (Order v) =
+    Order { v | id = x }
+
+
+getOrderQuantity : Order -> Int
+getOrderQuantity (Order v) =
+    v.quantity
+
+
+setOrderQuantity : Int -> Order -> Order
+setOrderQuantity x (Order v) =
+    Order { v | quantity = x }
+
+
+getOrderNote : Order -> Maybe String
+getOrderNote (Order v) =
+    v.note
+
+
+setOrderNote : Maybe String -> Order -> Order
+setOrderNote x (Order v) =
+    Order { v | note = x }
+
+
+getOrderTags : Order -> List String
+getOrderTags (Order v) =
+    v.tags
+
+
+setOrderTags : List String -> Order -> Order
+setOrderTags x (Order v) =
+    Order { v | tags = x }
+
+
+getOrderPrices : Order -> Dict.Dict String Int
+getOrderPrices (Order v) =
+    v.prices
+
+
+setOrderPrices : Dict.Dict String Int -> Order -> Order
+setOrderPrices x (Order v) =
+    Order { v | prices = x }
+
+
+getOrderFlags : Order -> List ( Bool, String )
+getOrderFlags (Order v) =
+    v.flags
+
+
+setOrderFlags : List ( Bool, String ) -> Order -> Order
+setOrderFlags x (Order v) =
+    Order { v | flags = x }
+
+
+getOrderStatus : Order -> Order_Status
+getOrderStatus (Order v) =
+    v.status
+
+
+setOrderStatus : Order_Status -> Order -> Order
+setOrderStatus x (Order v) =
+    Order { v | status = x }
+
+
+getOrderParent : Order -> Maybe Order
+getOrderParent (Order v) =
+    v.parent
+
+
+setOrderParent : Maybe Order -> Order -> Order
+setOrderParent x (Order v) =
+    Order { v | parent = x }
+
+
+getOrderIn : Order -> Maybe Bool
+getOrderIn (Order v) =
+    v.in_
+
+
+setOrderIn : Maybe Bool -> Order -> Order
+setOrderIn x (Order v) =
+    Order { v | in_ = x }
+
+
+getOrderPayment : Order -> Order_Payment
+getOrderPayment (Order v) =
+    v.payment
+
+
+setOrderPayment : Order_Payment -> Order -> Order
+setOrderPayment x (Order v) =
+    Order { v | payment = x }
+
+
+getOrderExtensions : Order -> Extensions
+getOrderExtensions (Order v) =
+    v.extensions_
+
+
+setOrderExtensions : Extensions -> Order -> Order
+setOrderExtensions x (Order v) =
+    Order { v | extensions_ = x }
+
+
+type Order_Payment
+    = Order_PaymentUnspecified
+    | Order_Card String
+    | Order_Voucher String
+
+
+order_PaymentDecoder : JD.Decoder Order_Payment
+order_PaymentDecoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map Order_Card (JD.field "card" JD.string)
+                , JD.map Order_Voucher (JD.field "voucher" JD.string)
+                , JD.succeed Order_PaymentUnspecified
+                ]
+
+
+order_PaymentEncoder : Order_Payment -> Maybe ( String, JE.Value )
+order_PaymentEncoder v =
+    case v of
+        Order_PaymentUnspecified ->
+            Nothing
+
+        Order_Card x ->
+            Just ( "card", JE.string x )
+
+        Order_Voucher x ->
+            Just ( "voucher", JE.string x )
+
+
+order_PaymentBinaryDecoder : PB.Decoder Order_Payment
+order_PaymentBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.oneOf Order_PaymentUnspecified
+                [ PB.variant 10 Order_Card PB.string
+                , PB.variant 11 Order_Voucher PB.string
+                ]
+
+
+order_PaymentBinaryEncoder : Order_Payment -> PB.FieldEncoder
+order_PaymentBinaryEncoder v =
+    case v of
+        Order_PaymentUnspecified ->
+            PB.noFieldEncoder
+
+        Order_Card x ->
+            PB.fieldEncoder 10 PB.string x
+
+        Order_Voucher x ->
+            PB.fieldEncoder 11 PB.string x
+
+
+type Order_Status
+    = Order_Pending -- 0
+    | Order_Shipped -- 1
+
+
+order_StatusDecoder : JD.Decoder Order_Status
+order_StatusDecoder =
+    let
+        lookup s =
+            case s of
+                "PENDING" ->
+                    JD.succeed Order_Pending
+
+                "SHIPPED" ->
+                    JD.succeed Order_Shipped
+
+                _ ->
+                    JD.fail ("unknown value \"" ++ s ++ "\"")
+
+        fromNumber n =
+            case n of
+                0 ->
+                    JD.succeed Order_Pending
+
+                1 ->
+                    JD.succeed Order_Shipped
+
+                _ ->
+                    JD.fail ("unknown value " ++ String.fromInt n)
+    in
+    JD.oneOf [ JD.andThen lookup JD.string, JD.andThen fromNumber JD.int ]
+
+
+order_StatusDefault : Order_Status
+order_StatusDefault =
+    Order_Pending
+
+
+order_StatusEncoder : Order_Status -> JE.Value
+order_StatusEncoder v =
+    let
+        lookup s =
+            case s of
+                Order_Pending ->
+                    JE.string "PENDING"
+
+                Order_Shipped ->
+                    JE.string "SHIPPED"
+    in
+    lookup v
+
+
+order_StatusBinaryDecoder : Int -> Maybe Order_Status
+order_StatusBinaryDecoder v =
+    case v of
+        0 ->
+            Just Order_Pending
+
+        1 ->
+            Just Order_Shipped
+
+        _ ->
+            Nothing
+
+
+order_StatusBinaryEncoder : Order_Status -> Int
+order_StatusBinaryEncoder v =
+    case v of
+        Order_Pending ->
+            0
+
+        Order_Shipped ->
+            1
+
+
+type Order_PricesEntry
+    = Order_PricesEntry Order_PricesEntry_
+
+
+type alias Order_PricesEntry_ =
+    { key : Maybe String -- 1
+    , value : Maybe Int -- 2
+    }
+
+
+order_PricesEntryDecoder : JD.Decoder Order_PricesEntry
+order_PricesEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Order_PricesEntry_
+                |> optional "key" JD.string
+                |> optional "value" intDecoder
+                |> JD.map Order_PricesEntry
+
+
+order_PricesEntryEncoder : Order_PricesEntry -> JE.Value
+order_PricesEntryEncoder (Order_PricesEntry v) =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "key" JE.string v.key
+            , optionalEncoder "value" JE.int v.value
+            ]
+
+
+order_PricesEntryBinaryDecoder : PB.Decoder Order_PricesEntry
+order_PricesEntryBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Order_PricesEntry_
+                |> PB.optional 1 PB.string
+                |> PB.optional 2 PB.int32
+                |> PB.map Order_PricesEntry
+
+
+order_PricesEntryBinaryEncoder : Order_PricesEntry -> PB.Encoder
+order_PricesEntryBinaryEncoder (Order_PricesEntry v) =
+    PB.encode
+        [ PB.optionalEncoder 1 PB.string v.key
+        , PB.optionalEncoder 2 PB.int32 v.value
+        ]
+
+
+newOrder_PricesEntry : Order_PricesEntry
+newOrder_PricesEntry =
+    Order_PricesEntry
+        { key = Nothing
+        , value = Nothing
+        }
+
+
+getOrder_PricesEntryKey : Order_PricesEntry -> Maybe String
+getOrder_PricesEntryKey (Order_PricesEntry v) =
+    v.key
+
+
+setOrder_PricesEntryKey : Maybe String -> Order_PricesEntry -> Order_PricesEntry
+setOrder_PricesEntryKey x (Order_PricesEntry v) =
+    Order_PricesEntry { v | key = x }
+
+
+getOrder_PricesEntryValue : Order_PricesEntry -> Maybe Int
+getOrder_PricesEntryValue (Order_PricesEntry v) =
+    v.value
+
+
+setOrder_PricesEntryValue : Maybe Int -> Order_PricesEntry -> Order_PricesEntry
+setOrder_PricesEntryValue x (Order_PricesEntry v) =
+    Order_PricesEntry { v | value = x }
+
+
+type Order_FlagsEntry
+    = Order_FlagsEntry Order_FlagsEntry_
+
+
+type alias Order_FlagsEntry_ =
+    { key : Maybe Bool -- 1
+    , value : Maybe String -- 2
+    }
+
+
+order_FlagsEntryDecoder : JD.Decoder Order_FlagsEntry
+order_FlagsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Order_FlagsEntry_
+                |> optional "key" JD.bool
+                |> optional "value" JD.string
+                |> JD.map Order_FlagsEntry
+
+
+order_FlagsEntryEncoder : Order_FlagsEntry -> JE.Value
+order_FlagsEntryEncoder (Order_FlagsEntry v) =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "key" JE.bool v.key
+            , optionalEncoder "value" JE.string v.value
+            ]
+
+
+order_FlagsEntryBinaryDecoder : PB.Decoder Order_FlagsEntry
+order_FlagsEntryBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Order_FlagsEntry_
+                |> PB.optional 1 PB.bool
+                |> PB.optional 2 PB.string
+                |> PB.map Order_FlagsEntry
+
+
+order_FlagsEntryBinaryEncoder : Order_FlagsEntry -> PB.Encoder
+order_FlagsEntryBinaryEncoder (Order_FlagsEntry v) =
+    PB.encode
+        [ PB.optionalEncoder 1 PB.bool v.key
+        , PB.optionalEncoder 2 PB.string v.value
+        ]
+
+
+newOrder_FlagsEntry : Order_FlagsEntry
+newOrder_FlagsEntry =
+    Order_FlagsEntry
+        { key = Nothing
+        , value = Nothing
+        }
+
+
+getOrder_FlagsEntryKey : Order_FlagsEntry -> Maybe Bool
+getOrder_FlagsEntryKey (Order_FlagsEntry v) =
+    v.key
+
+
+setOrder_FlagsEntryKey : Maybe Bool -> Order_FlagsEntry -> Order_FlagsEntry
+setOrder_FlagsEntryKey x (Order_FlagsEntry v) =
+    Order_FlagsEntry { v | key = x }
+
+
+getOrder_FlagsEntryValue : Order_FlagsEntry -> Maybe String
+getOrder_FlagsEntryValue (Order_FlagsEntry v) =
+    v.value
+
+
+setOrder_FlagsEntryValue : Maybe String -> Order_FlagsEntry -> Order_FlagsEntry
+setOrder_FlagsEntryValue x (Order_FlagsEntry v) =
+    Order_FlagsEntry { v | value = x }
+
+
+type Empty
+    = Empty Empty_
+
+
+type alias Empty_ =
+    {}
+
+
+emptyDecoder : JD.Decoder Empty
+emptyDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Empty_
+                |> JD.map Empty
+
+
+emptyEncoder : Empty -> JE.Value
+emptyEncoder (Empty v) =
+    JE.object <| List.filterMap identity <| []
+
+
+emptyBinaryDecoder : PB.Decoder Empty
+emptyBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Empty_
+                |> PB.map Empty
+
+
+emptyBinaryEncoder : Empty -> PB.Encoder
+emptyBinaryEncoder (Empty v) =
+    PB.encode []
+
+
+newEmpty : Empty
+newEmpty =
+    Empty {}
+
+
+getGift : Order -> Maybe String
+getGift v =
+    getExtension "[shop.gift]" JD.string (getOrderExtensions v)
+
+
+setGift : Maybe String -> Order -> Order
+setGift x v =
+    setOrderExtensions (setExtension "[shop.gift]" JE.string x (getOrderExtensions v)) v
@@ -0,0 +1,35 @@
+module Proto.Acme.Billing.V1.Invoice exposing (Invoice, invoiceDecoder, invoiceEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: invoice.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Proto.Acme.Common.V1.Common as ProtoAcmeCommonV1Common
+import Protobuf exposing (..)
+
+
+type alias Invoice =
+    { id : String -- 1
+    , total : Maybe ProtoAcmeCommonV1Common.Money -- 2
+    }
+
+
+invoiceDecoder : JD.Decoder Invoice
+invoiceDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Invoice
+                |> required "id" JD.string ""
+                |> optional "total" ProtoAcmeCommonV1Common.moneyDecoder
+
+
+invoiceEncoder : Invoice -> JE.Value
+invoiceEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "id" JE.string "" v.id
+            , optionalEncoder "total" ProtoAcmeCommonV1Common.moneyEncoder v.total
+            ]
@@ -0,0 +1,34 @@
+module Proto.Acme.Common.V1.Common exposing (Money, moneyDecoder, moneyEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: common.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+
+
+type alias Money =
+    { currencyCode : String -- 1
+    , units : Int -- 2
+    }
+
+
+moneyDecoder : JD.Decoder Money
+moneyDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Money
+                |> required "currencyCode" JD.string ""
+                |> required "units" intDecoder 0
+
+
+moneyEncoder : Money -> JE.Value
+moneyEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "currencyCode" JE.string "" v.currencyCode
+            , requiredFieldEncoder "units" numericStringEncoder 0 v.units
+            ]
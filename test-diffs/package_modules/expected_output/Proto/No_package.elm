@@ -0,0 +1,33 @@
+module Proto.No_package exposing (NoPackage, noPackageDecoder, noPackageEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: no_package.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+
+
+{-| Files without a package are named after their path.
+-}
+type alias NoPackage =
+    { field : Bool -- 1
+    }
+
+
+noPackageDecoder : JD.Decoder NoPackage
+noPackageDecoder =
+    JD.lazy <|
+        \_ ->
+            decode NoPackage
+                |> required "field" JD.bool False
+
+
+noPackageEncoder : NoPackage -> JE.Value
+noPackageEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "field" JE.bool False v.field
+            ]
@@ -0,0 +1,371 @@
+module Proto2 exposing (Level(..), Settings, Settings_Child, Settings_Mode(..), Settings_Parent(..), levelBinaryDecoder, levelBinaryEncoder, levelDecoder, levelDefault, levelEncoder, settingsBinaryDecoder, settingsBinaryEncoder, settingsDecoder, settingsEncoder, settings_ChildBinaryDecoder, settings_ChildBinaryEncoder, settings_ChildDecoder, settings_ChildEncoder, settings_ModeBinaryDecoder, settings_ModeBinaryEncoder, settings_ModeDecoder, settings_ModeDefault, settings_ModeEncoder, unwrapSettings_Parent)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: proto2.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+import Protobuf.Binary as PB
+
+
+type Level
+    = Low -- 1
+    | Medium -- 2
+    | High -- 3
+
+
+levelDecoder : JD.Decoder Level
+levelDecoder =
+    let
+        lookup s =
+            case s of
+                "LOW" ->
+                    JD.succeed Low
+
+                "MEDIUM" ->
+                    JD.succeed Medium
+
+                "MIDDLE" ->
+                    JD.succeed Medium
+
+                "HIGH" ->
+                    JD.succeed High
+
+                _ ->
+                    JD.fail ("unknown value \"" ++ s ++ "\"")
+
+        fromNumber n =
+            case n of
+                1 ->
+                    JD.succeed Low
+
+                2 ->
+                    JD.succeed Medium
+
+                3 ->
+                    JD.succeed High
+
+                _ ->
+                    JD.fail ("unknown value " ++ String.fromInt n)
+    in
+    JD.oneOf [ JD.andThen lookup JD.string, JD.andThen fromNumber JD.int ]
+
+
+levelDefault : Level
+levelDefault =
+    Low
+
+
+levelEncoder : Level -> JE.Value
+levelEncoder v =
+    let
+        lookup s =
+            case s of
+                Low ->
+                    JE.string "LOW"
+
+                Medium ->
+                    JE.string "MEDIUM"
+
+                High ->
+                    JE.string "HIGH"
+    in
+    lookup v
+
+
+levelBinaryDecoder : Int -> Maybe Level
+levelBinaryDecoder v =
+    case v of
+        1 ->
+            Just Low
+
+        2 ->
+            Just Medium
+
+        3 ->
+            Just High
+
+        _ ->
+            Nothing
+
+
+levelBinaryEncoder : Level -> Int
+levelBinaryEncoder v =
+    case v of
+        Low ->
+            1
+
+        Medium ->
+            2
+
+        High ->
+            3
+
+
+type alias Settings =
+    { retries : Maybe Int -- 1
+    , name : Maybe String -- 2
+    , level : Maybe Level -- 3
+    , parent : Settings_Parent -- 4
+    , timeout : Int -- 5
+    , port_ : Int -- 6
+    , offset : Int -- 7
+    , quota : Int -- 8
+    , ratio : Float -- 9
+    , limit : Float -- 10
+    , missing : Float -- 11
+    , scale : Float -- 12
+    , enabled : Bool -- 13
+    , greeting : String -- 14
+    , magic : Bytes -- 15
+    , fallback : Level -- 16
+    , mode : Settings_Mode -- 17
+    , id : String -- 18
+    , version : Int -- 19
+    , requiredLevel : Level -- 20
+    , child : Settings_Child -- 21
+    , samples : List Int -- 22
+    }
+
+
+settingsDecoder : JD.Decoder Settings
+settingsDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Settings
+                |> optional "retries" intDecoder
+                |> optional "name" JD.string
+                |> optional "level" levelDecoder
+                |> wrapped Settings_Parent (optional "parent" settingsDecoder)
+                |> required "timeout" intDecoder (-30)
+                |> required "port" intDecoder 8080
+                |> required "offset" intDecoder (-9007199254740993)
+                |> required "quota" intDecoder 18446744073709551615
+                |> required "ratio" JD.float (-1.5)
+                |> required "limit" JD.float (1 / 0)
+                |> required "missing" JD.float (0 / 0)
+                |> required "scale" JD.float 100.0
+                |> required "enabled" JD.bool True
+                |> required "greeting" JD.string "say \"hi\"\n\tthere"
+                |> required "magic" bytesFieldDecoder [ 1, 2, 122, 92 ]
+                |> required "fallback" levelDecoder Medium
+                |> required "mode" settings_ModeDecoder Settings_Fast
+                |> mandatory "id" JD.string
+                |> mandatory "version" intDecoder
+                |> mandatory "requiredLevel" levelDecoder
+                |> mandatory "child" settings_ChildDecoder
+                |> repeated "samples" intDecoder
+
+
+settingsEncoder : Settings -> JE.Value
+settingsEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "retries" JE.int v.retries
+            , optionalEncoder "name" JE.string v.name
+            , optionalEncoder "level" levelEncoder v.level
+            , optionalEncoder "parent" settingsEncoder (unwrapSettings_Parent v.parent)
+            , requiredFieldEncoder "timeout" JE.int (-30) v.timeout
+            , requiredFieldEncoder "port" JE.int 8080 v.port_
+            , requiredFieldEncoder "offset" numericStringEncoder (-9007199254740993) v.offset
+            , requiredFieldEncoder "quota" numericStringEncoder 18446744073709551615 v.quota
+            , requiredFieldEncoder "ratio" JE.float (-1.5) v.ratio
+            , requiredFieldEncoder "limit" JE.float (1 / 0) v.limit
+            , requiredFieldEncoder "missing" JE.float (0 / 0) v.missing
+            , requiredFieldEncoder "scale" JE.float 100.0 v.scale
+            , requiredFieldEncoder "enabled" JE.bool True v.enabled
+            , requiredFieldEncoder "greeting" JE.string "say \"hi\"\n\tthere" v.greeting
+            , requiredFieldEncoder "magic" bytesFieldEncoder [ 1, 2, 122, 92 ] v.magic
+            , requiredFieldEncoder "fallback" levelEncoder Medium v.fallback
+            , requiredFieldEncoder "mode" settings_ModeEncoder Settings_Fast v.mode
+            , mandatoryFieldEncoder "id" JE.string v.id
+            , mandatoryFieldEncoder "version" numericStringEncoder v.version
+            , mandatoryFieldEncoder "requiredLevel" levelEncoder v.requiredLevel
+            , mandatoryFieldEncoder "child" settings_ChildEncoder v.child
+            , repeatedFieldEncoder "samples" JE.int v.samples
+            ]
+
+
+settingsBinaryDecoder : PB.Decoder Settings
+settingsBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Settings
+                |> PB.optional 1 PB.int32
+                |> PB.optional 2 PB.string
+                |> PB.optional 3 (PB.closedEnum levelBinaryDecoder levelBinaryEncoder)
+                |> PB.wrapped Settings_Parent (PB.optional 4 (PB.embedded settingsBinaryDecoder settingsBinaryEncoder))
+                |> PB.defaulted 5 PB.int32 (-30)
+                |> PB.defaulted 6 PB.uint32 8080
+                |> PB.defaulted 7 PB.int64 (-9007199254740993)
+                |> PB.defaulted 8 PB.uint64 18446744073709551615
+                |> PB.defaulted 9 PB.double (-1.5)
+                |> PB.defaulted 10 PB.float (1 / 0)
+                |> PB.defaulted 11 PB.double (0 / 0)
+                |> PB.defaulted 12 PB.float 100.0
+                |> PB.defaulted 13 PB.bool True
+                |> PB.defaulted 14 PB.string "say \"hi\"\n\tthere"
+                |> PB.defaulted 15 PB.bytes [ 1, 2, 122, 92 ]
+                |> PB.defaulted 16 (PB.closedEnum levelBinaryDecoder levelBinaryEncoder) Medium
+                |> PB.defaulted 17 (PB.closedEnum settings_ModeBinaryDecoder settings_ModeBinaryEncoder) Settings_Fast
+                |> PB.mandatory 18 PB.string
+                |> PB.mandatory 19 PB.int64
+                |> PB.mandatory 20 (PB.closedEnum levelBinaryDecoder levelBinaryEncoder)
+                |> PB.mandatory 21 (PB.embedded settings_ChildBinaryDecoder settings_ChildBinaryEncoder)
+                |> PB.repeated 22 PB.int32
+
+
+settingsBinaryEncoder : Settings -> PB.Encoder
+settingsBinaryEncoder v =
+    PB.encode
+        [ PB.optionalEncoder 1 PB.int32 v.retries
+        , PB.optionalEncoder 2 PB.string v.name
+        , PB.optionalEncoder 3 (PB.closedEnum levelBinaryDecoder levelBinaryEncoder) v.level
+        , PB.optionalEncoder 4 (PB.embedded settingsBinaryDecoder settingsBinaryEncoder) (unwrapSettings_Parent v.parent)
+        , PB.defaultedFieldEncoder 5 PB.int32 (-30) v.timeout
+        , PB.defaultedFieldEncoder 6 PB.uint32 8080 v.port_
+        , PB.defaultedFieldEncoder 7 PB.int64 (-9007199254740993) v.offset
+        , PB.defaultedFieldEncoder 8 PB.uint64 18446744073709551615 v.quota
+        , PB.defaultedFieldEncoder 9 PB.double (-1.5) v.ratio
+        , PB.defaultedFieldEncoder 10 PB.float (1 / 0) v.limit
+        , PB.defaultedFieldEncoder 11 PB.double (0 / 0) v.missing
+        , PB.defaultedFieldEncoder 12 PB.float 100.0 v.scale
+        , PB.defaultedFieldEncoder 13 PB.bool True v.enabled
+        , PB.defaultedFieldEncoder 14 PB.string "say \"hi\"\n\tthere" v.greeting
+        , PB.defaultedFieldEncoder 15 PB.bytes [ 1, 2, 122, 92 ] v.magic
+        , PB.defaultedFieldEncoder 16 (PB.closedEnum levelBinaryDecoder levelBinaryEncoder) Medium v.fallback
+        , PB.defaultedFieldEncoder 17 (PB.closedEnum settings_ModeBinaryDecoder settings_ModeBinaryEncoder) Settings_Fast v.mode
+        , PB.mandatoryFieldEncoder 18 PB.string v.id
+        , PB.mandatoryFieldEncoder 19 PB.int64 v.version
+        , PB.mandatoryFieldEncoder 20 (PB.closedEnum levelBinaryDecoder levelBinaryEncoder) v.requiredLevel
+        , PB.mandatoryFieldEncoder 21 (PB.embedded settings_ChildBinaryDecoder settings_ChildBinaryEncoder) v.child
+        , PB.expandedFieldEncoder 22 PB.int32 v.samples
+        ]
+
+
+type Settings_Parent
+    = Settings_Parent (Maybe Settings)
+
+
+unwrapSettings_Parent : Settings_Parent -> Maybe Settings
+unwrapSettings_Parent (Settings_Parent v) =
+    v
+
+
+type Settings_Mode
+    = Settings_Slow -- 0
+    | Settings_Fast -- 1
+
+
+settings_ModeDecoder : JD.Decoder Settings_Mode
+settings_ModeDecoder =
+    let
+        lookup s =
+            case s of
+                "SLOW" ->
+                    JD.succeed Settings_Slow
+
+                "FAST" ->
+                    JD.succeed Settings_Fast
+
+                _ ->
+                    JD.fail ("unknown value \"" ++ s ++ "\"")
+
+        fromNumber n =
+            case n of
+                0 ->
+                    JD.succeed Settings_Slow
+
+                1 ->
+                    JD.succeed Settings_Fast
+
+                _ ->
+                    JD.fail ("unknown value " ++ String.fromInt n)
+    in
+    JD.oneOf [ JD.andThen lookup JD.string, JD.andThen fromNumber JD.int ]
+
+
+settings_ModeDefault : Settings_Mode
+settings_ModeDefault =
+    Settings_Slow
+
+
+settings_ModeEncoder : Settings_Mode -> JE.Value
+settings_ModeEncoder v =
+    let
+        lookup s =
+            case s of
+                Settings_Slow ->
+                    JE.string "SLOW"
+
+                Settings_Fast ->
+                    JE.string "FAST"
+    in
+    lookup v
+
+
+settings_ModeBinaryDecoder : Int -> Maybe Settings_Mode
+settings_ModeBinaryDecoder v =
+    case v of
+        0 ->
+            Just Settings_Slow
+
+        1 ->
+            Just Settings_Fast
+
+        _ ->
+            Nothing
+
+
+settings_ModeBinaryEncoder : Settings_Mode -> Int
+settings_ModeBinaryEncoder v =
+    case v of
+        Settings_Slow ->
+            0
+
+        Settings_Fast ->
+            1
+
+
+type alias Settings_Child =
+    { active : Bool -- 1
+    , payload : Maybe Bytes -- 2
+    }
+
+
+settings_ChildDecoder : JD.Decoder Settings_Child
+settings_ChildDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Settings_Child
+                |> mandatory "active" JD.bool
+                |> optional "payload" bytesFieldDecoder
+
+
+settings_ChildEncoder : Settings_Child -> JE.Value
+settings_ChildEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ mandatoryFieldEncoder "active" JE.bool v.active
+            , optionalEncoder "payload" bytesFieldEncoder v.payload
+            ]
+
+
+settings_ChildBinaryDecoder : PB.Decoder Settings_Child
+settings_ChildBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Settings_Child
+                |> PB.mandatory 1 PB.bool
+                |> PB.optional 2 PB.bytes
+
+
+settings_ChildBinaryEncoder : Settings_Child -> PB.Encoder
+settings_ChildBinaryEncoder v =
+    PB.encode
+        [ PB.mandatoryFieldEncoder 1 PB.bool v.active
+        , PB.optionalEncoder 2 PB.bytes v.payload
+        ]
@@ -0,0 +1,78 @@
+module Proto2_exact exposing (Counter, counterBinaryDecoder, counterBinaryEncoder, counterDecoder, counterEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: proto2_exact.proto
+
+import Bytes
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+import Protobuf.Binary as PB
+
+
+type alias Counter =
+    { offset : Protobuf.Int64 -- 1
+    , quota : Protobuf.Int64 -- 2
+    , start : Protobuf.Int64 -- 3
+    , total : Maybe Protobuf.Int64 -- 4
+    , id : Protobuf.Int64 -- 5
+    , magic : Bytes.Bytes -- 6
+    , blank : Bytes.Bytes -- 7
+    }
+
+
+counterDecoder : JD.Decoder Counter
+counterDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Counter
+                |> required "offset" Protobuf.int64Decoder (Protobuf.int64FromBits 4294967295 4292870143)
+                |> required "quota" Protobuf.uint64Decoder (Protobuf.uint64FromBits 4294967295 4294967295)
+                |> required "start" Protobuf.int64Decoder (Protobuf.int64FromBits 5 0)
+                |> optional "total" Protobuf.int64Decoder
+                |> mandatory "id" Protobuf.uint64Decoder
+                |> required "magic" elmBytesFieldDecoder (listToElmBytes [ 97, 98, 99 ])
+                |> required "blank" elmBytesFieldDecoder emptyBytes
+
+
+counterEncoder : Counter -> JE.Value
+counterEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "offset" Protobuf.int64Encoder (Protobuf.int64FromBits 4294967295 4292870143) v.offset
+            , requiredFieldEncoder "quota" Protobuf.int64Encoder (Protobuf.uint64FromBits 4294967295 4294967295) v.quota
+            , requiredFieldEncoder "start" Protobuf.int64Encoder (Protobuf.int64FromBits 5 0) v.start
+            , optionalEncoder "total" Protobuf.int64Encoder v.total
+            , mandatoryFieldEncoder "id" Protobuf.int64Encoder v.id
+            , mandatoryFieldEncoder "magic" elmBytesFieldEncoder v.magic
+            , mandatoryFieldEncoder "blank" elmBytesFieldEncoder v.blank
+            ]
+
+
+counterBinaryDecoder : PB.Decoder Counter
+counterBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Counter
+                |> PB.defaulted 1 PB.exactInt64 (Protobuf.int64FromBits 4294967295 4292870143)
+                |> PB.defaulted 2 PB.exactUint64 (Protobuf.uint64FromBits 4294967295 4294967295)
+                |> PB.defaulted 3 PB.exactSfixed64 (Protobuf.int64FromBits 5 0)
+                |> PB.optional 4 PB.exactInt64
+                |> PB.mandatory 5 PB.exactFixed64
+                |> PB.defaulted 6 PB.elmBytes (listToElmBytes [ 97, 98, 99 ])
+                |> PB.defaulted 7 PB.elmBytes emptyBytes
+
+
+counterBinaryEncoder : Counter -> PB.Encoder
+counterBinaryEncoder v =
+    PB.encode
+        [ PB.defaultedFieldEncoder 1 PB.exactInt64 (Protobuf.int64FromBits 4294967295 4292870143) v.offset
+        , PB.defaultedFieldEncoder 2 PB.exactUint64 (Protobuf.uint64FromBits 4294967295 4294967295) v.quota
+        , PB.defaultedFieldEncoder 3 PB.exactSfixed64 (Protobuf.int64FromBits 5 0) v.start
+        , PB.optionalEncoder 4 PB.exactInt64 v.total
+        , PB.mandatoryFieldEncoder 5 PB.exactFixed64 v.id
+        , PB.mandatoryFieldEncoder 6 PB.elmBytes v.magic
+        , PB.mandatoryFieldEncoder 7 PB.elmBytes v.blank
+        ]
@@ -0,0 +1,42 @@
+module Qualified_imports exposing (Response, responseDecoder, responseEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: qualified_imports.proto
+
+import Common.First as CommonFirst
+import Common.Second as CommonSecond
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+
+
+type alias Response =
+    { firstStatus : Maybe CommonFirst.Status -- 1
+    , secondStatus : Maybe CommonSecond.Status -- 2
+    , firstFoo : CommonFirst.Foo -- 3
+    , secondFoos : List CommonSecond.Foo -- 4
+    }
+
+
+responseDecoder : JD.Decoder Response
+responseDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Response
+                |> optional "firstStatus" CommonFirst.statusDecoder
+                |> optional "secondStatus" CommonSecond.statusDecoder
+                |> required "firstFoo" CommonFirst.fooDecoder CommonFirst.fooDefault
+                |> repeated "secondFoos" CommonSecond.fooDecoder
+
+
+responseEncoder : Response -> JE.Value
+responseEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "firstStatus" CommonFirst.statusEncoder v.firstStatus
+            , optionalEncoder "secondStatus" CommonSecond.statusEncoder v.secondStatus
+            , requiredFieldEncoder "firstFoo" CommonFirst.fooEncoder CommonFirst.fooDefault v.firstFoo
+            , repeatedFieldEncoder "secondFoos" CommonSecond.fooEncoder v.secondFoos
+            ]
@@ -0,0 +1,458 @@
+module Recursive exposing (Forest, Graph, Graph_Child, Graph_Child_(..), Leaf, Node, Node_Child(..), Node_Children(..), Node_Choice(..), Node_MaybeNext(..), Node_Named(..), Node_NamedEntry, Tree, Tree_Branch, Tree_Branch_Subtrees(..), Tree_Root(..), forestBinaryDecoder, forestBinaryEncoder, forestDecoder, forestEncoder, graphBinaryDecoder, graphBinaryEncoder, graphDecoder, graphEncoder, graph_ChildBinaryDecoder, graph_ChildBinaryEncoder, graph_ChildDecoder, graph_ChildEncoder, leafBinaryDecoder, leafBinaryEncoder, leafDecoder, leafEncoder, nodeBinaryDecoder, nodeBinaryEncoder, nodeDecoder, nodeEncoder, node_ChoiceBinaryDecoder, node_ChoiceBinaryEncoder, node_ChoiceDecoder, node_ChoiceEncoder, node_NamedEntryBinaryDecoder, node_NamedEntryBinaryEncoder, node_NamedEntryDecoder, node_NamedEntryEncoder, treeBinaryDecoder, treeBinaryEncoder, treeDecoder, treeEncoder, tree_BranchBinaryDecoder, tree_BranchBinaryEncoder, tree_BranchDecoder, tree_BranchEncoder, unwrapGraph_Child_, unwrapNode_Child, unwrapNode_Children, unwrapNode_MaybeNext, unwrapNode_Named, unwrapTree_Branch_Subtrees, unwrapTree_Root)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: recursive.proto
+
+import Dict
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+import Protobuf.Binary as PB
+
+
+type alias Node =
+    { label : String -- 1
+    , child : Node_Child -- 2
+    , children : Node_Children -- 3
+    , named : Node_Named -- 4
+    , choice : Node_Choice
+    , maybeNext : Node_MaybeNext
+    }
+
+
+nodeDecoder : JD.Decoder Node
+nodeDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Node
+                |> required "label" JD.string ""
+                |> wrapped Node_Child (optional "child" nodeDecoder)
+                |> wrapped Node_Children (repeated "children" nodeDecoder)
+                |> wrapped Node_Named (mapEntries "named" nodeDecoder)
+                |> field node_ChoiceDecoder
+                |> wrapped Node_MaybeNext (optional "maybeNext" nodeDecoder)
+
+
+nodeEncoder : Node -> JE.Value
+nodeEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "label" JE.string "" v.label
+            , optionalEncoder "child" nodeEncoder (unwrapNode_Child v.child)
+            , repeatedFieldEncoder "children" nodeEncoder (unwrapNode_Children v.children)
+            , mapEntriesFieldEncoder "named" nodeEncoder (unwrapNode_Named v.named)
+            , node_ChoiceEncoder v.choice
+            , optionalEncoder "maybeNext" nodeEncoder (unwrapNode_MaybeNext v.maybeNext)
+            ]
+
+
+nodeBinaryDecoder : PB.Decoder Node
+nodeBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Node
+                |> PB.required 1 PB.string
+                |> PB.wrapped Node_Child (PB.optional 2 (PB.embedded nodeBinaryDecoder nodeBinaryEncoder))
+                |> PB.wrapped Node_Children (PB.repeated 3 (PB.embedded nodeBinaryDecoder nodeBinaryEncoder))
+                |> PB.wrapped Node_Named (PB.mapEntries 4 PB.string (PB.embedded nodeBinaryDecoder nodeBinaryEncoder))
+                |> PB.field node_ChoiceBinaryDecoder
+                |> PB.wrapped Node_MaybeNext (PB.optional 5 (PB.embedded nodeBinaryDecoder nodeBinaryEncoder))
+
+
+nodeBinaryEncoder : Node -> PB.Encoder
+nodeBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.string v.label
+        , PB.optionalEncoder 2 (PB.embedded nodeBinaryDecoder nodeBinaryEncoder) (unwrapNode_Child v.child)
+        , PB.repeatedFieldEncoder 3 (PB.embedded nodeBinaryDecoder nodeBinaryEncoder) (unwrapNode_Children v.children)
+        , PB.mapEntriesFieldEncoder 4 PB.string (PB.embedded nodeBinaryDecoder nodeBinaryEncoder) (unwrapNode_Named v.named)
+        , node_ChoiceBinaryEncoder v.choice
+        , PB.optionalEncoder 5 (PB.embedded nodeBinaryDecoder nodeBinaryEncoder) (unwrapNode_MaybeNext v.maybeNext)
+        ]
+
+
+type Node_Child
+    = Node_Child (Maybe Node)
+
+
+unwrapNode_Child : Node_Child -> Maybe Node
+unwrapNode_Child (Node_Child v) =
+    v
+
+
+type Node_Children
+    = Node_Children (List Node)
+
+
+unwrapNode_Children : Node_Children -> List Node
+unwrapNode_Children (Node_Children v) =
+    v
+
+
+type Node_Named
+    = Node_Named (Dict.Dict String Node)
+
+
+unwrapNode_Named : Node_Named -> Dict.Dict String Node
+unwrapNode_Named (Node_Named v) =
+    v
+
+
+type Node_MaybeNext
+    = Node_MaybeNext (Maybe Node)
+
+
+unwrapNode_MaybeNext : Node_MaybeNext -> Maybe Node
+unwrapNode_MaybeNext (Node_MaybeNext v) =
+    v
+
+
+type Node_Choice
+    = Node_ChoiceUnspecified
+    | Node_Other Node
+    | Node_Text String
+
+
+node_ChoiceDecoder : JD.Decoder Node_Choice
+node_ChoiceDecoder =
+    JD.lazy <|
+        \_ ->
+            JD.oneOf
+                [ JD.map Node_Other (JD.field "other" nodeDecoder)
+                , JD.map Node_Text (JD.field "text" JD.string)
+                , JD.succeed Node_ChoiceUnspecified
+                ]
+
+
+node_ChoiceEncoder : Node_Choice -> Maybe ( String, JE.Value )
+node_ChoiceEncoder v =
+    case v of
+        Node_ChoiceUnspecified ->
+            Nothing
+
+        Node_Other x ->
+            Just ( "other", nodeEncoder x )
+
+        Node_Text x ->
+            Just ( "text", JE.string x )
+
+
+node_ChoiceBinaryDecoder : PB.Decoder Node_Choice
+node_ChoiceBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.oneOf Node_ChoiceUnspecified
+                [ PB.variant 6 Node_Other (PB.embedded nodeBinaryDecoder nodeBinaryEncoder)
+                , PB.variant 7 Node_Text PB.string
+                ]
+
+
+node_ChoiceBinaryEncoder : Node_Choice -> PB.FieldEncoder
+node_ChoiceBinaryEncoder v =
+    case v of
+        Node_ChoiceUnspecified ->
+            PB.noFieldEncoder
+
+        Node_Other x ->
+            PB.fieldEncoder 6 (PB.embedded nodeBinaryDecoder nodeBinaryEncoder) x
+
+        Node_Text x ->
+            PB.fieldEncoder 7 PB.string x
+
+
+type alias Node_NamedEntry =
+    { key : String -- 1
+    , value : Maybe Node -- 2
+    }
+
+
+node_NamedEntryDecoder : JD.Decoder Node_NamedEntry
+node_NamedEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Node_NamedEntry
+                |> required "key" JD.string ""
+                |> optional "value" nodeDecoder
+
+
+node_NamedEntryEncoder : Node_NamedEntry -> JE.Value
+node_NamedEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.string "" v.key
+            , optionalEncoder "value" nodeEncoder v.value
+            ]
+
+
+node_NamedEntryBinaryDecoder : PB.Decoder Node_NamedEntry
+node_NamedEntryBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Node_NamedEntry
+                |> PB.required 1 PB.string
+                |> PB.optional 2 (PB.embedded nodeBinaryDecoder nodeBinaryEncoder)
+
+
+node_NamedEntryBinaryEncoder : Node_NamedEntry -> PB.Encoder
+node_NamedEntryBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.string v.key
+        , PB.optionalEncoder 2 (PB.embedded nodeBinaryDecoder nodeBinaryEncoder) v.value
+        ]
+
+
+type alias Tree =
+    { root : Tree_Root -- 1
+    }
+
+
+treeDecoder : JD.Decoder Tree
+treeDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Tree
+                |> wrapped Tree_Root (optional "root" tree_BranchDecoder)
+
+
+treeEncoder : Tree -> JE.Value
+treeEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "root" tree_BranchEncoder (unwrapTree_Root v.root)
+            ]
+
+
+treeBinaryDecoder : PB.Decoder Tree
+treeBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Tree
+                |> PB.wrapped Tree_Root (PB.optional 1 (PB.embedded tree_BranchBinaryDecoder tree_BranchBinaryEncoder))
+
+
+treeBinaryEncoder : Tree -> PB.Encoder
+treeBinaryEncoder v =
+    PB.encode
+        [ PB.optionalEncoder 1 (PB.embedded tree_BranchBinaryDecoder tree_BranchBinaryEncoder) (unwrapTree_Root v.root)
+        ]
+
+
+type Tree_Root
+    = Tree_Root (Maybe Tree_Branch)
+
+
+unwrapTree_Root : Tree_Root -> Maybe Tree_Branch
+unwrapTree_Root (Tree_Root v) =
+    v
+
+
+type alias Tree_Branch =
+    { subtrees : Tree_Branch_Subtrees -- 1
+    , leaf : Maybe Leaf -- 2
+    }
+
+
+tree_BranchDecoder : JD.Decoder Tree_Branch
+tree_BranchDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Tree_Branch
+                |> wrapped Tree_Branch_Subtrees (repeated "subtrees" treeDecoder)
+                |> optional "leaf" leafDecoder
+
+
+tree_BranchEncoder : Tree_Branch -> JE.Value
+tree_BranchEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ repeatedFieldEncoder "subtrees" treeEncoder (unwrapTree_Branch_Subtrees v.subtrees)
+            , optionalEncoder "leaf" leafEncoder v.leaf
+            ]
+
+
+tree_BranchBinaryDecoder : PB.Decoder Tree_Branch
+tree_BranchBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Tree_Branch
+                |> PB.wrapped Tree_Branch_Subtrees (PB.repeated 1 (PB.embedded treeBinaryDecoder treeBinaryEncoder))
+                |> PB.optional 2 (PB.embedded leafBinaryDecoder leafBinaryEncoder)
+
+
+tree_BranchBinaryEncoder : Tree_Branch -> PB.Encoder
+tree_BranchBinaryEncoder v =
+    PB.encode
+        [ PB.repeatedFieldEncoder 1 (PB.embedded treeBinaryDecoder treeBinaryEncoder) (unwrapTree_Branch_Subtrees v.subtrees)
+        , PB.optionalEncoder 2 (PB.embedded leafBinaryDecoder leafBinaryEncoder) v.leaf
+        ]
+
+
+type Tree_Branch_Subtrees
+    = Tree_Branch_Subtrees (List Tree)
+
+
+unwrapTree_Branch_Subtrees : Tree_Branch_Subtrees -> List Tree
+unwrapTree_Branch_Subtrees (Tree_Branch_Subtrees v) =
+    v
+
+
+type alias Leaf =
+    { value : String -- 1
+    }
+
+
+leafDecoder : JD.Decoder Leaf
+leafDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Leaf
+                |> required "value" JD.string ""
+
+
+leafEncoder : Leaf -> JE.Value
+leafEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "value" JE.string "" v.value
+            ]
+
+
+leafBinaryDecoder : PB.Decoder Leaf
+leafBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Leaf
+                |> PB.required 1 PB.string
+
+
+leafBinaryEncoder : Leaf -> PB.Encoder
+leafBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.string v.value
+        ]
+
+
+type alias Forest =
+    { trees : List Tree -- 1
+    }
+
+
+forestDecoder : JD.Decoder Forest
+forestDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Forest
+                |> repeated "trees" treeDecoder
+
+
+forestEncoder : Forest -> JE.Value
+forestEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ repeatedFieldEncoder "trees" treeEncoder v.trees
+            ]
+
+
+forestBinaryDecoder : PB.Decoder Forest
+forestBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Forest
+                |> PB.repeated 1 (PB.embedded treeBinaryDecoder treeBinaryEncoder)
+
+
+forestBinaryEncoder : Forest -> PB.Encoder
+forestBinaryEncoder v =
+    PB.encode
+        [ PB.repeatedFieldEncoder 1 (PB.embedded treeBinaryDecoder treeBinaryEncoder) v.trees
+        ]
+
+
+type alias Graph =
+    { child : Graph_Child_ -- 1
+    , first : Maybe Graph_Child -- 2
+    }
+
+
+graphDecoder : JD.Decoder Graph
+graphDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Graph
+                |> wrapped Graph_Child_ (optional "child" graphDecoder)
+                |> optional "first" graph_ChildDecoder
+
+
+graphEncoder : Graph -> JE.Value
+graphEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "child" graphEncoder (unwrapGraph_Child_ v.child)
+            , optionalEncoder "first" graph_ChildEncoder v.first
+            ]
+
+
+graphBinaryDecoder : PB.Decoder Graph
+graphBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Graph
+                |> PB.wrapped Graph_Child_ (PB.optional 1 (PB.embedded graphBinaryDecoder graphBinaryEncoder))
+                |> PB.optional 2 (PB.embedded graph_ChildBinaryDecoder graph_ChildBinaryEncoder)
+
+
+graphBinaryEncoder : Graph -> PB.Encoder
+graphBinaryEncoder v =
+    PB.encode
+        [ PB.optionalEncoder 1 (PB.embedded graphBinaryDecoder graphBinaryEncoder) (unwrapGraph_Child_ v.child)
+        , PB.optionalEncoder 2 (PB.embedded graph_ChildBinaryDecoder graph_ChildBinaryEncoder) v.first
+        ]
+
+
+type Graph_Child_
+    = Graph_Child_ (Maybe Graph)
+
+
+unwrapGraph_Child_ : Graph_Child_ -> Maybe Graph
+unwrapGraph_Child_ (Graph_Child_ v) =
+    v
+
+
+{-| Recursive field named after a nested message.
+-}
+type alias Graph_Child =
+    { id : String -- 1
+    }
+
+
+graph_ChildDecoder : JD.Decoder Graph_Child
+graph_ChildDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Graph_Child
+                |> required "id" JD.string ""
+
+
+graph_ChildEncoder : Graph_Child -> JE.Value
+graph_ChildEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "id" JE.string "" v.id
+            ]
+
+
+graph_ChildBinaryDecoder : PB.Decoder Graph_Child
+graph_ChildBinaryDecoder =
+    PB.lazy <|
+        \_ ->
+            PB.decode Graph_Child
+                |> PB.required 1 PB.string
+
+
+graph_ChildBinaryEncoder : Graph_Child -> PB.Encoder
+graph_ChildBinaryEncoder v =
+    PB.encode
+        [ PB.requiredFieldEncoder 1 PB.string v.id
+        ]
@@ -1,17 +1,13 @@
-module Repeated exposing (..)
+module Repeated exposing (Enum(..), Foo, FooRepeated, Foo_NestedEnum(..), Foo_NestedMessage, Foo_NestedMessage_NestedNestedMessage, SubMessage, enumDecoder, enumDefault, enumEncoder, fooDecoder, fooEncoder, fooRepeatedDecoder, fooRepeatedEncoder, foo_NestedEnumDecoder, foo_NestedEnumDefault, foo_NestedEnumEncoder, foo_NestedMessageDecoder, foo_NestedMessageEncoder, foo_NestedMessage_NestedNestedMessageDecoder, foo_NestedMessage_NestedNestedMessageEncoder, subMessageDecoder, subMessageEncoder)
 
 -- DO NOT EDIT
 -- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
 -- https://github.com/tiziano88/elm-protobuf
 -- source file: repeated.proto
 
-import Protobuf exposing (..)
-
 import Json.Decode as JD
 import Json.Encode as JE
-
-
-uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+import Protobuf exposing (..)
 
 
 type Enum
@@ -19,6 +15,7 @@ type Enum
     | EnumValue1 -- 1
     | EnumValue2 -- 2
     | EnumValue123 -- 123
+    | EnumUnrecognized_ Int
 
 
 enumDecoder : JD.Decoder Enum
@@ -40,12 +37,30 @@ enumDecoder =
 
                 _ ->
                     EnumValueDefault
+
+        fromNumber n =
+            case n of
+                0 ->
+                    EnumValueDefault
+
+                1 ->
+                    EnumValue1
+
+                2 ->
+                    EnumValue2
+
+                123 ->
+                    EnumValue123
+
+                _ ->
+                    EnumUnrecognized_ n
     in
-        JD.map lookup JD.string
+    JD.oneOf [ JD.map lookup JD.string, JD.map fromNumber JD.int ]
 
 
 enumDefault : Enum
-enumDefault = EnumValueDefault
+enumDefault =
+    EnumValueDefault
 
 
 enumEncoder : Enum -> JE.Value
@@ -54,19 +69,21 @@ enumEncoder v =
         lookup s =
             case s of
                 EnumValueDefault ->
-                    "ENUM_VALUE_DEFAULT"
+                    JE.string "ENUM_VALUE_DEFAULT"
 
                 EnumValue1 ->
-                    "ENUM_VALUE_1"
+                    JE.string "ENUM_VALUE_1"
 
                 EnumValue2 ->
-                    "ENUM_VALUE_2"
+                    JE.string "ENUM_VALUE_2"
 
                 EnumValue123 ->
-                    "ENUM_VALUE_123"
+                    JE.string "ENUM_VALUE_123"
 
+                EnumUnrecognized_ n ->
+                    JE.int n
     in
-        JE.string <| lookup v
+    lookup v
 
 
 type alias SubMessage =
@@ -76,15 +93,18 @@ type alias SubMessage =
 
 subMessageDecoder : JD.Decoder SubMessage
 subMessageDecoder =
-    JD.lazy <| \_ -> decode SubMessage
-        |> required "int32Field" intDecoder 0
+    JD.lazy <|
+        \_ ->
+            decode SubMessage
+                |> required "int32Field" intDecoder 0
 
 
 subMessageEncoder : SubMessage -> JE.Value
 subMessageEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "int32Field" JE.int 0 v.int32Field)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "int32Field" JE.int 0 v.int32Field
+            ]
 
 
 type alias Foo =
@@ -113,57 +133,61 @@ type alias Foo =
 
 fooDecoder : JD.Decoder Foo
 fooDecoder =
-    JD.lazy <| \_ -> decode Foo
-        |> required "doubleField" JD.float 0.0
-        |> required "floatField" JD.float 0.0
-        |> required "int32Field" intDecoder 0
-        |> required "int64Field" intDecoder 0
-        |> required "uint32Field" intDecoder 0
-        |> required "uint64Field" intDecoder 0
-        |> required "sint32Field" intDecoder 0
-        |> required "sint64Field" intDecoder 0
-        |> required "fixed32Field" intDecoder 0
-        |> required "fixed64Field" intDecoder 0
-        |> required "sfixed32Field" intDecoder 0
-        |> required "sfixed64Field" intDecoder 0
-        |> required "boolField" JD.bool False
-        |> required "stringField" JD.string ""
-        |> required "enumField" enumDecoder enumDefault
-        |> optional "subMessage" subMessageDecoder
-        |> repeated "repeatedInt64Field" intDecoder
-        |> repeated "repeatedEnumField" enumDecoder
-        |> optional "nestedMessageField" foo_NestedMessageDecoder
-        |> required "nestedEnumField" foo_NestedEnumDecoder foo_NestedEnumDefault
+    JD.lazy <|
+        \_ ->
+            decode Foo
+                |> required "doubleField" JD.float 0.0
+                |> required "floatField" JD.float 0.0
+                |> required "int32Field" intDecoder 0
+                |> required "int64Field" intDecoder 0
+                |> required "uint32Field" intDecoder 0
+                |> required "uint64Field" intDecoder 0
+                |> required "sint32Field" intDecoder 0
+                |> required "sint64Field" intDecoder 0
+                |> required "fixed32Field" intDecoder 0
+                |> required "fixed64Field" intDecoder 0
+                |> required "sfixed32Field" intDecoder 0
+                |> required "sfixed64Field" intDecoder 0
+                |> required "boolField" JD.bool False
+                |> required "stringField" JD.string ""
+                |> required "enumField" enumDecoder enumDefault
+                |> optional "subMessage" subMessageDecoder
+                |> repeated "repeatedInt64Field" intDecoder
+                |> repeated "repeatedEnumField" enumDecoder
+                |> optional "nestedMessageField" foo_NestedMessageDecoder
+                |> required "nestedEnumField" foo_NestedEnumDecoder foo_NestedEnumDefault
 
 
 fooEncoder : Foo -> JE.Value
 fooEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "doubleField" JE.float 0.0 v.doubleField)
-        , (requiredFieldEncoder "floatField" JE.float 0.0 v.floatField)
-        , (requiredFieldEncoder "int32Field" JE.int 0 v.int32Field)
-        , (requiredFieldEncoder "int64Field" numericStringEncoder 0 v.int64Field)
-        , (requiredFieldEncoder "uint32Field" JE.int 0 v.uint32Field)
-        , (requiredFieldEncoder "uint64Field" numericStringEncoder 0 v.uint64Field)
-        , (requiredFieldEncoder "sint32Field" JE.int 0 v.sint32Field)
-        , (requiredFieldEncoder "sint64Field" numericStringEncoder 0 v.sint64Field)
-        , (requiredFieldEncoder "fixed32Field" JE.int 0 v.fixed32Field)
-        , (requiredFieldEncoder "fixed64Field" numericStringEncoder 0 v.fixed64Field)
-        , (requiredFieldEncoder "sfixed32Field" JE.int 0 v.sfixed32Field)
-        , (requiredFieldEncoder "sfixed64Field" numericStringEncoder 0 v.sfixed64Field)
-        , (requiredFieldEncoder "boolField" JE.bool False v.boolField)
-        , (requiredFieldEncoder "stringField" JE.string "" v.stringField)
-        , (requiredFieldEncoder "enumField" enumEncoder enumDefault v.enumField)
-        , (optionalEncoder "subMessage" subMessageEncoder v.subMessage)
-        , (repeatedFieldEncoder "repeatedInt64Field" numericStringEncoder v.repeatedInt64Field)
-        , (repeatedFieldEncoder "repeatedEnumField" enumEncoder v.repeatedEnumField)
-        , (optionalEncoder "nestedMessageField" foo_NestedMessageEncoder v.nestedMessageField)
-        , (requiredFieldEncoder "nestedEnumField" foo_NestedEnumEncoder foo_NestedEnumDefault v.nestedEnumField)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "doubleField" JE.float 0.0 v.doubleField
+            , requiredFieldEncoder "floatField" JE.float 0.0 v.floatField
+            , requiredFieldEncoder "int32Field" JE.int 0 v.int32Field
+            , requiredFieldEncoder "int64Field" numericStringEncoder 0 v.int64Field
+            , requiredFieldEncoder "uint32Field" JE.int 0 v.uint32Field
+            , requiredFieldEncoder "uint64Field" numericStringEncoder 0 v.uint64Field
+            , requiredFieldEncoder "sint32Field" JE.int 0 v.sint32Field
+            , requiredFieldEncoder "sint64Field" numericStringEncoder 0 v.sint64Field
+            , requiredFieldEncoder "fixed32Field" JE.int 0 v.fixed32Field
+            , requiredFieldEncoder "fixed64Field" numericStringEncoder 0 v.fixed64Field
+            , requiredFieldEncoder "sfixed32Field" JE.int 0 v.sfixed32Field
+            , requiredFieldEncoder "sfixed64Field" numericStringEncoder 0 v.sfixed64Field
+            , requiredFieldEncoder "boolField" JE.bool False v.boolField
+            , requiredFieldEncoder "stringField" JE.string "" v.stringField
+            , requiredFieldEncoder "enumField" enumEncoder enumDefault v.enumField
+            , optionalEncoder "subMessage" subMessageEncoder v.subMessage
+            , repeatedFieldEncoder "repeatedInt64Field" numericStringEncoder v.repeatedInt64Field
+            , repeatedFieldEncoder "repeatedEnumField" enumEncoder v.repeatedEnumField
+            , optionalEncoder "nestedMessageField" foo_NestedMessageEncoder v.nestedMessageField
+            , requiredFieldEncoder "nestedEnumField" foo_NestedEnumEncoder foo_NestedEnumDefault v.nestedEnumField
+            ]
 
 
 type Foo_NestedEnum
     = Foo_EnumValueDefault -- 0
+    | Foo_NestedEnumUnrecognized_ Int
 
 
 foo_NestedEnumDecoder : JD.Decoder Foo_NestedEnum
@@ -176,12 +200,21 @@ foo_NestedEnumDecoder =
 
                 _ ->
                     Foo_EnumValueDefault
+
+        fromNumber n =
+            case n of
+                0 ->
+                    Foo_EnumValueDefault
+
+                _ ->
+                    Foo_NestedEnumUnrecognized_ n
     in
-        JD.map lookup JD.string
+    JD.oneOf [ JD.map lookup JD.string, JD.map fromNumber JD.int ]
 
 
 foo_NestedEnumDefault : Foo_NestedEnum
-foo_NestedEnumDefault = Foo_EnumValueDefault
+foo_NestedEnumDefault =
+    Foo_EnumValueDefault
 
 
 foo_NestedEnumEncoder : Foo_NestedEnum -> JE.Value
@@ -190,10 +223,12 @@ foo_NestedEnumEncoder v =
         lookup s =
             case s of
                 Foo_EnumValueDefault ->
-                    "ENUM_VALUE_DEFAULT"
+                    JE.string "ENUM_VALUE_DEFAULT"
 
+                Foo_NestedEnumUnrecognized_ n ->
+                    JE.int n
     in
-        JE.string <| lookup v
+    lookup v
 
 
 type alias Foo_NestedMessage =
@@ -203,15 +238,18 @@ type alias Foo_NestedMessage =
 
 foo_NestedMessageDecoder : JD.Decoder Foo_NestedMessage
 foo_NestedMessageDecoder =
-    JD.lazy <| \_ -> decode Foo_NestedMessage
-        |> required "int32Field" intDecoder 0
+    JD.lazy <|
+        \_ ->
+            decode Foo_NestedMessage
+                |> required "int32Field" intDecoder 0
 
 
 foo_NestedMessageEncoder : Foo_NestedMessage -> JE.Value
 foo_NestedMessageEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "int32Field" JE.int 0 v.int32Field)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "int32Field" JE.int 0 v.int32Field
+            ]
 
 
 type alias Foo_NestedMessage_NestedNestedMessage =
@@ -221,15 +259,18 @@ type alias Foo_NestedMessage_NestedNestedMessage =
 
 foo_NestedMessage_NestedNestedMessageDecoder : JD.Decoder Foo_NestedMessage_NestedNestedMessage
 foo_NestedMessage_NestedNestedMessageDecoder =
-    JD.lazy <| \_ -> decode Foo_NestedMessage_NestedNestedMessage
-        |> required "int32Field" intDecoder 0
+    JD.lazy <|
+        \_ ->
+            decode Foo_NestedMessage_NestedNestedMessage
+                |> required "int32Field" intDecoder 0
 
 
 foo_NestedMessage_NestedNestedMessageEncoder : Foo_NestedMessage_NestedNestedMessage -> JE.Value
 foo_NestedMessage_NestedNestedMessageEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (requiredFieldEncoder "int32Field" JE.int 0 v.int32Field)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "int32Field" JE.int 0 v.int32Field
+            ]
 
 
 type alias FooRepeated =
@@ -254,42 +295,45 @@ type alias FooRepeated =
 
 fooRepeatedDecoder : JD.Decoder FooRepeated
 fooRepeatedDecoder =
-    JD.lazy <| \_ -> decode FooRepeated
-        |> repeated "doubleField" JD.float
-        |> repeated "floatField" JD.float
-        |> repeated "int32Field" intDecoder
-        |> repeated "int64Field" intDecoder
-        |> repeated "uint32Field" intDecoder
-        |> repeated "uint64Field" intDecoder
-        |> repeated "sint32Field" intDecoder
-        |> repeated "sint64Field" intDecoder
-        |> repeated "fixed32Field" intDecoder
-        |> repeated "fixed64Field" intDecoder
-        |> repeated "sfixed32Field" intDecoder
-        |> repeated "sfixed64Field" intDecoder
-        |> repeated "boolField" JD.bool
-        |> repeated "stringField" JD.string
-        |> repeated "enumField" enumDecoder
-        |> repeated "subMessage" subMessageDecoder
+    JD.lazy <|
+        \_ ->
+            decode FooRepeated
+                |> repeated "doubleField" JD.float
+                |> repeated "floatField" JD.float
+                |> repeated "int32Field" intDecoder
+                |> repeated "int64Field" intDecoder
+                |> repeated "uint32Field" intDecoder
+                |> repeated "uint64Field" intDecoder
+                |> repeated "sint32Field" intDecoder
+                |> repeated "sint64Field" intDecoder
+                |> repeated "fixed32Field" intDecoder
+                |> repeated "fixed64Field" intDecoder
+                |> repeated "sfixed32Field" intDecoder
+                |> repeated "sfixed64Field" intDecoder
+                |> repeated "boolField" JD.bool
+                |> repeated "stringField" JD.string
+                |> repeated "enumField" enumDecoder
+                |> repeated "subMessage" subMessageDecoder
 
 
 fooRepeatedEncoder : FooRepeated -> JE.Value
 fooRepeatedEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (repeatedFieldEncoder "doubleField" JE.float v.doubleField)
-        , (repeatedFieldEncoder "floatField" JE.float v.floatField)
-        , (repeatedFieldEncoder "int32Field" JE.int v.int32Field)
-        , (repeatedFieldEncoder "int64Field" numericStringEncoder v.int64Field)
-        , (repeatedFieldEncoder "uint32Field" JE.int v.uint32Field)
-        , (repeatedFieldEncoder "uint64Field" numericStringEncoder v.uint64Field)
-        , (repeatedFieldEncoder "sint32Field" JE.int v.sint32Field)
-        , (repeatedFieldEncoder "sint64Field" numericStringEncoder v.sint64Field)
-        , (repeatedFieldEncoder "fixed32Field" JE.int v.fixed32Field)
-        , (repeatedFieldEncoder "fixed64Field" numericStringEncoder v.fixed64Field)
-        , (repeatedFieldEncoder "sfixed32Field" JE.int v.sfixed32Field)
-        , (repeatedFieldEncoder "sfixed64Field" numericStringEncoder v.sfixed64Field)
-        , (repeatedFieldEncoder "boolField" JE.bool v.boolField)
-        , (repeatedFieldEncoder "stringField" JE.string v.stringField)
-        , (repeatedFieldEncoder "enumField" enumEncoder v.enumField)
-        , (repeatedFieldEncoder "subMessage" subMessageEncoder v.subMessage)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ repeatedFieldEncoder "doubleField" JE.float v.doubleField
+            , repeatedFieldEncoder "floatField" JE.float v.floatField
+            , repeatedFieldEncoder "int32Field" JE.int v.int32Field
+            , repeatedFieldEncoder "int64Field" numericStringEncoder v.int64Field
+            , repeatedFieldEncoder "uint32Field" JE.int v.uint32Field
+            , repeatedFieldEncoder "uint64Field" numericStringEncoder v.uint64Field
+            , repeatedFieldEncoder "sint32Field" JE.int v.sint32Field
+            , repeatedFieldEncoder "sint64Field" numericStringEncoder v.sint64Field
+            , repeatedFieldEncoder "fixed32Field" JE.int v.fixed32Field
+            , repeatedFieldEncoder "fixed64Field" numericStringEncoder v.fixed64Field
+            , repeatedFieldEncoder "sfixed32Field" JE.int v.sfixed32Field
+            , repeatedFieldEncoder "sfixed64Field" numericStringEncoder v.sfixed64Field
+            , repeatedFieldEncoder "boolField" JE.bool v.boolField
+            , repeatedFieldEncoder "stringField" JE.string v.stringField
+            , repeatedFieldEncoder "enumField" enumEncoder v.enumField
+            , repeatedFieldEncoder "subMessage" subMessageEncoder v.subMessage
+            ]
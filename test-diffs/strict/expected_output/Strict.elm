@@ -0,0 +1,185 @@
+module Strict exposing (Account, Account_Contact(..), Account_LimitsEntry, Account_Parent(..), Empty, Status(..), accountDecoder, accountEncoder, account_ContactDecoder, account_ContactEncoder, account_LimitsEntryDecoder, account_LimitsEntryEncoder, emptyDecoder, emptyEncoder, statusDecoder, statusDefault, statusEncoder, unwrapAccount_Parent)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: strict.proto
+
+import Dict
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+
+
+type Status
+    = StatusUnspecified -- 0
+    | Active -- 1
+    | StatusUnrecognized_ Int
+
+
+statusDecoder : JD.Decoder Status
+statusDecoder =
+    let
+        lookup s =
+            case s of
+                "STATUS_UNSPECIFIED" ->
+                    JD.succeed StatusUnspecified
+
+                "ACTIVE" ->
+                    JD.succeed Active
+
+                _ ->
+                    JD.fail ("unknown value \"" ++ s ++ "\"")
+
+        fromNumber n =
+            case n of
+                0 ->
+                    StatusUnspecified
+
+                1 ->
+                    Active
+
+                _ ->
+                    StatusUnrecognized_ n
+    in
+    JD.oneOf [ JD.andThen lookup JD.string, JD.map fromNumber JD.int ]
+
+
+statusDefault : Status
+statusDefault =
+    StatusUnspecified
+
+
+statusEncoder : Status -> JE.Value
+statusEncoder v =
+    let
+        lookup s =
+            case s of
+                StatusUnspecified ->
+                    JE.string "STATUS_UNSPECIFIED"
+
+                Active ->
+                    JE.string "ACTIVE"
+
+                StatusUnrecognized_ n ->
+                    JE.int n
+    in
+    lookup v
+
+
+type alias Account =
+    { name : String -- 1
+    , status : Status -- 2
+    , tags : List String -- 3
+    , limits : Dict.Dict String Int -- 4
+    , parent : Account_Parent -- 5
+    , nickname : Maybe String -- 6
+    , metadata : Maybe Protobuf.Value -- 9
+    , contact : Account_Contact
+    }
+
+
+accountDecoder : JD.Decoder Account
+accountDecoder =
+    JD.lazy <|
+        \_ ->
+            strictDecode Account
+                |> strictRequired "name" JD.string ""
+                |> strictRequired "status" statusDecoder statusDefault
+                |> strictRepeated "tags" JD.string
+                |> strictMapEntries "limits" intDecoder
+                |> wrapped Account_Parent (strictOptional "parent" accountDecoder)
+                |> strictOptional "nickname" stringValueDecoder
+                |> strictOptional "metadata" Protobuf.valueDecoder
+                |> field account_ContactDecoder
+
+
+accountEncoder : Account -> JE.Value
+accountEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "name" JE.string "" v.name
+            , requiredFieldEncoder "status" statusEncoder statusDefault v.status
+            , repeatedFieldEncoder "tags" JE.string v.tags
+            , mapEntriesFieldEncoder "limits" JE.int v.limits
+            , optionalEncoder "parent" accountEncoder (unwrapAccount_Parent v.parent)
+            , optionalEncoder "nickname" stringValueEncoder v.nickname
+            , optionalEncoder "metadata" Protobuf.valueEncoder v.metadata
+            , account_ContactEncoder v.contact
+            ]
+
+
+type Account_Parent
+    = Account_Parent (Maybe Account)
+
+
+unwrapAccount_Parent : Account_Parent -> Maybe Account
+unwrapAccount_Parent (Account_Parent v) =
+    v
+
+
+type Account_Contact
+    = Account_ContactUnspecified
+    | Account_Email String
+    | Account_Phone String
+
+
+account_ContactDecoder : JD.Decoder Account_Contact
+account_ContactDecoder =
+    JD.lazy <|
+        \_ ->
+            strictOneOf Account_ContactUnspecified
+                [ ( "email", JD.map Account_Email JD.string )
+                , ( "phone", JD.map Account_Phone JD.string )
+                ]
+
+
+account_ContactEncoder : Account_Contact -> Maybe ( String, JE.Value )
+account_ContactEncoder v =
+    case v of
+        Account_ContactUnspecified ->
+            Nothing
+
+        Account_Email x ->
+            Just ( "email", JE.string x )
+
+        Account_Phone x ->
+            Just ( "phone", JE.string x )
+
+
+type alias Account_LimitsEntry =
+    { key : String -- 1
+    , value : Int -- 2
+    }
+
+
+account_LimitsEntryDecoder : JD.Decoder Account_LimitsEntry
+account_LimitsEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            strictDecode Account_LimitsEntry
+                |> strictRequired "key" JD.string ""
+                |> strictRequired "value" intDecoder 0
+
+
+account_LimitsEntryEncoder : Account_LimitsEntry -> JE.Value
+account_LimitsEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.string "" v.key
+            , requiredFieldEncoder "value" JE.int 0 v.value
+            ]
+
+
+type alias Empty =
+    {}
+
+
+emptyDecoder : JD.Decoder Empty
+emptyDecoder =
+    JD.lazy <| \_ -> strictDecode Empty
+
+
+emptyEncoder : Empty -> JE.Value
+emptyEncoder v =
+    JE.object <| List.filterMap identity <| []
@@ -0,0 +1,43 @@
+module Struct_json exposing (Metadata, metadataDecoder, metadataEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: struct_json.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+
+
+type alias Metadata =
+    { structField : Maybe JE.Value -- 1
+    , valueField : Maybe JE.Value -- 2
+    , listValueField : Maybe JE.Value -- 3
+    , nullValueField : () -- 4
+    , valueList : List JE.Value -- 5
+    }
+
+
+metadataDecoder : JD.Decoder Metadata
+metadataDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Metadata
+                |> optional "structField" jsonStructDecoder
+                |> optional "valueField" jsonValueDecoder
+                |> optional "listValueField" jsonListValueDecoder
+                |> required "nullValueField" Protobuf.nullValueDecoder ()
+                |> repeated "valueList" jsonValueDecoder
+
+
+metadataEncoder : Metadata -> JE.Value
+metadataEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "structField" jsonValueEncoder v.structField
+            , optionalEncoder "valueField" jsonValueEncoder v.valueField
+            , optionalEncoder "listValueField" jsonValueEncoder v.listValueField
+            , requiredFieldEncoder "nullValueField" Protobuf.nullValueEncoder () v.nullValueField
+            , repeatedFieldEncoder "valueList" jsonValueEncoder v.valueList
+            ]
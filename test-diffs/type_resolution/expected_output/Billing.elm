@@ -0,0 +1,73 @@
+module Billing exposing (Invoice, LineItem, Line_item_Detail, invoiceDecoder, invoiceEncoder, lineItemDecoder, lineItemEncoder, line_item_DetailDecoder, line_item_DetailEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: billing.proto
+
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+
+
+type alias Invoice =
+    { item : Maybe LineItem -- 1
+    }
+
+
+invoiceDecoder : JD.Decoder Invoice
+invoiceDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Invoice
+                |> optional "item" lineItemDecoder
+
+
+invoiceEncoder : Invoice -> JE.Value
+invoiceEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "item" lineItemEncoder v.item
+            ]
+
+
+type alias LineItem =
+    { name : String -- 1
+    }
+
+
+lineItemDecoder : JD.Decoder LineItem
+lineItemDecoder =
+    JD.lazy <|
+        \_ ->
+            decode LineItem
+                |> required "name" JD.string ""
+
+
+lineItemEncoder : LineItem -> JE.Value
+lineItemEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "name" JE.string "" v.name
+            ]
+
+
+type alias Line_item_Detail =
+    { description : String -- 1
+    }
+
+
+line_item_DetailDecoder : JD.Decoder Line_item_Detail
+line_item_DetailDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Line_item_Detail
+                |> required "description" JD.string ""
+
+
+line_item_DetailEncoder : Line_item_Detail -> JE.Value
+line_item_DetailEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "description" JE.string "" v.description
+            ]
@@ -0,0 +1,148 @@
+module Type_resolution exposing (Order, Order_Invoice, Order_InvoicesEntry, Status(..), orderDecoder, orderEncoder, order_InvoiceDecoder, order_InvoiceEncoder, order_InvoicesEntryDecoder, order_InvoicesEntryEncoder, statusDecoder, statusDefault, statusEncoder)
+
+-- DO NOT EDIT
+-- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
+-- https://github.com/tiziano88/elm-protobuf
+-- source file: type_resolution.proto
+
+import Billing
+import Dict
+import Json.Decode as JD
+import Json.Encode as JE
+import Protobuf exposing (..)
+
+
+type Status
+    = StatusUnspecified -- 0
+    | StatusPaid -- 1
+    | StatusUnrecognized_ Int
+
+
+statusDecoder : JD.Decoder Status
+statusDecoder =
+    let
+        lookup s =
+            case s of
+                "STATUS_UNSPECIFIED" ->
+                    StatusUnspecified
+
+                "STATUS_PAID" ->
+                    StatusPaid
+
+                _ ->
+                    StatusUnspecified
+
+        fromNumber n =
+            case n of
+                0 ->
+                    StatusUnspecified
+
+                1 ->
+                    StatusPaid
+
+                _ ->
+                    StatusUnrecognized_ n
+    in
+    JD.oneOf [ JD.map lookup JD.string, JD.map fromNumber JD.int ]
+
+
+statusDefault : Status
+statusDefault =
+    StatusUnspecified
+
+
+statusEncoder : Status -> JE.Value
+statusEncoder v =
+    let
+        lookup s =
+            case s of
+                StatusUnspecified ->
+                    JE.string "STATUS_UNSPECIFIED"
+
+                StatusPaid ->
+                    JE.string "STATUS_PAID"
+
+                StatusUnrecognized_ n ->
+                    JE.int n
+    in
+    lookup v
+
+
+type alias Order =
+    { invoice : Maybe Billing.Invoice -- 1
+    , item : Maybe Billing.LineItem -- 2
+    , detail : Maybe Billing.Line_item_Detail -- 3
+    , status : Status -- 4
+    , invoices : Dict.Dict String Order_Invoice -- 5
+    }
+
+
+orderDecoder : JD.Decoder Order
+orderDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Order
+                |> optional "invoice" Billing.invoiceDecoder
+                |> optional "item" Billing.lineItemDecoder
+                |> optional "detail" Billing.line_item_DetailDecoder
+                |> required "status" statusDecoder statusDefault
+                |> mapEntries "invoices" order_InvoiceDecoder
+
+
+orderEncoder : Order -> JE.Value
+orderEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "invoice" Billing.invoiceEncoder v.invoice
+            , optionalEncoder "item" Billing.lineItemEncoder v.item
+            , optionalEncoder "detail" Billing.line_item_DetailEncoder v.detail
+            , requiredFieldEncoder "status" statusEncoder statusDefault v.status
+            , mapEntriesFieldEncoder "invoices" order_InvoiceEncoder v.invoices
+            ]
+
+
+type alias Order_InvoicesEntry =
+    { key : String -- 1
+    , value : Maybe Order_Invoice -- 2
+    }
+
+
+order_InvoicesEntryDecoder : JD.Decoder Order_InvoicesEntry
+order_InvoicesEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Order_InvoicesEntry
+                |> required "key" JD.string ""
+                |> optional "value" order_InvoiceDecoder
+
+
+order_InvoicesEntryEncoder : Order_InvoicesEntry -> JE.Value
+order_InvoicesEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.string "" v.key
+            , optionalEncoder "value" order_InvoiceEncoder v.value
+            ]
+
+
+{-| Same name as a message in an imported package.
+-}
+type alias Order_Invoice =
+    { number : Int -- 1
+    }
+
+
+order_InvoiceDecoder : JD.Decoder Order_Invoice
+order_InvoiceDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Order_Invoice
+                |> required "number" intDecoder 0
+
+
+order_InvoiceEncoder : Order_Invoice -> JE.Value
+order_InvoiceEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "number" JE.int 0 v.number
+            ]
@@ -1,32 +1,83 @@
-module Well_known_types exposing (..)
+module Well_known_types exposing (Message, Message_ValueMapEntry, messageDecoder, messageEncoder, message_ValueMapEntryDecoder, message_ValueMapEntryEncoder)
 
 -- DO NOT EDIT
 -- AUTOGENERATED BY THE ELM PROTOCOL BUFFER COMPILER
 -- https://github.com/tiziano88/elm-protobuf
 -- source file: well_known_types.proto
 
-import Protobuf exposing (..)
-
+import Dict
 import Json.Decode as JD
 import Json.Encode as JE
-
-
-uselessDeclarationToPreventErrorDueToEmptyOutputFile = 42
+import Protobuf exposing (..)
 
 
 type alias Message =
     { doubleValueField : Maybe Float -- 1
+    , durationField : Maybe Duration -- 2
+    , durationList : List Duration -- 3
+    , structField : Maybe Protobuf.Struct -- 4
+    , valueField : Maybe Protobuf.Value -- 5
+    , listValueField : Maybe Protobuf.ListValue -- 6
+    , nullValueField : () -- 7
+    , valueMap : Dict.Dict String Protobuf.Value -- 8
+    , fieldMaskField : Maybe Protobuf.FieldMask -- 9
+    , emptyField : Maybe Protobuf.Empty -- 10
     }
 
 
 messageDecoder : JD.Decoder Message
 messageDecoder =
-    JD.lazy <| \_ -> decode Message
-        |> optional "doubleValueField" floatValueDecoder
+    JD.lazy <|
+        \_ ->
+            decode Message
+                |> optional "doubleValueField" floatValueDecoder
+                |> optional "durationField" durationDecoder
+                |> repeated "durationList" durationDecoder
+                |> optional "structField" Protobuf.structDecoder
+                |> optional "valueField" Protobuf.valueDecoder
+                |> optional "listValueField" Protobuf.listValueDecoder
+                |> required "nullValueField" Protobuf.nullValueDecoder ()
+                |> mapEntries "valueMap" Protobuf.valueDecoder
+                |> optional "fieldMaskField" Protobuf.fieldMaskDecoder
+                |> optional "emptyField" Protobuf.emptyDecoder
 
 
 messageEncoder : Message -> JE.Value
 messageEncoder v =
-    JE.object <| List.filterMap identity <|
-        [ (optionalEncoder "doubleValueField" floatValueEncoder v.doubleValueField)
-        ]
+    JE.object <|
+        List.filterMap identity <|
+            [ optionalEncoder "doubleValueField" floatValueEncoder v.doubleValueField
+            , optionalEncoder "durationField" durationEncoder v.durationField
+            , repeatedFieldEncoder "durationList" durationEncoder v.durationList
+            , optionalEncoder "structField" Protobuf.structEncoder v.structField
+            , optionalEncoder "valueField" Protobuf.valueEncoder v.valueField
+            , optionalEncoder "listValueField" Protobuf.listValueEncoder v.listValueField
+            , requiredFieldEncoder "nullValueField" Protobuf.nullValueEncoder () v.nullValueField
+            , mapEntriesFieldEncoder "valueMap" Protobuf.valueEncoder v.valueMap
+            , optionalEncoder "fieldMaskField" Protobuf.fieldMaskEncoder v.fieldMaskField
+            , optionalEncoder "emptyField" Protobuf.emptyEncoder v.emptyField
+            ]
+
+
+type alias Message_ValueMapEntry =
+    { key : String -- 1
+    , value : Maybe Protobuf.Value -- 2
+    }
+
+
+message_ValueMapEntryDecoder : JD.Decoder Message_ValueMapEntry
+message_ValueMapEntryDecoder =
+    JD.lazy <|
+        \_ ->
+            decode Message_ValueMapEntry
+                |> required "key" JD.string ""
+                |> optional "value" Protobuf.valueDecoder
+
+
+message_ValueMapEntryEncoder : Message_ValueMapEntry -> JE.Value
+message_ValueMapEntryEncoder v =
+    JE.object <|
+        List.filterMap identity <|
+            [ requiredFieldEncoder "key" JE.string "" v.key
+            , optionalEncoder "value" Protobuf.valueEncoder v.value
+            ]